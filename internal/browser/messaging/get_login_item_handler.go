@@ -6,6 +6,7 @@ package messaging
 import (
 	"encoding/json"
 	"fmt"
+	"time"
 
 	"lucor.dev/paw/internal/agent"
 	"lucor.dev/paw/internal/paw"
@@ -28,11 +29,14 @@ type GetLoginItemHandlerRequestPayload struct {
 	SessionID string `json:"session_id"`
 	Name      string `json:"name"`
 	Type      int    `json:"type"`
+	// OTP requests the one-time password. For HOTP the counter is incremented.
+	OTP bool `json:"otp"`
 }
 
 type GetLoginItemHandlerResponsePayload struct {
 	Username string `json:"username"`
 	Password string `json:"password"`
+	TOTP     string `json:"totp,omitempty"`
 }
 
 // Serve implements browser.Handler.
@@ -73,13 +77,13 @@ func (h *GetLoginItemHandler) Serve(res *Response, req *Request) {
 		return
 	}
 
-	item, err := paw.NewItem(v.Name, paw.ItemType(v.Type))
-	if err != nil {
-		res.Error = fmt.Errorf("unable to make item from data: %w", err)
+	meta, ok := vault.ItemMetadataByName(paw.ItemType(v.Type), v.Name)
+	if !ok {
+		res.Error = fmt.Errorf("unable to find item %q into the vault", v.Name)
 		return
 	}
 
-	item, err = s.LoadItem(vault, item.GetMetadata())
+	item, err := s.LoadItem(vault, meta)
 	if err != nil {
 		res.Error = fmt.Errorf("unable to load item from vault: %w", err)
 		return
@@ -87,5 +91,20 @@ func (h *GetLoginItemHandler) Serve(res *Response, req *Request) {
 
 	login := item.(*paw.Login)
 
-	res.Payload = &GetLoginItemHandlerResponsePayload{Username: login.Username, Password: login.Password.Value}
+	payload := &GetLoginItemHandlerResponsePayload{Username: login.Username, Password: login.Password.Value}
+	if v.OTP && login.TOTP != nil && login.TOTP.Secret != "" {
+		var code string
+		if login.TOTP.IsHOTP() {
+			code, err = paw.NextHOTPCode(s, vault, login)
+		} else {
+			code, err = login.TOTP.Code(time.Now().UTC())
+		}
+		if err != nil {
+			res.Error = fmt.Errorf("unable to generate the one-time password: %w", err)
+			return
+		}
+		payload.TOTP = code
+	}
+
+	res.Payload = payload
 }
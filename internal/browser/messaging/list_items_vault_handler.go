@@ -28,10 +28,16 @@ type ListItemsVaultHandlerRequestPayload struct {
 	SessionID  string `json:"session_id"`
 	FilterName string `json:"filter_name"`
 	FilterType int    `json:"filter_type"`
+	// FilterTags filters the items having all the tags
+	FilterTags []string `json:"filter_tags,omitempty"`
+	// FilterFolder filters the items into the folder or into its subfolders
+	FilterFolder string `json:"filter_folder,omitempty"`
 }
 
 type ListItemsVaultHandlerResponsePayload struct {
 	Items any `json:"items"`
+	// Tags are the tags used by the vault items
+	Tags []string `json:"tags"`
 }
 
 // Serve implements browser.Handler.
@@ -69,7 +75,9 @@ func (h *ListItemsVaultHandler) Serve(res *Response, req *Request) {
 	meta := vault.FilterItemMetadata(&paw.VaultFilterOptions{
 		Name:     v.FilterName,
 		ItemType: paw.ItemType(v.FilterType),
+		Tags:     v.FilterTags,
+		Folder:   v.FilterFolder,
 	})
 
-	res.Payload = &ListItemsVaultHandlerResponsePayload{Items: meta}
+	res.Payload = &ListItemsVaultHandlerResponsePayload{Items: meta, Tags: vault.Tags()}
 }
@@ -12,6 +12,7 @@ import (
 	"strconv"
 	"strings"
 	"text/template"
+	"time"
 
 	"golang.org/x/term"
 
@@ -25,13 +26,31 @@ func Run(args []string, s paw.Storage) {
 	commands := []Cmd{
 		&AgentCmd{},
 		&AddCmd{},
+		&AttachCmd{},
+		&ConflictCmd{},
+		&DockerCredentialCmd{},
 		&EditCmd{},
+		&ExportCmd{},
+		&FsckCmd{},
+		&GetCmd{},
+		&GitCmd{},
+		&GitCredentialCmd{},
+		&HistoryCmd{},
+		&ImportCmd{},
+		&InjectCmd{},
 		&InitCmd{},
 		&ListCmd{},
 		&LockCmd{},
+		&MoveCmd{},
+		&PasswdCmd{},
 		&PwGenCmd{},
 		&RemoveCmd{},
+		&RotateKeyCmd{},
+		&RunCmd{},
 		&ShowCmd{},
+		&SyncCmd{},
+		&TOTPCmd{},
+		&TrashCmd{},
 		&UnlockCmd{},
 		&VersionCmd{},
 	}
@@ -39,7 +58,7 @@ func Run(args []string, s paw.Storage) {
 	// display the usage if no command is specified
 	if len(args) == 2 {
 		Usage(commands)
-		os.Exit(1)
+		os.Exit(errorClassUsage.ExitCode())
 	}
 
 	// check for valid command
@@ -54,7 +73,7 @@ func Run(args []string, s paw.Storage) {
 	// If no valid command is specified display the usage
 	if cmd == nil {
 		Usage(commands)
-		os.Exit(1)
+		os.Exit(errorClassUsage.ExitCode())
 	}
 
 	// Parse the arguments for the command
@@ -62,15 +81,13 @@ func Run(args []string, s paw.Storage) {
 	// and will exit in case of error
 	err := cmd.Parse(args[3:])
 	if err != nil {
-		fmt.Fprintf(os.Stderr, "[✗] %s\n", err)
-		os.Exit(1)
+		exitWithError(err, errorClassUsage)
 	}
 
 	// Finally run the command
 	err = cmd.Run(s)
 	if err != nil {
-		fmt.Fprintf(os.Stderr, "[✗] %s\n", err)
-		os.Exit(1)
+		exitWithError(err, errorClassGeneric)
 	}
 }
 
@@ -93,6 +110,17 @@ The commands are:
 
 {{ range $k, $cmd := . }}	{{ printf "%-13s %s\n" $cmd.Name $cmd.Description }}{{ end }}
 Use "paw cli <command> -help" for more information about a command.
+
+The output format can be set for any command using the --format=text|json
+option. The --template option formats the output using a Go template.
+
+Exit codes:
+	0    Success
+	1    Generic error
+	2    Invalid arguments or options
+	3    Vault, item or file not found
+	4    Invalid password or session
+	5    Item already exists
 `
 	printTemplate(os.Stdout, template, commands)
 }
@@ -129,7 +157,7 @@ func askPasswordWithConfirm() (string, error) {
 		if password == confirm {
 			return password, nil
 		}
-		fmt.Println("[✗] Passwords do not match")
+		fmt.Fprintln(os.Stderr, "[✗] Passwords do not match")
 	}
 }
 
@@ -137,8 +165,9 @@ func askPassword(prompt string) (string, error) {
 	if !term.IsTerminal(int(os.Stdin.Fd())) {
 		return "", fmt.Errorf("standard input is not a terminal")
 	}
-	defer fmt.Println("")
-	fmt.Printf("%s: ", prompt)
+	// prompt to stderr so stdout can be redirected
+	defer fmt.Fprintln(os.Stderr, "")
+	fmt.Fprintf(os.Stderr, "%s: ", prompt)
 	password, err := term.ReadPassword(int(os.Stdin.Fd()))
 	if err != nil {
 		return "", fmt.Errorf("could not read password from standard input: %w", err)
@@ -301,6 +330,50 @@ func parseItemPath(path string, opts itemPathOptions) (itemPath, error) {
 	return ip, nil
 }
 
+// loadItem returns the item identified by the item path loading from the vault
+func loadItem(s paw.Storage, vault *paw.Vault, ip itemPath) (paw.Item, error) {
+	meta, ok := vault.ItemMetadataByName(ip.itemType, ip.itemName)
+	if !ok {
+		return nil, fmt.Errorf("%w: %q", paw.ErrItemNotFound, ip)
+	}
+	return s.LoadItem(vault, meta)
+}
+
+// storeItem stores the modified item and updates the vault and the app state
+func storeItem(s paw.Storage, vault *paw.Vault, item paw.Item) error {
+	appState, err := s.LoadAppState()
+	if err != nil {
+		return err
+	}
+
+	unlock, err := paw.LockVault(s, vault)
+	if err != nil {
+		return err
+	}
+	defer unlock()
+
+	now := time.Now().UTC()
+	item.GetMetadata().Modified = now
+	err = s.StoreItem(vault, item)
+	if err != nil {
+		return err
+	}
+
+	err = vault.AddItem(item)
+	if err != nil {
+		return err
+	}
+
+	vault.Modified = now
+	err = s.StoreVault(vault)
+	if err != nil {
+		return err
+	}
+
+	appState.Modified = now
+	return s.StoreAppState(appState)
+}
+
 // loadVaultKey returns the key to unlock the vault from the storage
 // it will use a session from the PAW_SESSION env variable if set,
 // otherwise will ask for the vault's password
@@ -311,12 +384,12 @@ func loadVaultKey(s paw.Storage, vaultName string) (*paw.Key, error) {
 		if err == nil {
 			return key, nil
 		}
-		fmt.Println("[✗] Session is invalid or expired")
+		fmt.Fprintln(os.Stderr, "[✗] Session is invalid or expired")
 	}
 
 	password, err := askPassword("Enter the vault password")
 	if err != nil {
-		return nil, err
+		return nil, &authError{err: err}
 	}
 	return s.LoadVaultKey(vaultName, password)
 }
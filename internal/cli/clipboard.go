@@ -58,3 +58,12 @@ func writeToClipboard(ctx context.Context, data []byte) error {
 		}
 	}
 }
+
+// readImageFromClipboard returns the PNG encoded image from the clipboard
+func readImageFromClipboard() ([]byte, error) {
+	b := clipboard.Read(clipboard.FmtImage)
+	if b == nil {
+		return nil, fmt.Errorf("clipboard does not contain an image")
+	}
+	return b, nil
+}
@@ -20,3 +20,8 @@ func initClipboard() error {
 func writeToClipboard(ctx context.Context, data []byte) error {
 	return fmt.Errorf("cli clipboard is not supported on this OS")
 }
+
+// readImageFromClipboard returns the PNG encoded image from the clipboard
+func readImageFromClipboard() ([]byte, error) {
+	return nil, fmt.Errorf("cli clipboard is not supported on this OS")
+}
@@ -17,6 +17,10 @@ import (
 type AddCmd struct {
 	itemPath
 	importPath string
+	tags       stringsFlag
+	folder     string
+	fields     stringsFlag
+	values     stringsFlag
 }
 
 // Name returns the one word command name
@@ -35,10 +39,24 @@ func (cmd *AddCmd) Usage() {
 
 {{ . }}
 
+The custom fields are specified as NAME[:TYPE][=VALUE], where TYPE is one of
+text, hidden, url, email, date (YYYY-MM-DD) or totp and defaults to text.
+The value is asked if not specified, i.e. --field="pin:hidden".
+
+The fields of the api_credential, card, identity, license and wifi items can be
+specified as NAME=VALUE using the --set option, i.e. --set expiry=12/30.
+The other fields are not asked when the --set option is specified.
+
 Options:
+      --field=FIELD           Adds a custom field to the item. Can be specified multiple times
+      --folder=PATH           Sets the folder of the item, i.e. work/projects
+      --format=FORMAT         Sets the output format: text, json. Default to text
   -h, --help                  Displays this help and exit
   -i, --input=FILE            Imports the item from file. Only SSH file supported
       --session=SESSION_ID    Sets a session ID to use instead of the env var
+      --set=FIELD             Sets a field of the item. Can be specified multiple times
+      --tag=TAG               Adds a tag to the item. Can be specified multiple times
+      --template=TEMPLATE     Formats the output using the Go template
 `
 	printUsage(template, cmd.Description())
 }
@@ -52,11 +70,15 @@ func (cmd *AddCmd) Parse(args []string) error {
 
 	flagSet.StringVar(&cmd.importPath, "i", "", "")
 	flagSet.StringVar(&cmd.importPath, "input", "", "")
+	flagSet.Var(&cmd.fields, "field", "")
+	flagSet.Var(&cmd.values, "set", "")
+	flagSet.Var(&cmd.tags, "tag", "")
+	flagSet.StringVar(&cmd.folder, "folder", "", "")
 
 	flags.Parse(cmd, args)
 	if len(flagSet.Args()) != 1 {
 		cmd.Usage()
-		os.Exit(1)
+		os.Exit(errorClassUsage.ExitCode())
 	}
 	flags.SetEnv()
 
@@ -91,7 +113,12 @@ func (cmd *AddCmd) Run(s paw.Storage) error {
 	}
 
 	if ok := vault.HasItem(item); ok {
-		return fmt.Errorf("item with same name already exists")
+		return fmt.Errorf("%w: %q", paw.ErrItemAlreadyExists, cmd.itemPath)
+	}
+
+	err = setItemFields(item, cmd.values)
+	if err != nil {
+		return err
 	}
 
 	switch cmd.itemType {
@@ -104,9 +131,33 @@ func (cmd *AddCmd) Run(s paw.Storage) error {
 	case paw.SSHKeyItemType:
 		cmd.addSSHKeyItem(item)
 	default:
-		return fmt.Errorf("unsupported item type: %q", cmd.itemType)
+		v, ok := item.(paw.FieldsItem)
+		if !ok {
+			return fmt.Errorf("unsupported item type: %q", cmd.itemType)
+		}
+		if len(cmd.values) == 0 {
+			err = askItemFields(v)
+			if err != nil {
+				return err
+			}
+		}
+	}
+
+	err = setCustomFields(item, cmd.fields)
+	if err != nil {
+		return err
 	}
 
+	item.GetMetadata().Tags = paw.NormalizeTags(cmd.tags)
+	item.GetMetadata().Folder = paw.NormalizeFolder(cmd.folder)
+
+	// lock once the values have been asked to not block the other processes meanwhile
+	unlock, err := paw.LockVault(s, vault)
+	if err != nil {
+		return err
+	}
+	defer unlock()
+
 	now := time.Now().UTC()
 	err = s.StoreItem(vault, item)
 	if err != nil {
@@ -130,8 +181,7 @@ func (cmd *AddCmd) Run(s paw.Storage) error {
 		return err
 	}
 
-	fmt.Printf("[✓] item %q added\n", cmd.itemName)
-	return nil
+	return printResult(newItemResultOutput(cmd.vaultName, "added", item.GetMetadata()), fmt.Sprintf("item %q added", cmd.itemName))
 }
 
 func (cmd *AddCmd) addLoginItem(key *paw.Key, item paw.Item) error {
@@ -222,8 +272,8 @@ func (cmd *AddCmd) addSSHKeyItem(item paw.Item) error {
 
 	if cmd.importPath != "" {
 		cmd.importSSHKey(v)
-		fmt.Println("The key fingerprint is:")
-		fmt.Println(v.Fingerprint)
+		fmt.Fprintln(os.Stderr, "The key fingerprint is:")
+		fmt.Fprintln(os.Stderr, v.Fingerprint)
 		answer, err := askYesNo("Continue?", true)
 		if err != nil {
 			return err
@@ -241,8 +291,8 @@ func (cmd *AddCmd) addSSHKeyItem(item paw.Item) error {
 		v.PublicKey = string(k.MarshalPublicKey())
 		v.Fingerprint = k.Fingerprint()
 
-		fmt.Println("The key fingerprint is:")
-		fmt.Println(v.Fingerprint)
+		fmt.Fprintln(os.Stderr, "The key fingerprint is:")
+		fmt.Fprintln(os.Stderr, v.Fingerprint)
 	}
 
 	addToAgent, err := askYesNo("Add to SSH Agent?", false)
@@ -273,8 +323,8 @@ func (cmd *AddCmd) importSSHKey(item *paw.SSHKey) error {
 		item.PublicKey = string(sk.MarshalPublicKey())
 		item.Fingerprint = string(sk.Fingerprint())
 
-		fmt.Println("[i] importing SSH key with public key:")
-		fmt.Println(item.PublicKey)
+		fmt.Fprintln(os.Stderr, "[i] importing SSH key with public key:")
+		fmt.Fprintln(os.Stderr, item.PublicKey)
 		return nil
 	}
 	// Check if SSH Key is protected with a passphrase
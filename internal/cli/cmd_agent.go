@@ -5,6 +5,7 @@ package cli
 
 import (
 	"fmt"
+	"io"
 	"os"
 	"text/tabwriter"
 	"time"
@@ -44,7 +45,9 @@ Commands:
   sessions    Show the active sessions
 
 Options:
-  -h, --help  Displays this help and exit
+      --format=FORMAT      Sets the output format: text, json. Default to text
+  -h, --help               Displays this help and exit
+      --template=TEMPLATE  Formats the output using the Go template
 `
 	printUsage(template, cmd.Description())
 }
@@ -59,13 +62,13 @@ func (cmd *AgentCmd) Parse(args []string) error {
 	flags.Parse(cmd, args)
 	if len(flagSet.Args()) != 1 {
 		cmd.Usage()
-		os.Exit(1)
+		os.Exit(errorClassUsage.ExitCode())
 	}
 
 	cmd.command = flagSet.Arg(0)
 	if cmd.command != agentStartSubCmd && cmd.command != agentSessionsSubCmd {
 		cmd.Usage()
-		os.Exit(1)
+		os.Exit(errorClassUsage.ExitCode())
 	}
 
 	return nil
@@ -97,17 +100,28 @@ func (cmd *AgentCmd) Run(s paw.Storage) error {
 			return err
 		}
 
-		if len(sessions) == 0 {
-			fmt.Println("No session found")
-			return nil
-		}
-
-		w := tabwriter.NewWriter(os.Stdout, 0, 0, 1, ' ', tabwriter.Debug)
-		fmt.Fprintln(w, "Session ID\tVault\tLifetime")
+		out := []sessionOutput{}
 		for _, session := range sessions {
-			fmt.Fprintf(w, "%s\t%s\t%s\n", session.ID, session.Vault, session.Lifetime.Round(1*time.Second))
+			out = append(out, newSessionOutput(session))
 		}
-		w.Flush()
+		return printOutput(out, func(w io.Writer) {
+			printSessions(w, sessions)
+		})
 	}
 	return nil
 }
+
+// printSessions prints the agent sessions as a table
+func printSessions(w io.Writer, sessions []agent.Session) {
+	if len(sessions) == 0 {
+		fmt.Fprintln(w, "No session found")
+		return
+	}
+
+	tw := tabwriter.NewWriter(w, 0, 0, 1, ' ', tabwriter.Debug)
+	fmt.Fprintln(tw, "Session ID\tVault\tLifetime")
+	for _, session := range sessions {
+		fmt.Fprintf(tw, "%s\t%s\t%s\n", session.ID, session.Vault, session.Lifetime.Round(1*time.Second))
+	}
+	tw.Flush()
+}
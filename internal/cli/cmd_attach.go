@@ -0,0 +1,261 @@
+// SPDX-FileCopyrightText: 2025 Luca Corbo, Paw contributors
+// SPDX-License-Identifier: AGPL-3.0-or-later
+
+package cli
+
+import (
+	"fmt"
+	"io"
+	"os"
+	"text/tabwriter"
+	"time"
+
+	"lucor.dev/paw/internal/paw"
+)
+
+const (
+	attachAddSubCmd  = "add"
+	attachGetSubCmd  = "get"
+	attachListSubCmd = "ls"
+	attachRmSubCmd   = "rm"
+)
+
+// AttachCmd manages the files attached to an item
+type AttachCmd struct {
+	itemPath
+	command    string
+	file       string
+	name       string
+	outputPath string
+}
+
+// Name returns the one word command name
+func (cmd *AttachCmd) Name() string {
+	return "attach"
+}
+
+// Description returns the command description
+func (cmd *AttachCmd) Description() string {
+	return "Manages the files attached to an item"
+}
+
+// Usage displays the command usage
+func (cmd *AttachCmd) Usage() {
+	template := `Usage: paw cli attach [OPTION] COMMAND VAULT_NAME/ITEM_TYPE/ITEM_NAME [FILE]
+
+{{ . }}
+
+Commands:
+  add        Attaches the FILE to the item. Use - to read from the standard
+             input, the --name option is required in that case
+  get        Prints the content of the attached FILE
+  ls         Lists the files attached to the item
+  rm         Deletes the attached FILE
+
+The attachments are stored encrypted with the vault key. The maximum size of
+an attachment is 10 MiB.
+
+Options:
+      --format=FORMAT         Sets the output format of ls: text, json. Default to text
+  -h, --help                  Displays this help and exit
+      --name=NAME             Sets the name of the attachment. Default to the file name
+  -o, --output=FILE           Writes the attachment content to FILE instead of stdout
+      --session=SESSION_ID    Sets a session ID to use instead of the env var
+      --template=TEMPLATE     Formats the output of ls using the Go template
+`
+	printUsage(template, cmd.Description())
+}
+
+// Parse parses the arguments and set the usage for the command
+func (cmd *AttachCmd) Parse(args []string) error {
+	flags, err := newCommonFlags(flagOpts{Session: true})
+	if err != nil {
+		return err
+	}
+
+	flagSet.StringVar(&cmd.name, "name", "", "")
+	flagSet.StringVar(&cmd.outputPath, "o", "", "")
+	flagSet.StringVar(&cmd.outputPath, "output", "", "")
+
+	flags.Parse(cmd, args)
+	cmd.command = flagSet.Arg(0)
+	nargs := 3
+	if cmd.command == attachListSubCmd {
+		nargs = 2
+	}
+	switch {
+	case cmd.command != attachAddSubCmd && cmd.command != attachGetSubCmd && cmd.command != attachListSubCmd && cmd.command != attachRmSubCmd,
+		len(flagSet.Args()) != nargs:
+		cmd.Usage()
+		os.Exit(errorClassUsage.ExitCode())
+	}
+	flags.SetEnv()
+
+	itemPath, err := parseItemPath(flagSet.Arg(1), itemPathOptions{fullPath: true})
+	if err != nil {
+		return err
+	}
+	cmd.itemPath = itemPath
+	cmd.file = flagSet.Arg(2)
+
+	if cmd.command == attachAddSubCmd && cmd.file == "-" && cmd.name == "" {
+		return fmt.Errorf("the --name option is required reading from the standard input")
+	}
+	return nil
+}
+
+// Run runs the command
+func (cmd *AttachCmd) Run(s paw.Storage) error {
+	key, err := loadVaultKey(s, cmd.vaultName)
+	if err != nil {
+		return err
+	}
+
+	vault, err := s.LoadVault(cmd.vaultName, key)
+	if err != nil {
+		return err
+	}
+
+	item, err := loadItem(s, vault, cmd.itemPath)
+	if err != nil {
+		return err
+	}
+
+	switch cmd.command {
+	case attachAddSubCmd:
+		return cmd.add(s, vault, item)
+	case attachGetSubCmd:
+		return cmd.get(s, vault, item)
+	case attachRmSubCmd:
+		confirm, err := askYesNo(fmt.Sprintf("Are you sure you want to delete %q attached to %q?", cmd.file, cmd.itemPath), false)
+		if err != nil {
+			return err
+		}
+		if !confirm {
+			os.Exit(0)
+		}
+		err = paw.RemoveAttachment(s, vault, item, cmd.file)
+		if err != nil {
+			return err
+		}
+		fmt.Printf("[✓] attachment %q deleted\n", cmd.file)
+		return storeAppStateModified(s)
+	}
+
+	out := []attachmentOutput{}
+	for _, attachment := range item.GetMetadata().Attachments {
+		out = append(out, attachmentOutput{Name: attachment.Name, Size: attachment.Size, Created: attachment.Created})
+	}
+	return printOutput(out, func(w io.Writer) {
+		printAttachments(w, out)
+	})
+}
+
+// add attaches the file to the item
+func (cmd *AttachCmd) add(s paw.Storage, vault *paw.Vault, item paw.Item) error {
+	name := cmd.name
+	var r io.Reader = os.Stdin
+	if cmd.file != "-" {
+		f, err := os.Open(cmd.file)
+		if err != nil {
+			return fmt.Errorf("could not open the file to attach: %w", err)
+		}
+		defer f.Close()
+		fi, err := f.Stat()
+		if err != nil {
+			return fmt.Errorf("could not open the file to attach: %w", err)
+		}
+		if fi.Size() > paw.AttachmentMaxSize {
+			return paw.ErrAttachmentTooLarge
+		}
+		if name == "" {
+			name = fi.Name()
+		}
+		r = f
+	}
+
+	attachment, err := paw.AddAttachment(s, vault, item, name, r)
+	if err != nil {
+		return err
+	}
+	fmt.Printf("[✓] %q attached to %q\n", attachment.Name, cmd.itemName)
+	return storeAppStateModified(s)
+}
+
+// get writes the content of the attachment to stdout or to the output file
+func (cmd *AttachCmd) get(s paw.Storage, vault *paw.Vault, item paw.Item) error {
+	r, _, err := paw.OpenAttachment(s, vault, item, cmd.file)
+	if err != nil {
+		return err
+	}
+	defer r.Close()
+
+	var w io.WriteCloser = os.Stdout
+	if cmd.outputPath != "" {
+		f, err := os.OpenFile(cmd.outputPath, os.O_WRONLY|os.O_CREATE|os.O_TRUNC, 0600)
+		if err != nil {
+			return fmt.Errorf("could not create the output file: %w", err)
+		}
+		defer f.Close()
+		w = f
+	}
+
+	_, err = io.Copy(w, r)
+	if err != nil {
+		return fmt.Errorf("could not decrypt the attachment: %w", err)
+	}
+	if cmd.outputPath != "" {
+		err = w.Close()
+		if err != nil {
+			return fmt.Errorf("could not write the output file: %w", err)
+		}
+		fmt.Fprintf(os.Stderr, "[✓] attachment %q written to %s\n", cmd.file, cmd.outputPath)
+	}
+	return nil
+}
+
+// storeAppStateModified updates the app state modification time
+func storeAppStateModified(s paw.Storage) error {
+	appState, err := s.LoadAppState()
+	if err != nil {
+		return err
+	}
+	appState.Modified = time.Now().UTC()
+	return s.StoreAppState(appState)
+}
+
+// attachmentOutput is the structured representation of an attachment
+type attachmentOutput struct {
+	Name    string    `json:"name"`
+	Size    int64     `json:"size"`
+	Created time.Time `json:"created"`
+}
+
+// printAttachments prints the attachments as a table
+func printAttachments(w io.Writer, attachments []attachmentOutput) {
+	if len(attachments) == 0 {
+		fmt.Fprintln(w, "No attachments")
+		return
+	}
+
+	tw := tabwriter.NewWriter(w, 0, 0, 1, ' ', tabwriter.Debug)
+	fmt.Fprintln(tw, "Name\tSize\tCreated")
+	for _, a := range attachments {
+		fmt.Fprintf(tw, "%s\t%s\t%s\n", a.Name, formatSize(a.Size), a.Created.Local().Format("2006-01-02 15:04:05"))
+	}
+	tw.Flush()
+}
+
+// formatSize returns the size in bytes in a human readable format
+func formatSize(size int64) string {
+	const unit = 1024
+	if size < unit {
+		return fmt.Sprintf("%d B", size)
+	}
+	div, exp := int64(unit), 0
+	for n := size / unit; n >= unit; n /= unit {
+		div *= unit
+		exp++
+	}
+	return fmt.Sprintf("%.1f %ciB", float64(size)/float64(div), "KMGTPE"[exp])
+}
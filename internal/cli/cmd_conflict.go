@@ -0,0 +1,257 @@
+// SPDX-FileCopyrightText: 2025 Luca Corbo, Paw contributors
+// SPDX-License-Identifier: AGPL-3.0-or-later
+
+package cli
+
+import (
+	"fmt"
+	"io"
+	"os"
+	"text/tabwriter"
+	"time"
+
+	"lucor.dev/paw/internal/paw"
+)
+
+const (
+	conflictListSubCmd    = "ls"
+	conflictShowSubCmd    = "show"
+	conflictRestoreSubCmd = "restore"
+	conflictDismissSubCmd = "dismiss"
+)
+
+// ConflictCmd manages the conflicts detected merging the concurrent changes of a vault
+type ConflictCmd struct {
+	itemPath
+	command string
+}
+
+// Name returns the one word command name
+func (cmd *ConflictCmd) Name() string {
+	return "conflict"
+}
+
+// Description returns the command description
+func (cmd *ConflictCmd) Description() string {
+	return "Manages the conflicting changes merged into a vault"
+}
+
+// Usage displays the command usage
+func (cmd *ConflictCmd) Usage() {
+	template := `Usage: paw cli conflict [OPTION] COMMAND VAULT_NAME[/ITEM_TYPE/ITEM_NAME]
+
+{{ . }}
+
+Commands:
+  ls         Lists the conflicts to review
+  show       Shows the item as left by the discarded change
+  restore    Restores the discarded change as a new item and dismisses the
+             conflict
+  dismiss    Dismisses the conflict of the item once reviewed, or all the
+             conflicts if only the vault is specified
+
+The changes made concurrently to a vault, e.g. by the app and the CLI or by
+two devices on sync, are merged by item. When the same item has been changed
+on both sides, the most recent change is kept and the other one is discarded
+and listed as a conflict. When the discarded change left the item into the
+vault, a copy is kept until the conflict is dismissed and can be shown or
+restored. The item name can be either the current one or the one set by the
+discarded change.
+
+Options:
+      --format=FORMAT         Sets the output format: text, json. Default to text
+  -h, --help                  Displays this help and exit
+      --session=SESSION_ID    Sets a session ID to use instead of the env var
+      --template=TEMPLATE     Formats the output using the Go template
+`
+	printUsage(template, cmd.Description())
+}
+
+// Parse parses the arguments and set the usage for the command
+func (cmd *ConflictCmd) Parse(args []string) error {
+	flags, err := newCommonFlags(flagOpts{Session: true})
+	if err != nil {
+		return err
+	}
+
+	flags.Parse(cmd, args)
+	cmd.command = flagSet.Arg(0)
+	switch cmd.command {
+	case conflictListSubCmd, conflictShowSubCmd, conflictRestoreSubCmd, conflictDismissSubCmd:
+	default:
+		cmd.command = ""
+	}
+	if len(flagSet.Args()) != 2 || cmd.command == "" {
+		cmd.Usage()
+		os.Exit(errorClassUsage.ExitCode())
+	}
+	flags.SetEnv()
+
+	itemPath, err := parseItemPath(flagSet.Arg(1), itemPathOptions{})
+	if err != nil {
+		return err
+	}
+	if cmd.command == conflictListSubCmd && (itemPath.itemType != 0 || itemPath.itemName != "") {
+		return fmt.Errorf("the ls command accepts only the vault name")
+	}
+	if (itemPath.itemType != 0) != (itemPath.itemName != "") {
+		return fmt.Errorf("invalid vault item path. Got %q, expected VAULT_NAME or VAULT_NAME/ITEM_TYPE/ITEM_NAME", flagSet.Arg(1))
+	}
+	if (cmd.command == conflictShowSubCmd || cmd.command == conflictRestoreSubCmd) && itemPath.itemName == "" {
+		return fmt.Errorf("the %s command requires the item path VAULT_NAME/ITEM_TYPE/ITEM_NAME", cmd.command)
+	}
+	cmd.itemPath = itemPath
+	return nil
+}
+
+// Run runs the command
+func (cmd *ConflictCmd) Run(s paw.Storage) error {
+	key, err := loadVaultKey(s, cmd.vaultName)
+	if err != nil {
+		return err
+	}
+
+	vault, err := s.LoadVault(cmd.vaultName, key)
+	if err != nil {
+		return err
+	}
+
+	if cmd.command == conflictListSubCmd {
+		out := []conflictOutput{}
+		for _, c := range vault.ConflictedItems() {
+			out = append(out, newConflictOutput(cmd.vaultName, c))
+		}
+		return printOutput(out, func(w io.Writer) {
+			printConflicts(w, out)
+		})
+	}
+
+	ids := []string{}
+	if cmd.itemName != "" {
+		c, ok := conflictByName(vault, cmd.itemType, cmd.itemName)
+		if !ok {
+			return fmt.Errorf("%w: %q has no conflict", paw.ErrItemNotFound, cmd.itemPath)
+		}
+		ids = append(ids, c.ID())
+	}
+
+	switch cmd.command {
+	case conflictShowSubCmd:
+		item, err := paw.LoadConflictCopy(s, vault, ids[0])
+		if err != nil {
+			return err
+		}
+		show := &ShowCmd{itemPath: cmd.itemPath}
+		return printOutput(newItemOutput(cmd.vaultName, item), func(w io.Writer) {
+			show.printText(w, item)
+		})
+	case conflictRestoreSubCmd:
+		item, err := paw.RestoreConflictCopy(s, vault, ids[0])
+		if err != nil {
+			return err
+		}
+		err = storeAppStateModified(s)
+		if err != nil {
+			return err
+		}
+		meta := item.GetMetadata()
+		return printResult(newItemResultOutput(cmd.vaultName, "restored", meta), fmt.Sprintf("discarded change restored as item %q", meta.Name))
+	}
+
+	if len(vault.Conflicts) == 0 {
+		fmt.Println("[✓] no conflicts to review")
+		return nil
+	}
+	n := len(vault.Conflicts)
+	if len(ids) > 0 {
+		n = len(ids)
+	}
+	err = paw.DismissConflicts(s, vault, ids...)
+	if err != nil {
+		return err
+	}
+	fmt.Printf("[✓] %d conflicts dismissed\n", n)
+	return storeAppStateModified(s)
+}
+
+// conflictByName returns the conflict of the item with the specified type and
+// name, either the current one or the one set by the discarded change
+func conflictByName(vault *paw.Vault, itemType paw.ItemType, name string) (*paw.Conflict, bool) {
+	if meta, ok := vault.ItemMetadataByName(itemType, name); ok {
+		if c, ok := vault.Conflicts[meta.ID()]; ok {
+			return c, true
+		}
+	}
+	for _, c := range vault.ConflictedItems() {
+		if c.Type == itemType && c.Name == name {
+			return c, true
+		}
+	}
+	return nil, false
+}
+
+// describeConflict returns a description of the discarded change
+func describeConflict(c *paw.Conflict) string {
+	switch {
+	case c.Purged:
+		return "deletion from the trash"
+	case !c.Deleted.IsZero():
+		return fmt.Sprintf("move to the trash of %s", c.Deleted.Local().Format("2006-01-02 15:04:05"))
+	}
+	return fmt.Sprintf("change of %s", c.Modified.Local().Format("2006-01-02 15:04:05"))
+}
+
+// printMergeConflicts prints the conflicts detected by a merge
+func printMergeConflicts(w io.Writer, conflicts []conflictOutput) {
+	for _, c := range conflicts {
+		fmt.Fprintf(w, "conflict %s/%s: discarded the %s\n", c.Type, c.Name, c.Discarded)
+	}
+}
+
+// warnConflicts prints a warning if the vault has conflicts to review
+func warnConflicts(vault *paw.Vault) {
+	if len(vault.Conflicts) == 0 {
+		return
+	}
+	fmt.Fprintf(os.Stderr, "[!] vault %q has %d conflicts to review, see: paw cli conflict ls %s\n", vault.Name, len(vault.Conflicts), vault.Name)
+}
+
+// conflictOutput is the structured representation of a conflict
+type conflictOutput struct {
+	itemMetadataOutput
+	Discarded string    `json:"discarded"`
+	Deleted   time.Time `json:"deleted"`
+	Purged    bool      `json:"purged"`
+	Detected  time.Time `json:"detected"`
+	Copy      bool      `json:"copy"`
+}
+
+func newConflictOutput(vaultName string, c *paw.Conflict) conflictOutput {
+	return conflictOutput{
+		itemMetadataOutput: newItemMetadataOutput(vaultName, c.Metadata),
+		Discarded:          describeConflict(c),
+		Deleted:            c.Deleted,
+		Purged:             c.Purged,
+		Detected:           c.Detected,
+		Copy:               c.Copy,
+	}
+}
+
+// printConflicts prints the conflicts as a table
+func printConflicts(w io.Writer, conflicts []conflictOutput) {
+	if len(conflicts) == 0 {
+		fmt.Fprintln(w, "No conflicts to review")
+		return
+	}
+
+	tw := tabwriter.NewWriter(w, 0, 0, 1, ' ', tabwriter.Debug)
+	fmt.Fprintln(tw, "Type\tName\tDiscarded change\tDetected\tCopy")
+	for _, c := range conflicts {
+		kept := "no"
+		if c.Copy {
+			kept = "yes"
+		}
+		fmt.Fprintf(tw, "%s\t%s\t%s\t%s\t%s\n", c.Type, c.Name, c.Discarded, c.Detected.Local().Format("2006-01-02 15:04:05"), kept)
+	}
+	tw.Flush()
+}
@@ -0,0 +1,254 @@
+// SPDX-FileCopyrightText: 2025 Luca Corbo, Paw contributors
+// SPDX-License-Identifier: AGPL-3.0-or-later
+
+package cli
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/url"
+	"os"
+	"strings"
+
+	"lucor.dev/paw/internal/paw"
+)
+
+const (
+	// dockerCredentialsNotFound is the message expected by docker when the credentials are not found
+	dockerCredentialsNotFound = "credentials not found in native keychain"
+	// dockerRegistryTag is the tag of the logins used as registry credentials
+	dockerRegistryTag = "docker-registry"
+)
+
+// DockerCredentialCmd implements the docker credential helper protocol
+type DockerCredentialCmd struct {
+	vaultName string
+	action    string
+}
+
+// Name returns the one word command name
+func (cmd *DockerCredentialCmd) Name() string {
+	return "docker-credential"
+}
+
+// Description returns the command description
+func (cmd *DockerCredentialCmd) Description() string {
+	return "Docker credential helper backed by login items"
+}
+
+// Usage displays the command usage
+func (cmd *DockerCredentialCmd) Usage() {
+	template := `Usage: paw cli docker-credential [OPTION] get|store|erase|list
+
+{{ . }}
+
+The command implements the docker credential helper protocol. The registry
+server URLs are matched against the URL of the login items with the same host
+and port.
+
+Only the logins with the "docker-registry" tag are registry credentials: the
+ones created by store have it, an existing login can be used adding the tag.
+The other logins are never returned, updated nor removed.
+
+  get      prints the username and password of the best matching login
+  store    updates the password of the matching login or creates a new one
+  erase    removes the logins matching the server URL
+  list     prints the URL and the username of the logins
+
+Docker runs the helper without a terminal, so the vault must be unlocked using
+a session. See "paw cli unlock -h".
+
+When the executable is named docker-credential-paw the command can be used
+directly by docker, e.g. creating a symlink into the PATH, and setting
+"credsStore": "paw" into ~/.docker/config.json. In that case the vault is set
+using the PAW_VAULT env var.
+
+Options:
+  -h, --help                  Displays this help and exit
+      --session=SESSION_ID    Sets a session ID to use instead of the env var
+      --vault=VAULT_NAME      Sets the vault to use. Default to the PAW_VAULT env var.
+                              Can be omitted if only one vault exists
+`
+	printUsage(template, cmd.Description())
+}
+
+// Parse parses the arguments and set the usage for the command
+func (cmd *DockerCredentialCmd) Parse(args []string) error {
+	flags, err := newCommonFlags(flagOpts{Session: true})
+	if err != nil {
+		return err
+	}
+
+	flagSet.StringVar(&cmd.vaultName, "vault", "", "")
+
+	flags.Parse(cmd, args)
+	if len(flagSet.Args()) != 1 {
+		cmd.Usage()
+		os.Exit(errorClassUsage.ExitCode())
+	}
+	flags.SetEnv()
+
+	cmd.action = flagSet.Arg(0)
+	switch cmd.action {
+	case "get", "store", "erase", "list":
+	default:
+		return fmt.Errorf("invalid action %q, expected one of: get, store, erase, list", cmd.action)
+	}
+	return nil
+}
+
+// Run runs the command
+func (cmd *DockerCredentialCmd) Run(s paw.Storage) error {
+	vaultName, err := credentialVaultName(s, cmd.vaultName)
+	if err != nil {
+		return err
+	}
+
+	key, err := loadVaultKey(s, vaultName)
+	if err != nil {
+		return err
+	}
+
+	vault, err := s.LoadVault(vaultName, key)
+	if err != nil {
+		return err
+	}
+
+	switch cmd.action {
+	case "get":
+		return cmd.get(s, vault)
+	case "store":
+		return cmd.store(s, vault, os.Stdin)
+	case "erase":
+		return cmd.erase(s, vault, os.Stdin)
+	case "list":
+		return cmd.list(s, vault, os.Stdout)
+	}
+	return nil
+}
+
+func (cmd *DockerCredentialCmd) get(s paw.Storage, vault *paw.Vault) error {
+	serverURL, err := readDockerServerURL(os.Stdin)
+	if err != nil {
+		return err
+	}
+	u, err := parseDockerServerURL(serverURL)
+	if err != nil {
+		return err
+	}
+
+	login, err := findDockerCredentialLogin(s, vault, u)
+	if err != nil {
+		return err
+	}
+	if login == nil {
+		// docker expects the message on the standard output
+		fmt.Println(dockerCredentialsNotFound)
+		os.Exit(errorClassNotFound.ExitCode())
+	}
+
+	res := dockerCredential{
+		ServerURL: serverURL,
+		Username:  login.Username,
+	}
+	if login.Password != nil {
+		res.Secret = login.Password.Value
+	}
+	return json.NewEncoder(os.Stdout).Encode(res)
+}
+
+// findDockerCredentialLogin returns the registry credential for the URL, nil if none
+func findDockerCredentialLogin(s paw.Storage, vault *paw.Vault, u *url.URL) (*paw.Login, error) {
+	logins, match, err := findLoginsByURL(s, vault, u, "", dockerRegistryTag)
+	if err != nil {
+		return nil, err
+	}
+	// registries are identified by host and port, the domain match is not enough
+	if len(logins) == 0 || match < paw.HostURLMatch {
+		return nil, nil
+	}
+	return logins[0], nil
+}
+
+func (cmd *DockerCredentialCmd) store(s paw.Storage, vault *paw.Vault, r io.Reader) error {
+	req := dockerCredential{}
+	err := json.NewDecoder(r).Decode(&req)
+	if err != nil {
+		return fmt.Errorf("invalid docker credential: %w", err)
+	}
+	if req.Username == "" || req.Secret == "" {
+		return fmt.Errorf("invalid docker credential: the username and the secret are required")
+	}
+	u, err := parseDockerServerURL(req.ServerURL)
+	if err != nil {
+		return err
+	}
+	return storeCredential(s, vault, u, req.Username, req.Secret, dockerRegistryTag)
+}
+
+func (cmd *DockerCredentialCmd) erase(s paw.Storage, vault *paw.Vault, r io.Reader) error {
+	serverURL, err := readDockerServerURL(r)
+	if err != nil {
+		return err
+	}
+	u, err := parseDockerServerURL(serverURL)
+	if err != nil {
+		return err
+	}
+	return eraseCredential(s, vault, u, "", "", dockerRegistryTag)
+}
+
+func (cmd *DockerCredentialCmd) list(s paw.Storage, vault *paw.Vault, w io.Writer) error {
+	res := map[string]string{}
+	opts := &paw.VaultFilterOptions{ItemType: paw.LoginItemType, Tags: []string{dockerRegistryTag}}
+	for _, meta := range vault.FilterItemMetadata(opts) {
+		item, err := s.LoadItem(vault, meta)
+		if err != nil {
+			return err
+		}
+		login := item.(*paw.Login)
+		if login.URL == nil || login.URL.URL() == nil || login.URL.URL().Host == "" {
+			continue
+		}
+		res[login.URL.String()] = login.Username
+	}
+	return json.NewEncoder(w).Encode(res)
+}
+
+// dockerCredential represents the credentials exchanged using the docker credential helper protocol.
+// See https://github.com/docker/docker-credential-helpers
+type dockerCredential struct {
+	ServerURL string `json:"ServerURL"`
+	Username  string `json:"Username"`
+	Secret    string `json:"Secret"`
+}
+
+// readDockerServerURL reads the server URL sent by docker
+func readDockerServerURL(r io.Reader) (string, error) {
+	b, err := io.ReadAll(r)
+	if err != nil {
+		return "", fmt.Errorf("could not read the server URL: %w", err)
+	}
+	serverURL := strings.TrimSpace(string(b))
+	if serverURL == "" {
+		return "", fmt.Errorf("the server URL is missing")
+	}
+	return serverURL, nil
+}
+
+// parseDockerServerURL parses the server URL. Registries can be specified
+// without scheme, in that case https is assumed.
+func parseDockerServerURL(serverURL string) (*url.URL, error) {
+	if !strings.Contains(serverURL, "://") {
+		serverURL = "https://" + serverURL
+	}
+	u, err := url.Parse(serverURL)
+	if err != nil {
+		return nil, fmt.Errorf("invalid server URL: %w", err)
+	}
+	if u.Host == "" {
+		return nil, fmt.Errorf("invalid server URL %q: the host is missing", serverURL)
+	}
+	return u, nil
+}
@@ -0,0 +1,169 @@
+// SPDX-FileCopyrightText: 2025 Luca Corbo, Paw contributors
+// SPDX-License-Identifier: AGPL-3.0-or-later
+
+package cli
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"lucor.dev/paw/internal/paw"
+)
+
+func TestParseDockerServerURL(t *testing.T) {
+	tests := map[string]struct {
+		serverURL string
+		want      string
+		wantHost  string
+		wantErr   bool
+	}{
+		"without scheme": {
+			serverURL: "ghcr.io",
+			want:      "https://ghcr.io",
+			wantHost:  "ghcr.io",
+		},
+		"without scheme with port": {
+			serverURL: "localhost:5000",
+			want:      "https://localhost:5000",
+			wantHost:  "localhost:5000",
+		},
+		"without scheme with path": {
+			serverURL: "registry.example.com:8443/v2/",
+			want:      "https://registry.example.com:8443/v2/",
+			wantHost:  "registry.example.com:8443",
+		},
+		"with scheme": {
+			serverURL: "https://registry.example.com",
+			want:      "https://registry.example.com",
+			wantHost:  "registry.example.com",
+		},
+		"with http scheme and port": {
+			serverURL: "http://registry.local:5000",
+			want:      "http://registry.local:5000",
+			wantHost:  "registry.local:5000",
+		},
+		"docker hub index": {
+			serverURL: "https://index.docker.io/v1/",
+			want:      "https://index.docker.io/v1/",
+			wantHost:  "index.docker.io",
+		},
+		"invalid port": {
+			serverURL: "localhost:port",
+			wantErr:   true,
+		},
+		"missing host": {
+			serverURL: "https:///v1/",
+			wantErr:   true,
+		},
+	}
+	for name, tt := range tests {
+		t.Run(name, func(t *testing.T) {
+			got, err := parseDockerServerURL(tt.serverURL)
+			if tt.wantErr {
+				assert.Error(t, err)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, tt.want, got.String())
+			assert.Equal(t, tt.wantHost, got.Host)
+		})
+	}
+}
+
+func TestReadDockerServerURL(t *testing.T) {
+	got, err := readDockerServerURL(strings.NewReader("  https://index.docker.io/v1/\n"))
+	require.NoError(t, err)
+	assert.Equal(t, "https://index.docker.io/v1/", got)
+
+	_, err = readDockerServerURL(strings.NewReader("\n"))
+	assert.Error(t, err)
+}
+
+func TestDockerCredential_StoreErase(t *testing.T) {
+	s, vault := newCredentialTestVault(t)
+	cmd := &DockerCredentialCmd{}
+
+	store := func(serverURL, username, secret string) error {
+		req := `{"ServerURL":"` + serverURL + `","Username":"` + username + `","Secret":"` + secret + `"}`
+		return cmd.store(s, vault, strings.NewReader(req))
+	}
+	find := func(serverURL string) string {
+		u, err := parseDockerServerURL(serverURL)
+		require.NoError(t, err)
+		login, err := findDockerCredentialLogin(s, vault, u)
+		require.NoError(t, err)
+		if login == nil {
+			return ""
+		}
+		return login.Username + ":" + login.Password.Value
+	}
+
+	// the logins of the user are not registry credentials
+	storeCredentialTestLogin(t, s, vault, "website", "https://example.com", "erin", false)
+	storeCredentialTestLogin(t, s, vault, "registry website", "https://registry.example.com", "carol", false)
+
+	require.NoError(t, store("https://index.docker.io/v1/", "alice", "hub"))
+	require.NoError(t, store("localhost:5000", "bob", "local"))
+	require.NoError(t, store("registry.example.com", "carol", "example"))
+
+	tests := map[string]struct {
+		serverURL string
+		want      string
+	}{
+		"docker hub index":           {serverURL: "https://index.docker.io/v1/", want: "alice:hub"},
+		"docker hub without path":    {serverURL: "index.docker.io", want: "alice:hub"},
+		"docker hub other subdomain": {serverURL: "registry-1.docker.io", want: ""},
+		"port without scheme":        {serverURL: "localhost:5000", want: "bob:local"},
+		"port with scheme":           {serverURL: "https://localhost:5000", want: "bob:local"},
+		"other port":                 {serverURL: "localhost:5001", want: ""},
+		"without port":               {serverURL: "localhost", want: ""},
+		"without scheme":             {serverURL: "registry.example.com", want: "carol:example"},
+		"sibling subdomain":          {serverURL: "evil.example.com", want: ""},
+		"http for an https registry": {serverURL: "http://registry.example.com", want: ""},
+		"with scheme":                {serverURL: "https://registry.example.com", want: "carol:example"},
+	}
+	for name, tt := range tests {
+		t.Run(name, func(t *testing.T) {
+			assert.Equal(t, tt.want, find(tt.serverURL))
+		})
+	}
+
+	// store updates the existing login
+	require.NoError(t, store("localhost:5000", "bob", "changed"))
+	assert.Equal(t, "bob:changed", find("localhost:5000"))
+	assert.Equal(t, 5, vault.Size())
+
+	// list returns only the registry credentials
+	out := &bytes.Buffer{}
+	require.NoError(t, cmd.list(s, vault, out))
+	assert.JSONEq(t, `{
+		"https://index.docker.io/v1/": "alice",
+		"https://localhost:5000": "bob",
+		"https://registry.example.com": "carol"
+	}`, out.String())
+
+	// the username and secret are required
+	assert.Error(t, store("localhost:5000", "", "secret"))
+	assert.Error(t, store("localhost:5000", "bob", ""))
+	assert.Error(t, cmd.store(s, vault, strings.NewReader("not json")))
+
+	// erase removes only the login of the registry
+	require.NoError(t, cmd.erase(s, vault, strings.NewReader("localhost:5001")))
+	assert.Equal(t, "bob:changed", find("localhost:5000"))
+	require.NoError(t, cmd.erase(s, vault, strings.NewReader("localhost:5000")))
+	assert.Equal(t, "", find("localhost:5000"))
+	assert.Equal(t, "alice:hub", find("https://index.docker.io/v1/"))
+	require.NoError(t, cmd.erase(s, vault, strings.NewReader("https://index.docker.io/v1/\n")))
+	assert.Equal(t, "", find("index.docker.io"))
+	assert.Equal(t, "carol:example", find("registry.example.com"))
+	require.NoError(t, cmd.erase(s, vault, strings.NewReader("registry.example.com")))
+	assert.Equal(t, "", find("registry.example.com"))
+	_, ok := vault.ItemMetadataByName(paw.LoginItemType, "registry website")
+	assert.True(t, ok, "the login of the user is kept")
+
+	assert.Error(t, cmd.erase(s, vault, strings.NewReader("")))
+}
@@ -4,6 +4,7 @@
 package cli
 
 import (
+	"flag"
 	"fmt"
 	"os"
 	"time"
@@ -14,6 +15,11 @@ import (
 // Edit edits an item into the vault
 type EditCmd struct {
 	itemPath
+	tags         stringsFlag
+	folder       *string
+	fields       stringsFlag
+	removeFields stringsFlag
+	values       stringsFlag
 }
 
 // Name returns the one word command name
@@ -32,9 +38,26 @@ func (cmd *EditCmd) Usage() {
 
 {{ . }}
 
+The custom fields are specified as NAME[:TYPE][=VALUE], where TYPE is one of
+text, hidden, url, email, date (YYYY-MM-DD) or totp and defaults to text.
+The value is asked if not specified, i.e. --field="pin:hidden".
+A custom field with the same name is replaced.
+
+The fields of the api_credential, card, identity, license and wifi items can be
+specified as NAME=VALUE using the --set option, i.e. --set expiry=12/30.
+The other fields are not asked when the --set option is specified.
+
 Options:
+      --field=FIELD           Sets a custom field. Can be specified multiple times
+      --folder=PATH           Moves the item into the folder. Use an empty value to remove it
+      --format=FORMAT         Sets the output format: text, json. Default to text
   -h, --help                  Displays this help and exit
+      --remove-field=NAME     Removes a custom field. Can be specified multiple times
       --session=SESSION_ID    Sets a session ID to use instead of the env var
+      --set=FIELD             Sets a field of the item. Can be specified multiple times
+      --tag=TAG               Replaces the item tags. Can be specified multiple times.
+                              Use an empty value to remove all the tags
+      --template=TEMPLATE     Formats the output using the Go template
 `
 	printUsage(template, cmd.Description())
 }
@@ -46,10 +69,22 @@ func (cmd *EditCmd) Parse(args []string) error {
 		return err
 	}
 
+	var folder string
+	flagSet.Var(&cmd.fields, "field", "")
+	flagSet.Var(&cmd.removeFields, "remove-field", "")
+	flagSet.Var(&cmd.values, "set", "")
+	flagSet.Var(&cmd.tags, "tag", "")
+	flagSet.StringVar(&folder, "folder", "", "")
+
 	flags.Parse(cmd, args)
+	flagSet.Visit(func(f *flag.Flag) {
+		if f.Name == "folder" {
+			cmd.folder = &folder
+		}
+	})
 	if len(flagSet.Args()) != 1 {
 		cmd.Usage()
-		os.Exit(1)
+		os.Exit(errorClassUsage.ExitCode())
 	}
 	flags.SetEnv()
 
@@ -78,12 +113,12 @@ func (cmd *EditCmd) Run(s paw.Storage) error {
 		return err
 	}
 
-	item, err := paw.NewItem(cmd.itemName, cmd.itemType)
+	item, err := loadItem(s, vault, cmd.itemPath)
 	if err != nil {
 		return err
 	}
 
-	item, err = s.LoadItem(vault, item.GetMetadata())
+	err = setItemFields(item, cmd.values)
 	if err != nil {
 		return err
 	}
@@ -98,9 +133,41 @@ func (cmd *EditCmd) Run(s paw.Storage) error {
 	case paw.SSHKeyItemType:
 		cmd.editSSHKeyItem(item)
 	default:
-		return fmt.Errorf("unsupported item type: %q", cmd.itemType)
+		v, ok := item.(paw.FieldsItem)
+		if !ok {
+			return fmt.Errorf("unsupported item type: %q", cmd.itemType)
+		}
+		if len(cmd.values) == 0 {
+			err = askItemFields(v)
+			if err != nil {
+				return err
+			}
+		}
+	}
+
+	err = removeCustomFields(item, cmd.removeFields)
+	if err != nil {
+		return err
+	}
+	err = setCustomFields(item, cmd.fields)
+	if err != nil {
+		return err
 	}
 
+	if cmd.tags != nil {
+		item.GetMetadata().Tags = paw.NormalizeTags(cmd.tags)
+	}
+	if cmd.folder != nil {
+		item.GetMetadata().Folder = paw.NormalizeFolder(*cmd.folder)
+	}
+
+	// lock once the values have been asked to not block the other processes meanwhile
+	unlock, err := paw.LockVault(s, vault)
+	if err != nil {
+		return err
+	}
+	defer unlock()
+
 	now := time.Now().UTC()
 
 	item.GetMetadata().Modified = now
@@ -127,8 +194,7 @@ func (cmd *EditCmd) Run(s paw.Storage) error {
 		return err
 	}
 
-	fmt.Printf("[✓] item %q modified\n", cmd.itemName)
-	return nil
+	return printResult(newItemResultOutput(cmd.vaultName, "modified", item.GetMetadata()), fmt.Sprintf("item %q modified", cmd.itemName))
 }
 
 func (cmd *EditCmd) editLoginItem(key *paw.Key, item paw.Item) error {
@@ -157,7 +223,9 @@ func (cmd *EditCmd) editLoginItem(key *paw.Key, item paw.Item) error {
 	if err != nil {
 		return err
 	}
+	previous := v.Password.Value
 	v.Password.Value = password.Value
+	v.Password.UpdateHistory(previous, time.Now().UTC())
 	v.Password.Mode = password.Mode
 	v.Password.Format = password.Format
 	v.Password.Length = password.Length
@@ -199,7 +267,9 @@ func (cmd *EditCmd) editPasswordItem(key *paw.Key, item paw.Item) error {
 	if err != nil {
 		return err
 	}
+	previous := v.Value
 	v.Value = password.Value
+	v.UpdateHistory(previous, time.Now().UTC())
 	v.Mode = password.Mode
 	v.Format = password.Format
 	v.Length = password.Length
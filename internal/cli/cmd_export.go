@@ -0,0 +1,173 @@
+// SPDX-FileCopyrightText: 2025 Luca Corbo, Paw contributors
+// SPDX-License-Identifier: AGPL-3.0-or-later
+
+package cli
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+
+	"filippo.io/age"
+
+	"lucor.dev/paw/internal/paw"
+)
+
+// ExportCmd exports the vault items to file
+type ExportCmd struct {
+	vaultName  string
+	outputPath string
+	recipient  string
+	passphrase bool
+}
+
+// Name returns the one word command name
+func (cmd *ExportCmd) Name() string {
+	return "export"
+}
+
+// Description returns the command description
+func (cmd *ExportCmd) Description() string {
+	return "Exports the vault items"
+}
+
+// Usage displays the command usage
+func (cmd *ExportCmd) Usage() {
+	template := `Usage: paw cli export [OPTION] VAULT
+
+{{ . }}
+
+The items are exported in JSON format, optionally encrypted using age.
+The export can be imported using the import command.
+
+Options:
+      --encrypt-to=RECIPIENT  Encrypts the export to the age recipient (age1...)
+      --format=FORMAT         Sets the format of the report printed when writing to file:
+                              text, json. Default to text
+  -h, --help                  Displays this help and exit
+  -o, --output=FILE           Writes the export to file instead of the standard output
+      --passphrase            Encrypts the export using a passphrase
+      --session=SESSION_ID    Sets a session ID to use instead of the env var
+      --template=TEMPLATE     Formats the report using the Go template
+`
+	printUsage(template, cmd.Description())
+}
+
+// Parse parses the arguments and set the usage for the command
+func (cmd *ExportCmd) Parse(args []string) error {
+	flags, err := newCommonFlags(flagOpts{Session: true})
+	if err != nil {
+		return err
+	}
+
+	flagSet.StringVar(&cmd.recipient, "encrypt-to", "", "")
+	flagSet.StringVar(&cmd.outputPath, "o", "", "")
+	flagSet.StringVar(&cmd.outputPath, "output", "", "")
+	flagSet.BoolVar(&cmd.passphrase, "passphrase", false, "")
+
+	flags.Parse(cmd, args)
+	if len(flagSet.Args()) != 1 {
+		cmd.Usage()
+		os.Exit(errorClassUsage.ExitCode())
+	}
+	flags.SetEnv()
+
+	if cmd.recipient != "" && cmd.passphrase {
+		return fmt.Errorf("--encrypt-to and --passphrase cannot be used together")
+	}
+
+	cmd.vaultName = flagSet.Arg(0)
+	return nil
+}
+
+// Run runs the command
+func (cmd *ExportCmd) Run(s paw.Storage) error {
+	var recipient age.Recipient
+	var err error
+	switch {
+	case cmd.recipient != "":
+		recipient, err = age.ParseX25519Recipient(cmd.recipient)
+		if err != nil {
+			return fmt.Errorf("invalid recipient: %w", err)
+		}
+	case cmd.passphrase:
+		fmt.Fprintln(os.Stderr, "Enter the passphrase to encrypt the export")
+		passphrase, err := askPasswordWithConfirm()
+		if err != nil {
+			return err
+		}
+		recipient, err = age.NewScryptRecipient(passphrase)
+		if err != nil {
+			return err
+		}
+	}
+
+	key, err := loadVaultKey(s, cmd.vaultName)
+	if err != nil {
+		return err
+	}
+
+	vault, err := s.LoadVault(cmd.vaultName, key)
+	if err != nil {
+		return err
+	}
+
+	data, err := paw.ExportItems(s, vault)
+	if err != nil {
+		return err
+	}
+
+	var w io.WriteCloser = os.Stdout
+	if cmd.outputPath != "" {
+		f, err := os.OpenFile(cmd.outputPath, os.O_WRONLY|os.O_CREATE|os.O_TRUNC, 0600)
+		if err != nil {
+			return fmt.Errorf("could not create the export file: %w", err)
+		}
+		defer f.Close()
+		w = f
+	}
+
+	err = cmd.export(w, recipient, data)
+	if err != nil {
+		return fmt.Errorf("could not export the vault: %w", err)
+	}
+
+	message := fmt.Sprintf("%d items exported from vault %q", vault.Size(), cmd.vaultName)
+	if cmd.outputPath == "" {
+		// the standard output holds the export
+		fmt.Fprintf(os.Stderr, "[✓] %s\n", message)
+		return nil
+	}
+
+	err = w.Close()
+	if err != nil {
+		return fmt.Errorf("could not write the export file: %w", err)
+	}
+	out := exportOutput{Vault: cmd.vaultName, File: cmd.outputPath, Items: vault.Size()}
+	return printResult(out, message)
+}
+
+// exportOutput is the structured representation of an export written to file
+type exportOutput struct {
+	Vault string `json:"vault"`
+	File  string `json:"file"`
+	Items int    `json:"items"`
+}
+
+// export writes the data to w, encrypting to the recipient if not nil
+func (cmd *ExportCmd) export(w io.Writer, recipient age.Recipient, data map[string][]paw.Item) error {
+	if recipient == nil {
+		return json.NewEncoder(w).Encode(data)
+	}
+
+	e, err := age.Encrypt(w, recipient)
+	if err != nil {
+		return err
+	}
+	err = json.NewEncoder(e).Encode(data)
+	if err != nil {
+		return err
+	}
+	return e.Close()
+}
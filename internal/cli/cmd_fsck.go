@@ -0,0 +1,180 @@
+// SPDX-FileCopyrightText: 2025 Luca Corbo, Paw contributors
+// SPDX-License-Identifier: AGPL-3.0-or-later
+
+package cli
+
+import (
+	"fmt"
+	"io"
+	"os"
+	"text/tabwriter"
+
+	"lucor.dev/paw/internal/paw"
+)
+
+// FsckCmd checks and repairs the integrity of a vault
+type FsckCmd struct {
+	vaultName string
+	repair    bool
+}
+
+// Name returns the one word command name
+func (cmd *FsckCmd) Name() string {
+	return "fsck"
+}
+
+// Description returns the command description
+func (cmd *FsckCmd) Description() string {
+	return "Checks and repairs the integrity of a vault"
+}
+
+// Usage displays the command usage
+func (cmd *FsckCmd) Usage() {
+	template := `Usage: paw cli fsck [OPTION] VAULT_NAME
+
+{{ . }}
+
+The vault index and all the item and attachment files are decrypted, then the
+index is compared with the files found into the vault dir. The issues found are:
+
+  index               the vault index cannot be read
+  missing             an item is listed into the index but its file is missing
+  orphan              an item file is not listed into the index
+  mismatch            the item type, name or ID differ from the index ones
+  undecryptable       a file cannot be decrypted
+  missing_attachment  an attachment is referenced by an item but its file is missing
+  orphan_attachment   an attachment file is not referenced by any item
+  tmp_file            a temporary file has been left by an interrupted write
+
+On repair the item files are the source of truth: the index is updated from the
+decrypted items, or rebuilt from them if it cannot be read. The unreadable index
+is kept as vault.age.corrupt-TIME. The files that cannot be decrypted are never
+deleted, the ones left half-written by an interrupted write are reported as
+such. The stale temporary files are removed.
+
+The exit code is 1 if there are issues not repaired.
+
+Options:
+      --format=FORMAT         Sets the output format: text, json. Default to text
+  -h, --help                  Displays this help and exit
+      --repair                Repairs the issues found
+      --session=SESSION_ID    Sets a session ID to use instead of the env var
+      --template=TEMPLATE     Formats the output using the Go template
+`
+	printUsage(template, cmd.Description())
+}
+
+// Parse parses the arguments and set the usage for the command
+func (cmd *FsckCmd) Parse(args []string) error {
+	flags, err := newCommonFlags(flagOpts{Session: true})
+	if err != nil {
+		return err
+	}
+
+	flagSet.BoolVar(&cmd.repair, "repair", false, "")
+
+	flags.Parse(cmd, args)
+	if len(flagSet.Args()) != 1 {
+		cmd.Usage()
+		os.Exit(errorClassUsage.ExitCode())
+	}
+	flags.SetEnv()
+
+	cmd.vaultName = flagSet.Arg(0)
+	return nil
+}
+
+// Run runs the command
+func (cmd *FsckCmd) Run(s paw.Storage) error {
+	key, err := loadVaultKey(s, cmd.vaultName)
+	if err != nil {
+		return err
+	}
+
+	res, err := paw.CheckVault(s, cmd.vaultName, key, cmd.repair)
+	if err != nil {
+		return err
+	}
+
+	out := fsckOutput{
+		Vault:   cmd.vaultName,
+		Checked: res.Checked,
+		Issues:  []fsckIssueOutput{},
+	}
+	for _, issue := range res.Issues {
+		o := fsckIssueOutput{
+			Kind:     issue.Kind,
+			File:     issue.File,
+			Detail:   issue.Detail,
+			Repaired: issue.Repaired,
+		}
+		if issue.Metadata != nil {
+			o.Item = fmt.Sprintf("%s/%s", issue.Metadata.Type, issue.Metadata.Name)
+		}
+		out.Issues = append(out.Issues, o)
+	}
+	err = printOutput(out, func(w io.Writer) {
+		printFsck(w, out, cmd.repair)
+	})
+	if err != nil {
+		return err
+	}
+
+	if cmd.repair && len(res.Issues) > res.Unrepaired() {
+		err = storeAppStateModified(s)
+		if err != nil {
+			return err
+		}
+	}
+	if res.Unrepaired() > 0 {
+		os.Exit(errorClassGeneric.ExitCode())
+	}
+	return nil
+}
+
+// fsckOutput is the structured representation of a vault integrity check
+type fsckOutput struct {
+	Vault   string            `json:"vault"`
+	Checked int               `json:"checked"`
+	Issues  []fsckIssueOutput `json:"issues"`
+}
+
+// fsckIssueOutput is the structured representation of a vault integrity issue
+type fsckIssueOutput struct {
+	Kind     paw.VaultIssueKind `json:"kind"`
+	File     string             `json:"file"`
+	Item     string             `json:"item"`
+	Detail   string             `json:"detail"`
+	Repaired bool               `json:"repaired"`
+}
+
+// printFsck prints the issues as a table followed by a summary
+func printFsck(w io.Writer, out fsckOutput, repair bool) {
+	if len(out.Issues) == 0 {
+		fmt.Fprintf(w, "[✓] vault %q: %d files checked, no issues found\n", out.Vault, out.Checked)
+		return
+	}
+
+	tw := tabwriter.NewWriter(w, 0, 0, 1, ' ', tabwriter.Debug)
+	fmt.Fprintln(tw, "Issue\tFile\tItem\tDetail\tRepaired")
+	unrepaired := 0
+	for _, issue := range out.Issues {
+		repaired := "no"
+		if issue.Repaired {
+			repaired = "yes"
+		} else {
+			unrepaired++
+		}
+		fmt.Fprintf(tw, "%s\t%s\t%s\t%s\t%s\n", issue.Kind, issue.File, issue.Item, issue.Detail, repaired)
+	}
+	tw.Flush()
+
+	switch {
+	case !repair:
+		fmt.Fprintf(w, "[✗] vault %q: %d files checked, %d issues found. Use --repair to repair them\n", out.Vault, out.Checked, len(out.Issues))
+	case unrepaired > 0:
+		fmt.Fprintf(w, "[✗] vault %q: %d files checked, %d of %d issues repaired\n", out.Vault, out.Checked, len(out.Issues)-unrepaired, len(out.Issues))
+	default:
+		fmt.Fprintf(w, "[✓] vault %q: %d files checked, %d issues repaired\n", out.Vault, out.Checked, len(out.Issues))
+	}
+}
@@ -0,0 +1,223 @@
+// SPDX-FileCopyrightText: 2025 Luca Corbo, Paw contributors
+// SPDX-License-Identifier: AGPL-3.0-or-later
+
+package cli
+
+import (
+	"fmt"
+	"os"
+	"strings"
+	"time"
+
+	"lucor.dev/paw/internal/paw"
+)
+
+// GetCmd prints a single field of an item
+type GetCmd struct {
+	itemPath
+	field     string
+	noNewline bool
+}
+
+// Name returns the one word command name
+func (cmd *GetCmd) Name() string {
+	return "get"
+}
+
+// Description returns the command description
+func (cmd *GetCmd) Description() string {
+	return "Prints a single field of an item"
+}
+
+// Usage displays the command usage
+func (cmd *GetCmd) Usage() {
+	template := `Usage: paw cli get [OPTION] VAULT_NAME/ITEM_TYPE/ITEM_NAME FIELD
+
+{{ . }}
+
+The field value is printed without any decoration, so it can be used in
+command substitutions. The tags and folder fields are available for all
+the item types, the tags are printed comma separated.
+
+Fields:
+  login           id, name, url, username, password, totp, note
+  note            id, name, note
+  password        id, name, password, note
+  ssh_key         id, name, private_key, passphrase, public_key, fingerprint, note
+` + itemFieldsUsage("id, name") + `
+
+The custom fields are retrieved by name, case-insensitively. Use the
+field: prefix if a custom field has the same name of a built-in field,
+i.e. field:password. The code is printed for the TOTP custom fields.
+
+Options:
+  -h, --help                  Displays this help and exit
+  -n, --no-newline            Do not print the trailing newline
+      --session=SESSION_ID    Sets a session ID to use instead of the env var
+`
+	printUsage(template, cmd.Description())
+}
+
+// Parse parses the arguments and set the usage for the command
+func (cmd *GetCmd) Parse(args []string) error {
+	flags, err := newCommonFlags(flagOpts{Session: true})
+	if err != nil {
+		return err
+	}
+
+	flagSet.BoolVar(&cmd.noNewline, "n", false, "")
+	flagSet.BoolVar(&cmd.noNewline, "no-newline", false, "")
+
+	flags.Parse(cmd, args)
+	if len(flagSet.Args()) != 2 {
+		cmd.Usage()
+		os.Exit(errorClassUsage.ExitCode())
+	}
+	flags.SetEnv()
+
+	itemPath, err := parseItemPath(flagSet.Arg(0), itemPathOptions{fullPath: true})
+	if err != nil {
+		return err
+	}
+	cmd.itemPath = itemPath
+	cmd.field = flagSet.Arg(1)
+	return nil
+}
+
+// Run runs the command
+func (cmd *GetCmd) Run(s paw.Storage) error {
+	key, err := loadVaultKey(s, cmd.vaultName)
+	if err != nil {
+		return err
+	}
+
+	vault, err := s.LoadVault(cmd.vaultName, key)
+	if err != nil {
+		return err
+	}
+
+	item, err := loadItem(s, vault, cmd.itemPath)
+	if err != nil {
+		return err
+	}
+
+	value, err := loadItemField(s, vault, item, cmd.field)
+	if err != nil {
+		return err
+	}
+
+	if cmd.noNewline {
+		fmt.Print(value)
+		return nil
+	}
+	fmt.Println(value)
+	return nil
+}
+
+// loadItemField returns the value of the named field of the item.
+// Unlike itemField the HOTP code is generated incrementing the stored counter.
+func loadItemField(s paw.Storage, vault *paw.Vault, item paw.Item, field string) (string, error) {
+	if login, ok := item.(*paw.Login); ok && field == "totp" && login.TOTP != nil && login.TOTP.IsHOTP() {
+		return paw.NextHOTPCode(s, vault, login)
+	}
+	return itemField(item, field)
+}
+
+// itemField returns the value of the named field of the item
+func itemField(item paw.Item, field string) (string, error) {
+	meta := item.GetMetadata()
+	if strings.HasPrefix(field, "field:") {
+		return customFieldValue(item, strings.TrimPrefix(field, "field:"))
+	}
+	switch field {
+	case "id":
+		return meta.ID(), nil
+	case "name":
+		return meta.Name, nil
+	case "tags":
+		return strings.Join(meta.Tags, ","), nil
+	case "folder":
+		return meta.Folder, nil
+	}
+
+	notFound := fmt.Errorf("%w: %q is not a field of the %s item", errFieldNotFound, field, meta.Type)
+	var note *paw.Note
+	switch v := item.(type) {
+	case *paw.Login:
+		switch field {
+		case "url":
+			if v.URL == nil {
+				return "", nil
+			}
+			return v.URL.String(), nil
+		case "username":
+			return v.Username, nil
+		case "password":
+			if v.Password == nil {
+				return "", nil
+			}
+			return v.Password.Value, nil
+		case "totp":
+			if v.TOTP == nil || v.TOTP.Secret == "" {
+				return "", fmt.Errorf("%w: TOTP is not configured for the item", errFieldNotFound)
+			}
+			return v.TOTP.Code(time.Now().UTC())
+		}
+		note = v.Note
+	case *paw.Password:
+		if field == "password" {
+			return v.Value, nil
+		}
+		note = v.Note
+	case *paw.SSHKey:
+		switch field {
+		case "private_key":
+			return v.PrivateKey, nil
+		case "passphrase":
+			if v.Passphrase == nil {
+				return "", nil
+			}
+			return v.Passphrase.Value, nil
+		case "public_key":
+			return v.PublicKey, nil
+		case "fingerprint":
+			return v.Fingerprint, nil
+		}
+		note = v.Note
+	case *paw.Note:
+		note = v
+	case paw.FieldsItem:
+		if f, ok := paw.ItemFieldByName(v, field); ok {
+			return *f.Value, nil
+		}
+		if v, err := customFieldValue(item, field); err == nil {
+			return v, nil
+		}
+		return "", notFound
+	}
+
+	if field != "note" {
+		if v, err := customFieldValue(item, field); err == nil {
+			return v, nil
+		}
+		return "", notFound
+	}
+	if note == nil {
+		return "", nil
+	}
+	return note.Value, nil
+}
+
+// customFieldValue returns the value of the custom field, the code for the TOTP fields
+func customFieldValue(item paw.Item, name string) (string, error) {
+	notFound := fmt.Errorf("%w: the item has no custom field named %q", errFieldNotFound, name)
+	v, ok := item.(paw.CustomFielder)
+	if !ok {
+		return "", notFound
+	}
+	f, ok := v.GetCustomFields().Get(name)
+	if !ok {
+		return "", notFound
+	}
+	return f.Resolve(time.Now().UTC())
+}
@@ -0,0 +1,170 @@
+// SPDX-FileCopyrightText: 2025 Luca Corbo, Paw contributors
+// SPDX-License-Identifier: AGPL-3.0-or-later
+
+package cli
+
+import (
+	"fmt"
+	"io"
+	"os"
+
+	"lucor.dev/paw/internal/paw"
+)
+
+const (
+	gitInitSubCmd = "init"
+	gitLogSubCmd  = "log"
+	gitPullSubCmd = "pull"
+	gitPushSubCmd = "push"
+)
+
+// GitCmd manages the git repository backing a vault
+type GitCmd struct {
+	command   string
+	vaultName string
+	remote    string
+	maxCount  int
+}
+
+// Name returns the one word command name
+func (cmd *GitCmd) Name() string {
+	return "git"
+}
+
+// Description returns the command description
+func (cmd *GitCmd) Description() string {
+	return "Manages the git repository backing a vault"
+}
+
+// Usage displays the command usage
+func (cmd *GitCmd) Usage() {
+	template := `Usage: paw cli git [OPTION] COMMAND VAULT
+
+{{ . }}
+
+Commands:
+  init       Initialises the git repository for the vault. If the vault does
+             not exist, it is cloned from the --remote repository
+  log        Lists the latest commits
+  pull       Fetches and merges the commits from the remote repository
+  push       Pushes the commits to the remote repository
+
+Once initialised, every change to the vault is committed automatically. The
+commit messages contain only the item type and ID, never the item names.
+On pull, the conflicting changes are merged decrypting the vault index of both
+sides: the most recent change of each item wins, the discarded changes are
+listed as conflicts, see: paw cli conflict
+
+Options:
+      --format=FORMAT         Sets the output format of log: text, json. Default to text
+  -h, --help                  Displays this help and exit
+  -n, --max-count=NUM         Sets the number of commits listed by log. Default to 20
+      --remote=URL            Sets the URL of the remote repository on init
+      --session=SESSION_ID    Sets a session ID to use instead of the env var
+      --template=TEMPLATE     Formats the output of log using the Go template
+`
+	printUsage(template, cmd.Description())
+}
+
+// Parse parses the arguments and set the usage for the command
+func (cmd *GitCmd) Parse(args []string) error {
+	flags, err := newCommonFlags(flagOpts{Session: true})
+	if err != nil {
+		return err
+	}
+
+	flagSet.StringVar(&cmd.remote, "remote", "", "")
+	flagSet.IntVar(&cmd.maxCount, "n", 20, "")
+	flagSet.IntVar(&cmd.maxCount, "max-count", 20, "")
+
+	flags.Parse(cmd, args)
+	cmd.command = flagSet.Arg(0)
+	switch {
+	case cmd.command != gitInitSubCmd && cmd.command != gitLogSubCmd && cmd.command != gitPullSubCmd && cmd.command != gitPushSubCmd,
+		len(flagSet.Args()) != 2:
+		cmd.Usage()
+		os.Exit(errorClassUsage.ExitCode())
+	}
+	flags.SetEnv()
+
+	if cmd.maxCount < 1 {
+		return fmt.Errorf("invalid max count %d, expected a positive number", cmd.maxCount)
+	}
+	cmd.vaultName = flagSet.Arg(1)
+	return nil
+}
+
+// Run runs the command
+func (cmd *GitCmd) Run(s paw.Storage) error {
+	switch cmd.command {
+	case gitInitSubCmd:
+		cloned := !cmd.vaultExists(s)
+		err := paw.InitVaultGit(s, cmd.vaultName, cmd.remote)
+		if err != nil {
+			return err
+		}
+		if cloned {
+			fmt.Printf("[✓] vault %q cloned from %s\n", cmd.vaultName, cmd.remote)
+			return storeAppStateModified(s)
+		}
+		fmt.Printf("[✓] vault %q is now backed by git\n", cmd.vaultName)
+		return nil
+	case gitPushSubCmd:
+		err := paw.PushVaultGit(s, cmd.vaultName)
+		if err != nil {
+			return err
+		}
+		fmt.Printf("[✓] vault %q pushed\n", cmd.vaultName)
+		return nil
+	case gitPullSubCmd:
+		key, err := loadVaultKey(s, cmd.vaultName)
+		if err != nil {
+			return err
+		}
+		res, err := paw.PullVaultGit(s, cmd.vaultName, key)
+		if err != nil {
+			return err
+		}
+		if !res.Updated {
+			fmt.Printf("[✓] vault %q is already up to date\n", cmd.vaultName)
+			return nil
+		}
+		for _, name := range res.Merged {
+			fmt.Printf("merged %s\n", name)
+		}
+		conflicts := []conflictOutput{}
+		for _, c := range res.Conflicts {
+			conflicts = append(conflicts, newConflictOutput(cmd.vaultName, c))
+		}
+		printMergeConflicts(os.Stdout, conflicts)
+		fmt.Printf("[✓] vault %q pulled\n", cmd.vaultName)
+		if len(conflicts) > 0 {
+			fmt.Fprintf(os.Stderr, "[!] see: paw cli conflict ls %s\n", cmd.vaultName)
+		}
+		return storeAppStateModified(s)
+	}
+
+	commits, err := paw.VaultGitLog(s, cmd.vaultName, cmd.maxCount)
+	if err != nil {
+		return err
+	}
+	return printOutput(commits, func(w io.Writer) {
+		for _, c := range commits {
+			fmt.Fprintf(w, "%s %s %s\n", c.Hash[:7], c.Date.Local().Format("2006-01-02 15:04:05"), c.Message)
+		}
+	})
+}
+
+// vaultExists reports whether the vault exists into the storage
+func (cmd *GitCmd) vaultExists(s paw.Storage) bool {
+	vaults, err := s.Vaults()
+	if err != nil {
+		return false
+	}
+	for _, name := range vaults {
+		if name == cmd.vaultName {
+			return true
+		}
+	}
+	return false
+}
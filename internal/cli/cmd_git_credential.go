@@ -0,0 +1,263 @@
+// SPDX-FileCopyrightText: 2025 Luca Corbo, Paw contributors
+// SPDX-License-Identifier: AGPL-3.0-or-later
+
+package cli
+
+import (
+	"bufio"
+	"fmt"
+	"io"
+	"net/url"
+	"os"
+	"strings"
+
+	"lucor.dev/paw/internal/paw"
+)
+
+// GitCredentialCmd implements the git credential helper protocol
+type GitCredentialCmd struct {
+	vaultName string
+	action    string
+}
+
+// Name returns the one word command name
+func (cmd *GitCredentialCmd) Name() string {
+	return "git-credential"
+}
+
+// Description returns the command description
+func (cmd *GitCredentialCmd) Description() string {
+	return "Git credential helper backed by login items"
+}
+
+// Usage displays the command usage
+func (cmd *GitCredentialCmd) Usage() {
+	template := `Usage: paw cli git-credential [OPTION] get|store|erase
+
+{{ . }}
+
+The command implements the git credential helper protocol. The credentials
+are matched against the URL of the login items: the protocol and host must be
+the same, items with the same path are preferred. Credentials for the http
+protocol are returned only if the login allows HTTP.
+
+  get      prints the username and password of the best matching login
+  store    updates the password of the matching login or creates a new one
+  erase    removes the matching login only if the password is the same
+
+Git runs the helper without a terminal, so the vault must be unlocked using
+a session. See "paw cli unlock -h".
+
+Options:
+  -h, --help                  Displays this help and exit
+      --session=SESSION_ID    Sets a session ID to use instead of the env var
+      --vault=VAULT_NAME      Sets the vault to use. Default to the PAW_VAULT env var.
+                              Can be omitted if only one vault exists
+
+Example:
+  git config --global credential.helper '!paw cli git-credential --vault=myvault'
+`
+	printUsage(template, cmd.Description())
+}
+
+// Parse parses the arguments and set the usage for the command
+func (cmd *GitCredentialCmd) Parse(args []string) error {
+	flags, err := newCommonFlags(flagOpts{Session: true})
+	if err != nil {
+		return err
+	}
+
+	flagSet.StringVar(&cmd.vaultName, "vault", "", "")
+
+	flags.Parse(cmd, args)
+	if len(flagSet.Args()) != 1 {
+		cmd.Usage()
+		os.Exit(errorClassUsage.ExitCode())
+	}
+	flags.SetEnv()
+
+	cmd.action = flagSet.Arg(0)
+	switch cmd.action {
+	case "get", "store", "erase":
+	default:
+		return fmt.Errorf("invalid action %q, expected one of: get, store, erase", cmd.action)
+	}
+	return nil
+}
+
+// Run runs the command
+func (cmd *GitCredentialCmd) Run(s paw.Storage) error {
+	req, err := readGitCredential(os.Stdin)
+	if err != nil {
+		return err
+	}
+	if req.host == "" {
+		// nothing to match, let git try the next helper
+		return nil
+	}
+
+	vaultName, err := credentialVaultName(s, cmd.vaultName)
+	if err != nil {
+		return err
+	}
+
+	key, err := loadVaultKey(s, vaultName)
+	if err != nil {
+		return err
+	}
+
+	vault, err := s.LoadVault(vaultName, key)
+	if err != nil {
+		return err
+	}
+
+	switch cmd.action {
+	case "get":
+		return cmd.get(s, vault, req)
+	case "store":
+		return cmd.store(s, vault, req)
+	case "erase":
+		return cmd.erase(s, vault, req)
+	}
+	return nil
+}
+
+func (cmd *GitCredentialCmd) get(s paw.Storage, vault *paw.Vault, req gitCredential) error {
+	login, err := findGitCredentialLogin(s, vault, req)
+	if err != nil {
+		return err
+	}
+	if login == nil {
+		return nil
+	}
+	res := gitCredential{
+		username: login.Username,
+	}
+	if login.Password != nil {
+		res.password = login.Password.Value
+	}
+	return res.Write(os.Stdout)
+}
+
+// findGitCredentialLogin returns the login for the git credential request, nil if none.
+// The login must match the request host, the domain match is not enough, and
+// the http protocol is allowed only if the login allows it.
+func findGitCredentialLogin(s paw.Storage, vault *paw.Vault, req gitCredential) (*paw.Login, error) {
+	logins, match, err := findLoginsByURL(s, vault, req.URL(), req.username)
+	if err != nil {
+		return nil, err
+	}
+	if match < paw.HostURLMatch {
+		return nil, nil
+	}
+	for _, login := range logins {
+		if req.protocol == "http" && (login.Autofill == nil || !login.Autofill.AllowHTTP) {
+			continue
+		}
+		return login, nil
+	}
+	return nil, nil
+}
+
+func (cmd *GitCredentialCmd) store(s paw.Storage, vault *paw.Vault, req gitCredential) error {
+	if req.username == "" || req.password == "" {
+		return nil
+	}
+	return storeCredential(s, vault, req.URL(), req.username, req.password)
+}
+
+func (cmd *GitCredentialCmd) erase(s paw.Storage, vault *paw.Vault, req gitCredential) error {
+	if req.username == "" || req.password == "" {
+		return nil
+	}
+	return eraseCredential(s, vault, req.URL(), req.username, req.password)
+}
+
+// gitCredential represents the attributes exchanged using the git credential helper protocol.
+// See https://git-scm.com/docs/git-credential#IOFMT
+type gitCredential struct {
+	protocol string
+	host     string
+	path     string
+	username string
+	password string
+}
+
+// URL returns the URL of the credential
+func (c gitCredential) URL() *url.URL {
+	return &url.URL{
+		Scheme: c.protocol,
+		Host:   c.host,
+		Path:   c.path,
+	}
+}
+
+// Write writes the credential attributes
+func (c gitCredential) Write(w io.Writer) error {
+	attrs := []struct{ k, v string }{
+		{"protocol", c.protocol},
+		{"host", c.host},
+		{"path", c.path},
+		{"username", c.username},
+		{"password", c.password},
+	}
+	for _, attr := range attrs {
+		if attr.v == "" {
+			continue
+		}
+		if strings.ContainsAny(attr.v, "\n\x00") {
+			return fmt.Errorf("invalid %s: contains a newline or NUL character", attr.k)
+		}
+		_, err := fmt.Fprintf(w, "%s=%s\n", attr.k, attr.v)
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// readGitCredential reads the credential attributes until an empty line or EOF
+func readGitCredential(r io.Reader) (gitCredential, error) {
+	c := gitCredential{}
+	scanner := bufio.NewScanner(r)
+	for scanner.Scan() {
+		line := scanner.Text()
+		if line == "" {
+			break
+		}
+		k, v, ok := strings.Cut(line, "=")
+		if !ok {
+			return c, fmt.Errorf("invalid git credential attribute %q", line)
+		}
+		switch k {
+		case "protocol":
+			c.protocol = v
+		case "host":
+			c.host = v
+		case "path":
+			c.path = v
+		case "username":
+			c.username = v
+		case "password":
+			c.password = v
+		case "url":
+			u, err := url.Parse(v)
+			if err != nil {
+				return c, fmt.Errorf("invalid git credential url: %w", err)
+			}
+			c.protocol = u.Scheme
+			c.host = u.Host
+			c.path = strings.TrimPrefix(u.Path, "/")
+			if u.User != nil {
+				c.username = u.User.Username()
+				if p, ok := u.User.Password(); ok {
+					c.password = p
+				}
+			}
+		}
+	}
+	if err := scanner.Err(); err != nil {
+		return c, fmt.Errorf("could not read the git credential: %w", err)
+	}
+	return c, nil
+}
@@ -0,0 +1,162 @@
+// SPDX-FileCopyrightText: 2025 Luca Corbo, Paw contributors
+// SPDX-License-Identifier: AGPL-3.0-or-later
+
+package cli
+
+import (
+	"fmt"
+	"io"
+	"os"
+	"text/tabwriter"
+	"time"
+
+	"lucor.dev/paw/internal/paw"
+)
+
+// HistoryCmd shows and restores the previous passwords of an item
+type HistoryCmd struct {
+	itemPath
+	restore int
+}
+
+// Name returns the one word command name
+func (cmd *HistoryCmd) Name() string {
+	return "history"
+}
+
+// Description returns the command description
+func (cmd *HistoryCmd) Description() string {
+	return "Shows the password history of an item"
+}
+
+// Usage displays the command usage
+func (cmd *HistoryCmd) Usage() {
+	template := `Usage: paw cli history [OPTION] VAULT_NAME/ITEM_TYPE/ITEM_NAME
+
+{{ . }}
+
+The previous passwords of login and password items are listed from the most
+recent, each one with the time it has been replaced.
+
+Options:
+      --format=FORMAT         Sets the output format: text, json. Default to text
+  -h, --help                  Displays this help and exit
+      --restore=INDEX         Restores the password at the index. The current
+                              password is added to the history
+      --session=SESSION_ID    Sets a session ID to use instead of the env var
+      --template=TEMPLATE     Formats the output using the Go template
+`
+	printUsage(template, cmd.Description())
+}
+
+// Parse parses the arguments and set the usage for the command
+func (cmd *HistoryCmd) Parse(args []string) error {
+	flags, err := newCommonFlags(flagOpts{Session: true})
+	if err != nil {
+		return err
+	}
+
+	flagSet.IntVar(&cmd.restore, "restore", 0, "")
+
+	flags.Parse(cmd, args)
+	if len(flagSet.Args()) != 1 {
+		cmd.Usage()
+		os.Exit(errorClassUsage.ExitCode())
+	}
+	flags.SetEnv()
+
+	itemPath, err := parseItemPath(flagSet.Arg(0), itemPathOptions{fullPath: true})
+	if err != nil {
+		return err
+	}
+	switch itemPath.itemType {
+	case paw.LoginItemType, paw.PasswordItemType:
+	default:
+		return fmt.Errorf("the password history is not available for the %s items", itemPath.itemType)
+	}
+	if cmd.restore < 0 {
+		return fmt.Errorf("invalid history index %d", cmd.restore)
+	}
+	cmd.itemPath = itemPath
+	return nil
+}
+
+// Run runs the command
+func (cmd *HistoryCmd) Run(s paw.Storage) error {
+	key, err := loadVaultKey(s, cmd.vaultName)
+	if err != nil {
+		return err
+	}
+
+	vault, err := s.LoadVault(cmd.vaultName, key)
+	if err != nil {
+		return err
+	}
+
+	item, err := loadItem(s, vault, cmd.itemPath)
+	if err != nil {
+		return err
+	}
+
+	var password *paw.Password
+	switch v := item.(type) {
+	case *paw.Login:
+		password = v.Password
+	case *paw.Password:
+		password = v
+	}
+	if password == nil {
+		password = paw.NewPassword()
+	}
+
+	if cmd.restore > 0 {
+		if cmd.restore > len(password.History) {
+			return fmt.Errorf("password history entry %d: %w", cmd.restore, errFieldNotFound)
+		}
+		// the index is 1-based as displayed
+		err = password.RestoreHistory(cmd.restore-1, time.Now().UTC())
+		if err != nil {
+			return err
+		}
+		err = storeItem(s, vault, item)
+		if err != nil {
+			return err
+		}
+		fmt.Fprintf(os.Stderr, "[✓] password of item %q restored\n", cmd.itemName)
+		return nil
+	}
+
+	out := []passwordHistoryOutput{}
+	for i, entry := range password.History {
+		out = append(out, passwordHistoryOutput{
+			Index:    i + 1,
+			Password: entry.Value,
+			Replaced: entry.Replaced,
+		})
+	}
+	return printOutput(out, func(w io.Writer) {
+		printPasswordHistory(w, out)
+	})
+}
+
+// passwordHistoryOutput is the structured representation of a password history entry
+type passwordHistoryOutput struct {
+	Index    int       `json:"index"`
+	Password string    `json:"password"`
+	Replaced time.Time `json:"replaced"`
+}
+
+// printPasswordHistory prints the password history as a table
+func printPasswordHistory(w io.Writer, history []passwordHistoryOutput) {
+	if len(history) == 0 {
+		fmt.Fprintln(w, "No password history found")
+		return
+	}
+
+	tw := tabwriter.NewWriter(w, 0, 0, 1, ' ', tabwriter.Debug)
+	fmt.Fprintln(tw, "Index\tReplaced\tPassword")
+	for _, entry := range history {
+		fmt.Fprintf(tw, "%d\t%s\t%s\n", entry.Index, entry.Replaced.Local().Format("2006-01-02 15:04:05"), entry.Password)
+	}
+	tw.Flush()
+}
@@ -0,0 +1,339 @@
+// SPDX-FileCopyrightText: 2025 Luca Corbo, Paw contributors
+// SPDX-License-Identifier: AGPL-3.0-or-later
+
+package cli
+
+import (
+	"bufio"
+	"bytes"
+	"fmt"
+	"io"
+	"os"
+	"time"
+
+	"filippo.io/age"
+	"filippo.io/age/armor"
+
+	"lucor.dev/paw/internal/importer"
+	"lucor.dev/paw/internal/paw"
+)
+
+var (
+	ageHeader      = []byte("age-encryption.org/")
+	ageArmorHeader = []byte(armor.Header)
+)
+
+// ImportCmd imports items into the vault from file
+type ImportCmd struct {
+	vaultName    string
+	inputPath    string
+	identityPath string
+	onConflict   paw.OnConflict
+	format       importer.Format
+	dryRun       bool
+}
+
+// Name returns the one word command name
+func (cmd *ImportCmd) Name() string {
+	return "import"
+}
+
+// Description returns the command description
+func (cmd *ImportCmd) Description() string {
+	return "Imports items into the vault"
+}
+
+// Usage displays the command usage
+func (cmd *ImportCmd) Usage() {
+	template := `Usage: paw cli import [OPTION] VAULT FILE
+
+{{ . }}
+
+FILE is an export in the format specified by --from, optionally encrypted using age.
+Passphrase encrypted files will prompt for the passphrase.
+
+Formats:
+  paw          Paw JSON export
+  bitwarden    Bitwarden unencrypted JSON export
+  1password    1Password 1PUX export
+  keepass      KeePass 2 XML export
+  csv          Chrome and Firefox CSV passwords export
+
+Options:
+  -h, --help                  Displays this help and exit
+      --dry-run               Reports the import actions without storing any item
+      --format=FORMAT         Sets the output format: text, json. Default to text
+      --from=FORMAT           Format of the file to import. Default to paw
+      --identity=FILE         Decrypts the file using the age identity file
+      --on-conflict=STRATEGY  Strategy to use when an item with the same name already exists:
+                              skip, overwrite, rename. Default to skip
+      --session=SESSION_ID    Sets a session ID to use instead of the env var
+      --template=TEMPLATE     Formats the output using the Go template
+`
+	printUsage(template, cmd.Description())
+}
+
+// Parse parses the arguments and set the usage for the command
+func (cmd *ImportCmd) Parse(args []string) error {
+	flags, err := newCommonFlags(flagOpts{Session: true})
+	if err != nil {
+		return err
+	}
+
+	var onConflict, from string
+	flagSet.BoolVar(&cmd.dryRun, "dry-run", false, "")
+	flagSet.StringVar(&from, "from", string(importer.Paw), "")
+	flagSet.StringVar(&cmd.identityPath, "identity", "", "")
+	flagSet.StringVar(&onConflict, "on-conflict", string(paw.OnConflictSkip), "")
+
+	flags.Parse(cmd, args)
+	if len(flagSet.Args()) != 2 {
+		cmd.Usage()
+		os.Exit(errorClassUsage.ExitCode())
+	}
+	flags.SetEnv()
+
+	cmd.onConflict, err = paw.OnConflictFromString(onConflict)
+	if err != nil {
+		return err
+	}
+
+	cmd.format, err = importer.FormatFromString(from)
+	if err != nil {
+		return err
+	}
+
+	cmd.vaultName = flagSet.Arg(0)
+	cmd.inputPath = flagSet.Arg(1)
+	return nil
+}
+
+// Run runs the command
+func (cmd *ImportCmd) Run(s paw.Storage) error {
+	appState, err := s.LoadAppState()
+	if err != nil {
+		return err
+	}
+
+	imported, err := cmd.readImported()
+	if err != nil {
+		return err
+	}
+
+	key, err := loadVaultKey(s, cmd.vaultName)
+	if err != nil {
+		return err
+	}
+
+	vault, err := s.LoadVault(cmd.vaultName, key)
+	if err != nil {
+		return err
+	}
+
+	unlock, err := paw.LockVault(s, vault)
+	if err != nil {
+		return err
+	}
+	defer unlock()
+
+	entries := imported.Resolve(vault, cmd.onConflict)
+	if cmd.dryRun {
+		return printImportEntries(cmd.vaultName, entries, true)
+	}
+
+	err = importEntries(s, vault, entries)
+	if err != nil {
+		return err
+	}
+
+	now := time.Now().UTC()
+	appState.Modified = now
+	err = s.StoreAppState(appState)
+	if err != nil {
+		return err
+	}
+
+	return printImportEntries(cmd.vaultName, entries, false)
+}
+
+// readImported reads the items from the input file, decrypting if needed
+func (cmd *ImportCmd) readImported() (*paw.Imported, error) {
+	f, err := os.Open(cmd.inputPath)
+	if err != nil {
+		return nil, fmt.Errorf("could not open the import file: %w", err)
+	}
+	defer f.Close()
+
+	r, err := cmd.decrypt(bufio.NewReader(f))
+	if err != nil {
+		return nil, fmt.Errorf("could not decrypt the import file: %w", err)
+	}
+
+	imported, err := importer.Import(cmd.format, r)
+	if err != nil {
+		return nil, fmt.Errorf("could not decode the import file: %w", err)
+	}
+	return imported, nil
+}
+
+// decrypt returns a reader decrypting the content if age encrypted
+func (cmd *ImportCmd) decrypt(br *bufio.Reader) (io.Reader, error) {
+	var r io.Reader = br
+	header, _ := br.Peek(len(ageArmorHeader))
+	switch {
+	case bytes.HasPrefix(header, ageArmorHeader):
+		r = armor.NewReader(br)
+	case bytes.HasPrefix(header, ageHeader):
+	default:
+		// not encrypted
+		return br, nil
+	}
+
+	var identities []age.Identity
+	if cmd.identityPath != "" {
+		f, err := os.Open(cmd.identityPath)
+		if err != nil {
+			return nil, fmt.Errorf("could not open the identity file: %w", err)
+		}
+		defer f.Close()
+		identities, err = age.ParseIdentities(f)
+		if err != nil {
+			return nil, err
+		}
+	} else {
+		passphrase, err := askPassword("Enter the passphrase to decrypt the file")
+		if err != nil {
+			return nil, err
+		}
+		identity, err := age.NewScryptIdentity(passphrase)
+		if err != nil {
+			return nil, err
+		}
+		identities = append(identities, identity)
+	}
+	return age.Decrypt(r, identities...)
+}
+
+// importEntries stores the import entries into the vault.
+// On failure the stored items are rolled back.
+func importEntries(s paw.Storage, vault *paw.Vault, entries []paw.ImportEntry) error {
+	// previous holds the overwritten items to restore on failure
+	previous := []paw.Item{}
+	stored := []paw.Item{}
+
+	rollback := func() {
+		for _, item := range stored {
+			_ = s.DeleteItem(vault, item)
+			vault.DeleteItem(item)
+		}
+		for _, item := range previous {
+			_ = s.StoreItem(vault, item)
+			_ = vault.AddItem(item)
+		}
+	}
+
+	for _, entry := range entries {
+		item := entry.Item
+		switch entry.Action {
+		case paw.ImportSkip:
+			continue
+		case paw.ImportOverwrite:
+			meta, ok := vault.ItemMetadataByName(item.GetMetadata().Type, item.GetMetadata().Name)
+			if ok {
+				old, err := s.LoadItem(vault, meta)
+				if err == nil {
+					previous = append(previous, old)
+				}
+			}
+		default:
+			stored = append(stored, item)
+		}
+
+		err := s.StoreItem(vault, item)
+		if err != nil {
+			rollback()
+			return fmt.Errorf("could not import item %q: %w", entry.Name, err)
+		}
+		err = vault.AddItem(item)
+		if err != nil {
+			rollback()
+			return fmt.Errorf("could not import item %q: %w", entry.Name, err)
+		}
+	}
+
+	vault.Modified = time.Now().UTC()
+	err := s.StoreVault(vault)
+	if err != nil {
+		rollback()
+		return err
+	}
+	return nil
+}
+
+// importOutput is the structured representation of an import
+type importOutput struct {
+	Vault       string              `json:"vault"`
+	DryRun      bool                `json:"dry_run"`
+	Entries     []importEntryOutput `json:"entries"`
+	Created     int                 `json:"created"`
+	Overwritten int                 `json:"overwritten"`
+	Renamed     int                 `json:"renamed"`
+	Skipped     int                 `json:"skipped"`
+}
+
+// importEntryOutput is the structured representation of an import entry
+type importEntryOutput struct {
+	Action paw.ImportAction `json:"action"`
+	Type   string           `json:"type"`
+	// Name is the item name into the import file
+	Name string `json:"name"`
+	// ImportedName is the item name into the vault, it differs from Name on rename
+	ImportedName string `json:"imported_name"`
+}
+
+// printImportEntries prints a summary of the import entries.
+// When dryRun is true the summary reports the actions that would be performed.
+func printImportEntries(vaultName string, entries []paw.ImportEntry, dryRun bool) error {
+	out := importOutput{
+		Vault:   vaultName,
+		DryRun:  dryRun,
+		Entries: []importEntryOutput{},
+	}
+	for _, entry := range entries {
+		meta := entry.Item.GetMetadata()
+		out.Entries = append(out.Entries, importEntryOutput{
+			Action:       entry.Action,
+			Type:         meta.Type.String(),
+			Name:         entry.Name,
+			ImportedName: meta.Name,
+		})
+		switch entry.Action {
+		case paw.ImportCreate:
+			out.Created++
+		case paw.ImportOverwrite:
+			out.Overwritten++
+		case paw.ImportRename:
+			out.Renamed++
+		case paw.ImportSkip:
+			out.Skipped++
+		}
+	}
+
+	return printOutput(out, func(w io.Writer) {
+		for _, entry := range out.Entries {
+			switch entry.Action {
+			case paw.ImportRename:
+				fmt.Fprintf(w, "%-9s %s/%s -> %s\n", entry.Action, entry.Type, entry.Name, entry.ImportedName)
+			default:
+				fmt.Fprintf(w, "%-9s %s/%s\n", entry.Action, entry.Type, entry.ImportedName)
+			}
+		}
+		if out.DryRun {
+			fmt.Fprintf(w, "[i] dry run: %d to create, %d to overwrite, %d to rename, %d to skip\n",
+				out.Created, out.Overwritten, out.Renamed, out.Skipped)
+			return
+		}
+		fmt.Fprintf(w, "[✓] %d created, %d overwritten, %d renamed, %d skipped\n",
+			out.Created, out.Overwritten, out.Renamed, out.Skipped)
+	})
+}
@@ -32,7 +32,9 @@ func (cmd *InitCmd) Usage() {
 {{ . }}
 
 Options:
-  -h, --help  Displays this help and exit
+      --format=FORMAT         Sets the output format: text, json. Default to text
+  -h, --help                  Displays this help and exit
+      --template=TEMPLATE     Formats the output using the Go template
 `
 	printUsage(template, cmd.Description())
 }
@@ -47,7 +49,7 @@ func (cmd *InitCmd) Parse(args []string) error {
 	flags.Parse(cmd, args)
 	if len(flagSet.Args()) != 1 {
 		cmd.Usage()
-		os.Exit(1)
+		os.Exit(errorClassUsage.ExitCode())
 	}
 
 	cmd.vaultName = flagSet.Arg(0)
@@ -56,7 +58,7 @@ func (cmd *InitCmd) Parse(args []string) error {
 
 // Run runs the command
 func (cmd *InitCmd) Run(s paw.Storage) error {
-	fmt.Printf("Initializing vault %q\n", cmd.vaultName)
+	fmt.Fprintf(os.Stderr, "Initializing vault %q\n", cmd.vaultName)
 	password, err := askPasswordWithConfirm()
 	if err != nil {
 		return err
@@ -70,6 +72,5 @@ func (cmd *InitCmd) Run(s paw.Storage) error {
 	if err != nil {
 		return err
 	}
-	fmt.Printf("[✓] vault %q created\n", cmd.vaultName)
-	return nil
+	return printResult(resultOutput{Vault: cmd.vaultName, Action: "created"}, fmt.Sprintf("vault %q created", cmd.vaultName))
 }
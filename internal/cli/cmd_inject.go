@@ -0,0 +1,150 @@
+// SPDX-FileCopyrightText: 2025 Luca Corbo, Paw contributors
+// SPDX-License-Identifier: AGPL-3.0-or-later
+
+package cli
+
+import (
+	"bytes"
+	"fmt"
+	"io"
+	"net/url"
+	"os"
+	"path/filepath"
+	"strings"
+	"text/template"
+
+	"lucor.dev/paw/internal/paw"
+)
+
+// InjectCmd renders a template replacing the secret references
+type InjectCmd struct {
+	input  string
+	output string
+}
+
+// Name returns the one word command name
+func (cmd *InjectCmd) Name() string {
+	return "inject"
+}
+
+// Description returns the command description
+func (cmd *InjectCmd) Description() string {
+	return "Renders a template replacing the secret references"
+}
+
+// Usage displays the command usage
+func (cmd *InjectCmd) Usage() {
+	template := `Usage: paw cli inject [OPTION]
+
+{{ . }}
+
+The template is a Go template where the secrets are referenced using the paw
+function:
+  {{ "{{" }} paw "VAULT_NAME/ITEM_TYPE/ITEM_NAME" "FIELD" {{ "}}" }}
+  {{ "{{" }} paw "paw://VAULT_NAME/ITEM_TYPE/ITEM_NAME/FIELD" {{ "}}" }}
+  {{ "{{" }} paw "paw://VAULT_NAME/ITEM_TYPE/ITEM_NAME" "FIELD" {{ "}}" }}
+
+See "paw cli get -h" for the available fields.
+
+The secrets are resolved using the session, if any, otherwise the vault
+password is asked. The output is written only if all the references are
+resolved. The output file is created with 0600 permissions.
+
+Options:
+  -h, --help                  Displays this help and exit
+  -i, --in-file=FILE          Reads the template from the file. Default to stdin
+  -o, --out-file=FILE         Writes the output to the file. Default to stdout
+      --session=SESSION_ID    Sets a session ID to use instead of the env var
+
+Example:
+  paw cli inject -i config.tpl -o config.yml
+`
+	printUsage(template, cmd.Description())
+}
+
+// Parse parses the arguments and set the usage for the command
+func (cmd *InjectCmd) Parse(args []string) error {
+	flags, err := newCommonFlags(flagOpts{Session: true})
+	if err != nil {
+		return err
+	}
+
+	flagSet.StringVar(&cmd.input, "i", "", "")
+	flagSet.StringVar(&cmd.input, "in-file", "", "")
+	flagSet.StringVar(&cmd.output, "o", "", "")
+	flagSet.StringVar(&cmd.output, "out-file", "", "")
+
+	flags.Parse(cmd, args)
+	if len(flagSet.Args()) != 0 {
+		cmd.Usage()
+		os.Exit(errorClassUsage.ExitCode())
+	}
+	flags.SetEnv()
+	return nil
+}
+
+// Run runs the command
+func (cmd *InjectCmd) Run(s paw.Storage) error {
+	var r io.Reader = os.Stdin
+	name := "stdin"
+	if cmd.input != "" {
+		f, err := os.Open(cmd.input)
+		if err != nil {
+			return fmt.Errorf("could not open the template: %w", err)
+		}
+		defer f.Close()
+		r = f
+		name = filepath.Base(cmd.input)
+	}
+
+	text, err := io.ReadAll(r)
+	if err != nil {
+		return fmt.Errorf("could not read the template: %w", err)
+	}
+
+	resolver := newSecretResolver(s)
+	tpl, err := template.New(name).Option("missingkey=error").Funcs(template.FuncMap{
+		"paw": func(ref string, field ...string) (string, error) {
+			sr, err := injectSecretRef(ref, field...)
+			if err != nil {
+				return "", err
+			}
+			return resolver.Resolve(sr)
+		},
+	}).Parse(string(text))
+	if err != nil {
+		return fmt.Errorf("could not parse the template: %w", err)
+	}
+
+	// render in memory so nothing is written if a reference cannot be resolved
+	buf := &bytes.Buffer{}
+	err = tpl.Execute(buf, nil)
+	if err != nil {
+		return fmt.Errorf("could not execute the template: %w", err)
+	}
+
+	if cmd.output == "" {
+		_, err = os.Stdout.Write(buf.Bytes())
+		return err
+	}
+	err = paw.WriteFileAtomic(cmd.output, buf.Bytes())
+	if err != nil {
+		return fmt.Errorf("could not write the output file: %w", err)
+	}
+	return nil
+}
+
+// injectSecretRef returns the secret reference for the arguments of the paw
+// template function. The field, if any, is appended to the reference according
+// to its form.
+func injectSecretRef(ref string, field ...string) (secretRef, error) {
+	switch {
+	case len(field) > 1:
+		return secretRef{}, fmt.Errorf("paw: too many arguments, expected a reference and a field")
+	case len(field) == 1 && strings.HasPrefix(ref, secretRefScheme):
+		ref = ref + "/" + url.PathEscape(field[0])
+	case len(field) == 1:
+		ref = ref + ":" + field[0]
+	}
+	return parseSecretRef(ref)
+}
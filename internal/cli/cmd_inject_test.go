@@ -0,0 +1,72 @@
+// SPDX-FileCopyrightText: 2025 Luca Corbo, Paw contributors
+// SPDX-License-Identifier: AGPL-3.0-or-later
+
+package cli
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"lucor.dev/paw/internal/paw"
+)
+
+func TestInjectSecretRef(t *testing.T) {
+	github := itemPath{vaultName: "work", itemType: paw.LoginItemType, itemName: "github"}
+	tests := map[string]struct {
+		ref     string
+		field   []string
+		want    secretRef
+		wantErr bool
+	}{
+		"path form": {
+			ref:  "work/login/github:password",
+			want: secretRef{itemPath: github, field: "password"},
+		},
+		"path form with field": {
+			ref:   "work/login/github",
+			field: []string{"password"},
+			want:  secretRef{itemPath: github, field: "password"},
+		},
+		"uri form": {
+			ref:  "paw://work/login/github/password",
+			want: secretRef{itemPath: github, field: "password"},
+		},
+		"uri form with field": {
+			ref:   "paw://work/login/github",
+			field: []string{"password"},
+			want:  secretRef{itemPath: github, field: "password"},
+		},
+		"uri form with escaped field": {
+			ref:   "paw://work/login/github",
+			field: []string{"api/key"},
+			want:  secretRef{itemPath: github, field: "api/key"},
+		},
+		"uri form with field already set": {
+			ref:     "paw://work/login/github/password",
+			field:   []string{"username"},
+			wantErr: true,
+		},
+		"path form missing field": {
+			ref:     "work/login/github",
+			wantErr: true,
+		},
+		"too many arguments": {
+			ref:     "work/login/github",
+			field:   []string{"username", "password"},
+			wantErr: true,
+		},
+	}
+	for name, tt := range tests {
+		t.Run(name, func(t *testing.T) {
+			got, err := injectSecretRef(tt.ref, tt.field...)
+			if tt.wantErr {
+				assert.Error(t, err)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, tt.want, got)
+		})
+	}
+}
@@ -5,6 +5,7 @@ package cli
 
 import (
 	"fmt"
+	"io"
 
 	"lucor.dev/paw/internal/paw"
 	"lucor.dev/paw/internal/tree"
@@ -13,6 +14,8 @@ import (
 // List lists the vaults content
 type ListCmd struct {
 	itemPath
+	tags   stringsFlag
+	folder string
 }
 
 // Name returns the one word command name
@@ -32,7 +35,13 @@ func (cmd *ListCmd) Usage() {
 {{ . }}
 
 Options:
-  -h, --help  Displays this help and exit
+      --folder=PATH           Lists only the items into the folder and its subfolders
+      --format=FORMAT         Sets the output format: text, json. Default to text
+  -h, --help                  Displays this help and exit
+      --session=SESSION_ID    Sets a session ID to use instead of the env var
+      --tag=TAG               Lists only the items with the tag. Can be specified
+                              multiple times to match all the tags
+      --template=TEMPLATE     Formats the output using the Go template
 `
 	printUsage(template, cmd.Description())
 }
@@ -44,9 +53,15 @@ func (cmd *ListCmd) Parse(args []string) error {
 		return err
 	}
 
+	flagSet.Var(&cmd.tags, "tag", "")
+	flagSet.StringVar(&cmd.folder, "folder", "", "")
+
 	flags.Parse(cmd, args)
 	flags.SetEnv()
 	if len(flagSet.Args()) == 0 {
+		if len(cmd.tags) > 0 || cmd.folder != "" {
+			return fmt.Errorf("the --tag and --folder options require the vault name")
+		}
 		return nil
 	}
 
@@ -67,33 +82,61 @@ func (cmd *ListCmd) Run(s paw.Storage) error {
 	}
 
 	if cmd.vaultName == "" {
-		tree.Print(vaultNode)
-		return nil
+		out := []vaultOutput{}
+		for _, v := range vaultNode.Child {
+			out = append(out, vaultOutput{Name: v.Value})
+		}
+		return printOutput(out, func(w io.Writer) {
+			tree.Print(vaultNode)
+		})
 	}
 
-	itemsNode, err := cmd.items(s)
+	meta, err := cmd.items(s)
 	if err != nil {
 		return err
 	}
 
+	out := []itemMetadataOutput{}
+	for _, v := range meta {
+		out = append(out, newItemMetadataOutput(cmd.vaultName, v))
+	}
+	return printOutput(out, func(w io.Writer) {
+		cmd.printTree(w, meta)
+	})
+}
+
+// printTree prints the items grouped by type as a tree
+func (cmd *ListCmd) printTree(w io.Writer, meta []*paw.Metadata) {
+	nodes := map[paw.ItemType]*tree.Node{}
+	for _, v := range meta {
+		node, ok := nodes[v.Type]
+		if !ok {
+			node = &tree.Node{Value: v.Type.String()}
+			nodes[v.Type] = node
+		}
+		node.Child = append(node.Child, tree.Node{Value: v.Name})
+	}
+
 	n := tree.Node{Value: "paw/" + cmd.vaultName}
-	for _, v := range itemsNode {
-		if len(v.Child) == 0 {
-			continue
+	for _, t := range paw.ItemTypes() {
+		if node, ok := nodes[t]; ok {
+			n.Child = append(n.Child, *node)
 		}
-		n.Child = append(n.Child, v)
 	}
 
+	if len(n.Child) == 0 && (len(cmd.tags) > 0 || cmd.folder != "") {
+		fmt.Fprintln(w, "no items found")
+		return
+	}
 	if len(n.Child) == 0 {
-		fmt.Printf("vault %q is empty\n", cmd.vaultName)
-		return nil
+		fmt.Fprintf(w, "vault %q is empty\n", cmd.vaultName)
+		return
 	}
 
 	tree.Print(n)
-	return nil
 }
 
-func (cmd *ListCmd) items(s paw.Storage) ([]tree.Node, error) {
+func (cmd *ListCmd) items(s paw.Storage) ([]*paw.Metadata, error) {
 	key, err := loadVaultKey(s, cmd.vaultName)
 	if err != nil {
 		return nil, err
@@ -103,35 +146,14 @@ func (cmd *ListCmd) items(s paw.Storage) ([]tree.Node, error) {
 	if err != nil {
 		return nil, err
 	}
+	warnConflicts(vault)
 
-	meta := vault.FilterItemMetadata(&paw.VaultFilterOptions{
+	return vault.FilterItemMetadata(&paw.VaultFilterOptions{
 		Name:     cmd.itemName,
 		ItemType: cmd.itemType,
-	})
-
-	loginNode := tree.Node{Value: paw.LoginItemType.String()}
-	noteNode := tree.Node{Value: paw.NoteItemType.String()}
-	passwordNode := tree.Node{Value: paw.PasswordItemType.String()}
-	sshkeyNode := tree.Node{Value: paw.SSHKeyItemType.String()}
-	for _, v := range meta {
-		switch v.Type {
-		case paw.LoginItemType:
-			loginNode.Child = append(loginNode.Child, tree.Node{Value: v.Name})
-		case paw.NoteItemType:
-			noteNode.Child = append(noteNode.Child, tree.Node{Value: v.Name})
-		case paw.PasswordItemType:
-			passwordNode.Child = append(passwordNode.Child, tree.Node{Value: v.Name})
-		case paw.SSHKeyItemType:
-			sshkeyNode.Child = append(sshkeyNode.Child, tree.Node{Value: v.Name})
-		}
-	}
-
-	return []tree.Node{
-		loginNode,
-		noteNode,
-		passwordNode,
-		sshkeyNode,
-	}, nil
+		Tags:     cmd.tags,
+		Folder:   cmd.folder,
+	}), nil
 }
 
 func (cmd *ListCmd) vaults(s paw.Storage) (tree.Node, error) {
@@ -153,7 +175,7 @@ func (cmd *ListCmd) vaults(s paw.Storage) (tree.Node, error) {
 		n.Child = append(n.Child, tree.Node{Value: v})
 	}
 	if len(n.Child) == 0 {
-		return n, fmt.Errorf("vault %q does not exists", cmd.vaultName)
+		return n, fmt.Errorf("%w: %q", paw.ErrVaultNotFound, cmd.vaultName)
 	}
 	return n, nil
 }
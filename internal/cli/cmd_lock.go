@@ -34,7 +34,9 @@ func (cmd *LockCmd) Usage() {
 {{ . }}
 
 Options:
-  -h, --help  Displays this help and exit
+      --format=FORMAT         Sets the output format: text, json. Default to text
+  -h, --help                  Displays this help and exit
+      --template=TEMPLATE     Formats the output using the Go template
 `
 	printUsage(template, cmd.Description())
 }
@@ -49,7 +51,7 @@ func (cmd *LockCmd) Parse(args []string) error {
 	flags.Parse(cmd, args)
 	if len(flagSet.Args()) != 1 {
 		cmd.Usage()
-		os.Exit(1)
+		os.Exit(errorClassUsage.ExitCode())
 	}
 
 	cmd.vaultName = flagSet.Arg(0)
@@ -67,13 +69,12 @@ func (cmd *LockCmd) Run(s paw.Storage) error {
 		return err
 	}
 
-	fmt.Println("Removing SSH keys from the agent...")
+	fmt.Fprintln(os.Stderr, "Removing SSH keys from the agent...")
 	err = cmd.removeSSHKeysFromAgent(c, s)
 	if err != nil {
-		fmt.Println("could not remove SSH keys from the agent:", err)
+		fmt.Fprintln(os.Stderr, "could not remove SSH keys from the agent:", err)
 	}
-	fmt.Println("[✓] vault locked")
-	return nil
+	return printResult(resultOutput{Vault: cmd.vaultName, Action: "locked"}, "vault locked")
 }
 
 func (cmd *LockCmd) removeSSHKeysFromAgent(c agent.PawAgent, s paw.Storage) error {
@@ -105,10 +106,10 @@ func (cmd *LockCmd) removeSSHKeysFromAgent(c agent.PawAgent, s paw.Storage) erro
 
 		err = c.RemoveSSHKey(k.PublicKey())
 		if err != nil {
-			fmt.Printf("Could not remove SSH key from the agent. Error: %q - Public key: %s", err, k.MarshalPublicKey())
+			fmt.Fprintf(os.Stderr, "Could not remove SSH key from the agent. Error: %q - Public key: %s", err, k.MarshalPublicKey())
 			return true
 		}
-		fmt.Printf("Removed key: %s", k.MarshalPublicKey())
+		fmt.Fprintf(os.Stderr, "Removed key: %s", k.MarshalPublicKey())
 		return true
 	})
 	return nil
@@ -0,0 +1,128 @@
+// SPDX-FileCopyrightText: 2025 Luca Corbo, Paw contributors
+// SPDX-License-Identifier: AGPL-3.0-or-later
+
+package cli
+
+import (
+	"fmt"
+	"os"
+	"strings"
+	"time"
+
+	"lucor.dev/paw/internal/paw"
+)
+
+// MoveCmd renames an item into the vault
+type MoveCmd struct {
+	itemPath
+	newItemName string
+}
+
+// Name returns the one word command name
+func (cmd *MoveCmd) Name() string {
+	return "mv"
+}
+
+// Description returns the command description
+func (cmd *MoveCmd) Description() string {
+	return "Renames an item into the vault"
+}
+
+// Usage displays the command usage
+func (cmd *MoveCmd) Usage() {
+	template := `Usage: paw cli mv [OPTION] VAULT_NAME/ITEM_TYPE/ITEM_NAME NEW_ITEM_NAME
+
+{{ . }}
+
+Options:
+      --format=FORMAT         Sets the output format: text, json. Default to text
+  -h, --help                  Displays this help and exit
+      --session=SESSION_ID    Sets a session ID to use instead of the env var
+      --template=TEMPLATE     Formats the output using the Go template
+`
+	printUsage(template, cmd.Description())
+}
+
+// Parse parses the arguments and set the usage for the command
+func (cmd *MoveCmd) Parse(args []string) error {
+	flags, err := newCommonFlags(flagOpts{Session: true})
+	if err != nil {
+		return err
+	}
+
+	flags.Parse(cmd, args)
+	if len(flagSet.Args()) != 2 {
+		cmd.Usage()
+		os.Exit(errorClassUsage.ExitCode())
+	}
+	flags.SetEnv()
+
+	itemPath, err := parseItemPath(flagSet.Arg(0), itemPathOptions{fullPath: true})
+	if err != nil {
+		return err
+	}
+	cmd.itemPath = itemPath
+
+	cmd.newItemName = flagSet.Arg(1)
+	if cmd.newItemName == "" {
+		return fmt.Errorf("item name cannot be empty")
+	}
+	if strings.Contains(cmd.newItemName, "/") {
+		return fmt.Errorf("item name cannot contain the path separator. Got %q", cmd.newItemName)
+	}
+	return nil
+}
+
+// Run runs the command
+func (cmd *MoveCmd) Run(s paw.Storage) error {
+	appState, err := s.LoadAppState()
+	if err != nil {
+		return err
+	}
+
+	key, err := loadVaultKey(s, cmd.vaultName)
+	if err != nil {
+		return err
+	}
+
+	vault, err := s.LoadVault(cmd.vaultName, key)
+	if err != nil {
+		return err
+	}
+
+	unlock, err := paw.LockVault(s, vault)
+	if err != nil {
+		return err
+	}
+	defer unlock()
+
+	item, err := loadItem(s, vault, cmd.itemPath)
+	if err != nil {
+		return err
+	}
+
+	err = vault.RenameItem(item, cmd.newItemName)
+	if err != nil {
+		return err
+	}
+
+	err = s.StoreItem(vault, item)
+	if err != nil {
+		return err
+	}
+
+	now := time.Now().UTC()
+	vault.Modified = now
+	err = s.StoreVault(vault)
+	if err != nil {
+		return err
+	}
+
+	appState.Modified = now
+	err = s.StoreAppState(appState)
+	if err != nil {
+		return err
+	}
+
+	return printResult(newItemResultOutput(cmd.vaultName, "renamed", item.GetMetadata()), fmt.Sprintf("item %q renamed to %q", cmd.itemName, cmd.newItemName))
+}
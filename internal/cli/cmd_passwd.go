@@ -0,0 +1,93 @@
+// SPDX-FileCopyrightText: 2025 Luca Corbo, Paw contributors
+// SPDX-License-Identifier: AGPL-3.0-or-later
+
+package cli
+
+import (
+	"fmt"
+	"os"
+
+	"lucor.dev/paw/internal/paw"
+)
+
+// PasswdCmd changes the password protecting the vault key
+type PasswdCmd struct {
+	vaultName string
+}
+
+// Name returns the one word command name
+func (cmd *PasswdCmd) Name() string {
+	return "passwd"
+}
+
+// Description returns the command description
+func (cmd *PasswdCmd) Description() string {
+	return "Changes the vault password"
+}
+
+// Usage displays the command usage
+func (cmd *PasswdCmd) Usage() {
+	template := `Usage: paw cli passwd VAULT
+
+{{ . }}
+
+The vault key does not change. If the vault is a git repository, see: paw cli git,
+the key file protected by the previous password is kept into the git history,
+and into the remote once pushed: rotate the key, see: paw cli rotate-key, or
+rewrite the history to remove it.
+
+Options:
+      --format=FORMAT         Sets the output format: text, json. Default to text
+  -h, --help                  Displays this help and exit
+      --template=TEMPLATE     Formats the output using the Go template
+`
+	printUsage(template, cmd.Description())
+}
+
+// Parse parses the arguments and set the usage for the command
+func (cmd *PasswdCmd) Parse(args []string) error {
+	flags, err := newCommonFlags(flagOpts{})
+	if err != nil {
+		return err
+	}
+
+	flags.Parse(cmd, args)
+	if len(flagSet.Args()) != 1 {
+		cmd.Usage()
+		os.Exit(errorClassUsage.ExitCode())
+	}
+
+	cmd.vaultName = flagSet.Arg(0)
+	return nil
+}
+
+// Run runs the command
+func (cmd *PasswdCmd) Run(s paw.Storage) error {
+	password, err := askPassword("Enter the current vault password")
+	if err != nil {
+		return err
+	}
+
+	// ensure the password is valid before asking for the new one
+	_, err = s.LoadVaultKey(cmd.vaultName, password)
+	if err != nil {
+		return fmt.Errorf("could not unlock the vault: %w", err)
+	}
+
+	if paw.IsVaultGitRepository(s, cmd.vaultName) {
+		fmt.Fprintf(os.Stderr, "[!] the key file protected by the current password is kept into the git history of vault %q\n", cmd.vaultName)
+	}
+
+	fmt.Fprintf(os.Stderr, "Enter the new password for vault %q\n", cmd.vaultName)
+	newPassword, err := askPasswordWithConfirm()
+	if err != nil {
+		return err
+	}
+
+	err = paw.ChangeVaultPassword(s, cmd.vaultName, password, newPassword)
+	if err != nil {
+		return err
+	}
+
+	return printResult(resultOutput{Vault: cmd.vaultName, Action: "password_changed"}, fmt.Sprintf("password for vault %q changed", cmd.vaultName))
+}
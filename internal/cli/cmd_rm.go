@@ -32,9 +32,14 @@ func (cmd *RemoveCmd) Usage() {
 
 {{ . }}
 
+The item is moved to the trash and can be restored until it is purged.
+See "paw cli trash -h".
+
 Options:
+      --format=FORMAT         Sets the output format: text, json. Default to text
   -h, --help                  Displays this help and exit
       --session=SESSION_ID    Sets a session ID to use instead of the env var
+      --template=TEMPLATE     Formats the output using the Go template
 `
 	printUsage(template, cmd.Description())
 }
@@ -49,7 +54,7 @@ func (cmd *RemoveCmd) Parse(args []string) error {
 	flags.Parse(cmd, args)
 	if len(flagSet.Args()) != 1 {
 		cmd.Usage()
-		os.Exit(1)
+		os.Exit(errorClassUsage.ExitCode())
 	}
 	flags.SetEnv()
 
@@ -78,13 +83,9 @@ func (cmd *RemoveCmd) Run(s paw.Storage) error {
 		return err
 	}
 
-	item, err := paw.NewItem(cmd.itemName, cmd.itemType)
-	if err != nil {
-		return err
-	}
-
-	if ok := vault.HasItem(item); !ok {
-		return fmt.Errorf("item does not exists")
+	item, ok := vault.ItemMetadataByName(cmd.itemType, cmd.itemName)
+	if !ok {
+		return fmt.Errorf("%w: %q", paw.ErrItemNotFound, cmd.itemPath)
 	}
 
 	msg := fmt.Sprintf("Are you sure you want to delete %q?", cmd.itemPath)
@@ -96,14 +97,24 @@ func (cmd *RemoveCmd) Run(s paw.Storage) error {
 		os.Exit(0)
 	}
 
-	err = s.DeleteItem(vault, item)
+	unlock, err := paw.LockVault(s, vault)
 	if err != nil {
 		return err
 	}
+	defer unlock()
 
-	vault.DeleteItem(item)
+	err = s.TrashItem(vault, item)
+	if err != nil {
+		return err
+	}
 
 	now := time.Now().UTC()
+	vault.TrashItem(item, now)
+	_, err = paw.PurgeExpiredTrash(s, vault, appState.Preferences.Trash.Retention(), now)
+	if err != nil {
+		return err
+	}
+
 	vault.Modified = now
 	err = s.StoreVault(vault)
 	if err != nil {
@@ -116,6 +127,5 @@ func (cmd *RemoveCmd) Run(s paw.Storage) error {
 		return err
 	}
 
-	fmt.Printf("[✓] item %q removed\n", cmd.itemName)
-	return nil
+	return printResult(newItemResultOutput(cmd.vaultName, "trashed", item), fmt.Sprintf("item %q moved to the trash", cmd.itemName))
 }
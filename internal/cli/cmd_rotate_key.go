@@ -0,0 +1,121 @@
+// SPDX-FileCopyrightText: 2025 Luca Corbo, Paw contributors
+// SPDX-License-Identifier: AGPL-3.0-or-later
+
+package cli
+
+import (
+	"fmt"
+	"os"
+	"time"
+
+	"lucor.dev/paw/internal/agent"
+	"lucor.dev/paw/internal/paw"
+)
+
+// RotateKeyCmd generates a new vault key re-encrypting the vault and its items
+type RotateKeyCmd struct {
+	vaultName string
+}
+
+// Name returns the one word command name
+func (cmd *RotateKeyCmd) Name() string {
+	return "rotate-key"
+}
+
+// Description returns the command description
+func (cmd *RotateKeyCmd) Description() string {
+	return "Rotates the vault key re-encrypting all the items"
+}
+
+// Usage displays the command usage
+func (cmd *RotateKeyCmd) Usage() {
+	template := `Usage: paw cli rotate-key VAULT
+
+{{ . }}
+
+The vault password does not change. Active sessions for the vault are locked.
+If the vault is a git repository, see: paw cli git, the previous key file and
+the items encrypted with it are kept into the git history, and into the remote
+once pushed: rewrite the history to remove them.
+
+Options:
+      --format=FORMAT         Sets the output format: text, json. Default to text
+  -h, --help                  Displays this help and exit
+      --template=TEMPLATE     Formats the output using the Go template
+`
+	printUsage(template, cmd.Description())
+}
+
+// Parse parses the arguments and set the usage for the command
+func (cmd *RotateKeyCmd) Parse(args []string) error {
+	flags, err := newCommonFlags(flagOpts{})
+	if err != nil {
+		return err
+	}
+
+	flags.Parse(cmd, args)
+	if len(flagSet.Args()) != 1 {
+		cmd.Usage()
+		os.Exit(errorClassUsage.ExitCode())
+	}
+
+	cmd.vaultName = flagSet.Arg(0)
+	return nil
+}
+
+// Run runs the command
+func (cmd *RotateKeyCmd) Run(s paw.Storage) error {
+	appState, err := s.LoadAppState()
+	if err != nil {
+		return err
+	}
+
+	password, err := askPassword("Enter the vault password")
+	if err != nil {
+		return err
+	}
+
+	key, err := s.LoadVaultKey(cmd.vaultName, password)
+	if err != nil {
+		return err
+	}
+
+	vault, err := s.LoadVault(cmd.vaultName, key)
+	if err != nil {
+		return err
+	}
+
+	if paw.IsVaultGitRepository(s, cmd.vaultName) {
+		fmt.Fprintf(os.Stderr, "[!] the previous key file is kept into the git history of vault %q\n", cmd.vaultName)
+	}
+
+	msg := fmt.Sprintf("Are you sure you want to rotate the key for vault %q?", cmd.vaultName)
+	confirm, err := askYesNo(msg, false)
+	if err != nil {
+		return err
+	}
+	if !confirm {
+		os.Exit(0)
+	}
+
+	_, err = paw.RotateVaultKey(s, vault, password)
+	if err != nil {
+		return err
+	}
+
+	// sessions hold the previous key, lock them
+	if c, err := agent.NewClient(s.SocketAgentPath()); err == nil {
+		err = c.Lock(cmd.vaultName)
+		if err != nil {
+			fmt.Fprintln(os.Stderr, "[✗] could not lock the vault sessions:", err)
+		}
+	}
+
+	appState.Modified = time.Now().UTC()
+	err = s.StoreAppState(appState)
+	if err != nil {
+		return err
+	}
+
+	return printResult(resultOutput{Vault: cmd.vaultName, Action: "key_rotated"}, fmt.Sprintf("key for vault %q rotated", cmd.vaultName))
+}
@@ -0,0 +1,343 @@
+// SPDX-FileCopyrightText: 2025 Luca Corbo, Paw contributors
+// SPDX-License-Identifier: AGPL-3.0-or-later
+
+package cli
+
+import (
+	"bufio"
+	"bytes"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"os/exec"
+	"os/signal"
+	"sort"
+	"strings"
+	"sync"
+	"syscall"
+
+	"lucor.dev/paw/internal/paw"
+)
+
+// secretMask is the text used to mask the secrets into the command output
+const secretMask = "<concealed by paw>"
+
+// RunCmd runs a command with secrets injected as environment variables
+type RunCmd struct {
+	env       stringsFlag
+	envFiles  stringsFlag
+	noMasking bool
+	command   []string
+}
+
+// Name returns the one word command name
+func (cmd *RunCmd) Name() string {
+	return "run"
+}
+
+// Description returns the command description
+func (cmd *RunCmd) Description() string {
+	return "Runs a command with secrets as environment variables"
+}
+
+// Usage displays the command usage
+func (cmd *RunCmd) Usage() {
+	template := `Usage: paw cli run [OPTION] -- COMMAND [ARG...]
+
+{{ . }}
+
+The secrets are resolved using the session, if any, otherwise the vault
+password is asked. They are set only into the environment of the command and
+are masked if printed on its standard output or standard error.
+
+A secret reference can be specified as:
+  VAULT_NAME/ITEM_TYPE/ITEM_NAME:FIELD
+  paw://VAULT_NAME/ITEM_TYPE/ITEM_NAME/FIELD
+
+See "paw cli get -h" for the available fields.
+
+The env file contains NAME=VALUE lines. Values starting with paw:// are
+resolved as secret references, other values are passed as they are. Empty
+lines and lines starting with # are ignored.
+
+Options:
+      --env=NAME=REF          Sets the env var NAME to the referenced secret. Can be repeated
+      --env-file=FILE         Reads the env vars from the file. Can be repeated
+  -h, --help                  Displays this help and exit
+      --no-masking            Do not mask the secrets into the command output.
+                              The command will use directly the terminal
+      --session=SESSION_ID    Sets a session ID to use instead of the env var
+
+Example:
+  paw cli run --env DB_PASS=myvault/login/db:password -- ./app
+`
+	printUsage(template, cmd.Description())
+}
+
+// Parse parses the arguments and set the usage for the command
+func (cmd *RunCmd) Parse(args []string) error {
+	flags, err := newCommonFlags(flagOpts{Session: true})
+	if err != nil {
+		return err
+	}
+
+	flagSet.Var(&cmd.env, "env", "")
+	flagSet.Var(&cmd.envFiles, "env-file", "")
+	flagSet.BoolVar(&cmd.noMasking, "no-masking", false, "")
+
+	flags.Parse(cmd, args)
+	if len(flagSet.Args()) == 0 {
+		cmd.Usage()
+		os.Exit(errorClassUsage.ExitCode())
+	}
+	flags.SetEnv()
+
+	cmd.command = flagSet.Args()
+	return nil
+}
+
+// Run runs the command
+func (cmd *RunCmd) Run(s paw.Storage) error {
+	refs := map[string]string{}
+	values := map[string]string{}
+	var names []string
+	addVar := func(name string) {
+		if _, ok := refs[name]; ok {
+			return
+		}
+		if _, ok := values[name]; ok {
+			return
+		}
+		names = append(names, name)
+	}
+
+	for _, file := range cmd.envFiles {
+		vars, err := parseEnvFile(file)
+		if err != nil {
+			return err
+		}
+		for _, v := range vars {
+			addVar(v.name)
+			if strings.HasPrefix(v.value, secretRefScheme) {
+				refs[v.name] = v.value
+				delete(values, v.name)
+				continue
+			}
+			values[v.name] = v.value
+			delete(refs, v.name)
+		}
+	}
+
+	// vars specified by flag override the ones from the env files
+	for _, v := range cmd.env {
+		name, ref, ok := strings.Cut(v, "=")
+		if !ok || name == "" {
+			return fmt.Errorf("invalid env %q, expected NAME=REF", v)
+		}
+		addVar(name)
+		refs[name] = ref
+		delete(values, name)
+	}
+
+	resolver := newSecretResolver(s)
+	env := os.Environ()
+	var secrets []string
+	for _, name := range names {
+		if value, ok := values[name]; ok {
+			env = append(env, name+"="+value)
+			continue
+		}
+		ref, err := parseSecretRef(refs[name])
+		if err != nil {
+			return fmt.Errorf("env %s: %w", name, err)
+		}
+		value, err := resolver.Resolve(ref)
+		if err != nil {
+			return fmt.Errorf("env %s: %w", name, err)
+		}
+		env = append(env, name+"="+value)
+		secrets = append(secrets, value)
+	}
+
+	c := exec.Command(cmd.command[0], cmd.command[1:]...)
+	c.Env = env
+	c.Stdin = os.Stdin
+	c.Stdout = os.Stdout
+	c.Stderr = os.Stderr
+
+	var stdout, stderr *maskWriter
+	if !cmd.noMasking {
+		stdout = newMaskWriter(os.Stdout, secrets)
+		stderr = newMaskWriter(os.Stderr, secrets)
+		c.Stdout = stdout
+		c.Stderr = stderr
+	}
+
+	// the signals are forwarded to the command, paw exits when the command exits
+	sigs := make(chan os.Signal, 1)
+	signal.Notify(sigs, os.Interrupt, syscall.SIGTERM)
+	defer signal.Stop(sigs)
+
+	err := c.Start()
+	if err != nil {
+		return err
+	}
+	go func() {
+		for sig := range sigs {
+			_ = c.Process.Signal(sig)
+		}
+	}()
+
+	err = c.Wait()
+	if !cmd.noMasking {
+		stdout.Flush()
+		stderr.Flush()
+	}
+
+	var exitErr *exec.ExitError
+	if errors.As(err, &exitErr) {
+		os.Exit(exitCode(exitErr))
+	}
+	return err
+}
+
+// exitCode returns the exit code of the exited command. A command killed by a
+// signal exits with 128 plus the signal number, as the shells do.
+func exitCode(exitErr *exec.ExitError) int {
+	if ws, ok := exitErr.Sys().(syscall.WaitStatus); ok && ws.Signaled() {
+		return 128 + int(ws.Signal())
+	}
+	return exitErr.ExitCode()
+}
+
+// envVar is an env variable defined into an env file
+type envVar struct {
+	name  string
+	value string
+}
+
+// parseEnvFile parses the NAME=VALUE lines of the env file.
+// The "export" prefix and the quotes around the value are removed.
+func parseEnvFile(name string) ([]envVar, error) {
+	f, err := os.Open(name)
+	if err != nil {
+		return nil, fmt.Errorf("could not open the env file: %w", err)
+	}
+	defer f.Close()
+
+	var vars []envVar
+	scanner := bufio.NewScanner(f)
+	for n := 1; scanner.Scan(); n++ {
+		line := strings.TrimSpace(scanner.Text())
+		if line == "" || strings.HasPrefix(line, "#") {
+			continue
+		}
+		line = strings.TrimPrefix(line, "export ")
+		k, v, ok := strings.Cut(line, "=")
+		k = strings.TrimSpace(k)
+		if !ok || k == "" {
+			return nil, fmt.Errorf("%s:%d: invalid line, expected NAME=VALUE", name, n)
+		}
+		v = strings.TrimSpace(v)
+		if len(v) > 1 && (v[0] == '"' || v[0] == '\'') && v[len(v)-1] == v[0] {
+			v = v[1 : len(v)-1]
+		}
+		vars = append(vars, envVar{name: k, value: v})
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, fmt.Errorf("could not read the env file: %w", err)
+	}
+	return vars, nil
+}
+
+// maskWriter replaces the secrets with a mask before writing to w.
+// The bytes that could be the start of a secret are held until the next write
+// or the flush, so that the secrets written in chunks are masked too.
+type maskWriter struct {
+	mu      sync.Mutex
+	w       io.Writer
+	secrets [][]byte
+	buf     []byte
+}
+
+func newMaskWriter(w io.Writer, secrets []string) *maskWriter {
+	mw := &maskWriter{w: w}
+	for _, v := range secrets {
+		if v == "" {
+			continue
+		}
+		mw.secrets = append(mw.secrets, []byte(v))
+	}
+	// match the longest secrets first, they could contain the shorter ones
+	sort.Slice(mw.secrets, func(i, j int) bool {
+		return len(mw.secrets[i]) > len(mw.secrets[j])
+	})
+	return mw
+}
+
+// Write implements io.Writer
+func (mw *maskWriter) Write(p []byte) (int, error) {
+	mw.mu.Lock()
+	defer mw.mu.Unlock()
+
+	mw.buf = append(mw.buf, p...)
+	err := mw.write(false)
+	if err != nil {
+		return 0, err
+	}
+	return len(p), nil
+}
+
+// Flush writes the held bytes
+func (mw *maskWriter) Flush() error {
+	mw.mu.Lock()
+	defer mw.mu.Unlock()
+	return mw.write(true)
+}
+
+// write writes the buffer replacing the secrets with the mask. Unless flushing,
+// the bytes starting from the first position that could be the start of a
+// secret not fully written yet are held into the buffer.
+func (mw *maskWriter) write(flush bool) error {
+	out := make([]byte, 0, len(mw.buf))
+	i := 0
+	for i < len(mw.buf) {
+		if !flush && mw.isPartialSecret(mw.buf[i:]) {
+			break
+		}
+		if n := mw.secretLen(mw.buf[i:]); n > 0 {
+			out = append(out, secretMask...)
+			i += n
+			continue
+		}
+		out = append(out, mw.buf[i])
+		i++
+	}
+	mw.buf = append(mw.buf[:0], mw.buf[i:]...)
+	if len(out) == 0 {
+		return nil
+	}
+	_, err := mw.w.Write(out)
+	return err
+}
+
+// secretLen returns the length of the longest secret b starts with, zero if none
+func (mw *maskWriter) secretLen(b []byte) int {
+	for _, secret := range mw.secrets {
+		if bytes.HasPrefix(b, secret) {
+			return len(secret)
+		}
+	}
+	return 0
+}
+
+// isPartialSecret reports whether b is the prefix of a longer secret
+func (mw *maskWriter) isPartialSecret(b []byte) bool {
+	for _, secret := range mw.secrets {
+		if len(b) < len(secret) && bytes.HasPrefix(secret, b) {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,252 @@
+// SPDX-FileCopyrightText: 2025 Luca Corbo, Paw contributors
+// SPDX-License-Identifier: AGPL-3.0-or-later
+
+package cli
+
+import (
+	"bytes"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"runtime"
+	"syscall"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestMaskWriter(t *testing.T) {
+	m := secretMask
+	tests := map[string]struct {
+		secrets []string
+		writes  []string
+		want    string
+	}{
+		"no secrets": {
+			writes: []string{"hello", " world\n"},
+			want:   "hello world\n",
+		},
+		"empty secret is ignored": {
+			secrets: []string{""},
+			writes:  []string{"hello\n"},
+			want:    "hello\n",
+		},
+		"single write": {
+			secrets: []string{"s3cret"},
+			writes:  []string{"user=alice password=s3cret\n"},
+			want:    "user=alice password=" + m + "\n",
+		},
+		"repeated secret": {
+			secrets: []string{"s3cret"},
+			writes:  []string{"s3cret s3cret\n"},
+			want:    m + " " + m + "\n",
+		},
+		"secret split across writes": {
+			secrets: []string{"s3cret"},
+			writes:  []string{"password=s3", "cr", "et\n"},
+			want:    "password=" + m + "\n",
+		},
+		"secret split byte by byte": {
+			secrets: []string{"s3cret"},
+			writes:  []string{"s", "3", "c", "r", "e", "t"},
+			want:    m,
+		},
+		"secret at the end of the last write": {
+			secrets: []string{"s3cret"},
+			writes:  []string{"password=", "s3cret"},
+			want:    "password=" + m,
+		},
+		"overlapping secrets": {
+			secrets: []string{"pass", "password"},
+			writes:  []string{"password pass\n"},
+			want:    m + " " + m + "\n",
+		},
+		"overlapping secrets split across writes": {
+			secrets: []string{"pass", "password"},
+			writes:  []string{"pass", "wor", "d pa", "ss\n"},
+			want:    m + " " + m + "\n",
+		},
+		"overlapping secrets sharing bytes": {
+			secrets: []string{"abc", "cde"},
+			writes:  []string{"xab", "c", "de\n"},
+			want:    "x" + m + "de\n",
+		},
+		"pending partial match is flushed unmasked": {
+			secrets: []string{"s3cret"},
+			writes:  []string{"value s3cr"},
+			want:    "value s3cr",
+		},
+		"partial match not completed": {
+			secrets: []string{"s3cret"},
+			writes:  []string{"s3cr", "ew\n"},
+			want:    "s3crew\n",
+		},
+	}
+	for name, tt := range tests {
+		t.Run(name, func(t *testing.T) {
+			buf := &bytes.Buffer{}
+			mw := newMaskWriter(buf, tt.secrets)
+			for _, w := range tt.writes {
+				n, err := mw.Write([]byte(w))
+				require.NoError(t, err)
+				assert.Equal(t, len(w), n)
+			}
+			require.NoError(t, mw.Flush())
+			assert.Equal(t, tt.want, buf.String())
+		})
+	}
+}
+
+func TestMaskWriter_HoldsPartialMatch(t *testing.T) {
+	buf := &bytes.Buffer{}
+	mw := newMaskWriter(buf, []string{"s3cret"})
+
+	_, err := mw.Write([]byte("password=s3c"))
+	require.NoError(t, err)
+	assert.Equal(t, "password=", buf.String())
+
+	_, err = mw.Write([]byte("ret and s"))
+	require.NoError(t, err)
+	assert.Equal(t, "password="+secretMask+" and ", buf.String())
+
+	require.NoError(t, mw.Flush())
+	assert.Equal(t, "password="+secretMask+" and s", buf.String())
+
+	// flush empties the held bytes
+	require.NoError(t, mw.Flush())
+	assert.Equal(t, "password="+secretMask+" and s", buf.String())
+}
+
+func TestMaskWriter_IsPartialSecret(t *testing.T) {
+	tests := map[string]struct {
+		secrets []string
+		b       string
+		want    bool
+	}{
+		"no match":            {secrets: []string{"s3cret"}, b: "hello", want: false},
+		"single byte":         {secrets: []string{"s3cret"}, b: "s", want: true},
+		"longest prefix":      {secrets: []string{"s3cret"}, b: "s3cre", want: true},
+		"complete secret":     {secrets: []string{"s3cret"}, b: "s3cret", want: false},
+		"longer than secret":  {secrets: []string{"s3cret"}, b: "s3cret!", want: false},
+		"prefix not at start": {secrets: []string{"s3cret"}, b: "xs3c", want: false},
+		"complete shorter secret prefix of a longer one": {
+			secrets: []string{"pass", "password"},
+			b:       "pass",
+			want:    true,
+		},
+	}
+	for name, tt := range tests {
+		t.Run(name, func(t *testing.T) {
+			mw := newMaskWriter(&bytes.Buffer{}, tt.secrets)
+			assert.Equal(t, tt.want, mw.isPartialSecret([]byte(tt.b)))
+		})
+	}
+}
+
+func TestMaskWriter_SecretLen(t *testing.T) {
+	mw := newMaskWriter(&bytes.Buffer{}, []string{"pass", "password", "other"})
+	assert.Equal(t, 0, mw.secretLen([]byte("hello")))
+	assert.Equal(t, 0, mw.secretLen([]byte("pas")))
+	assert.Equal(t, 4, mw.secretLen([]byte("passw")))
+	assert.Equal(t, 8, mw.secretLen([]byte("password1")))
+	assert.Equal(t, 5, mw.secretLen([]byte("other")))
+}
+
+func TestParseEnvFile(t *testing.T) {
+	tests := map[string]struct {
+		content string
+		want    []envVar
+		wantErr string
+	}{
+		"empty": {
+			content: "",
+		},
+		"comments and blank lines": {
+			content: "# comment\n\n  \nA=1\n",
+			want:    []envVar{{name: "A", value: "1"}},
+		},
+		"export prefix and spaces": {
+			content: "export A=1\n  B = 2  \n",
+			want:    []envVar{{name: "A", value: "1"}, {name: "B", value: "2"}},
+		},
+		"quoted values": {
+			content: "A=\"w/login/a:password\"\nB='x y'\nC=\"\"\n",
+			want: []envVar{
+				{name: "A", value: "w/login/a:password"},
+				{name: "B", value: "x y"},
+				{name: "C", value: ""},
+			},
+		},
+		"unbalanced quotes are kept": {
+			content: "A=\"abc\nB='abc\"\nC=\"\n",
+			want: []envVar{
+				{name: "A", value: "\"abc"},
+				{name: "B", value: "'abc\""},
+				{name: "C", value: "\""},
+			},
+		},
+		"value containing the separator": {
+			content: "A=b=c\n",
+			want:    []envVar{{name: "A", value: "b=c"}},
+		},
+		"empty value": {
+			content: "A=\n",
+			want:    []envVar{{name: "A", value: ""}},
+		},
+		"missing separator": {
+			content: "A=1\nB\n",
+			wantErr: ":2: invalid line",
+		},
+		"missing name": {
+			content: "=1\n",
+			wantErr: ":1: invalid line",
+		},
+		"export only": {
+			content: "export =1\n",
+			wantErr: ":1: invalid line",
+		},
+	}
+	for name, tt := range tests {
+		t.Run(name, func(t *testing.T) {
+			file := filepath.Join(t.TempDir(), ".env")
+			require.NoError(t, os.WriteFile(file, []byte(tt.content), 0600))
+
+			got, err := parseEnvFile(file)
+			if tt.wantErr != "" {
+				require.Error(t, err)
+				assert.Contains(t, err.Error(), tt.wantErr)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, tt.want, got)
+		})
+	}
+}
+
+func TestParseEnvFile_NotFound(t *testing.T) {
+	_, err := parseEnvFile(filepath.Join(t.TempDir(), "missing"))
+	require.Error(t, err)
+}
+
+func TestExitCode(t *testing.T) {
+	if runtime.GOOS == "windows" {
+		t.Skip("signals are not supported on windows")
+	}
+	tests := map[string]struct {
+		script string
+		want   int
+	}{
+		"exited":         {script: "exit 3", want: 3},
+		"killed by TERM": {script: "kill -TERM $$", want: 128 + int(syscall.SIGTERM)},
+		"killed by KILL": {script: "kill -KILL $$", want: 128 + int(syscall.SIGKILL)},
+	}
+	for name, tt := range tests {
+		t.Run(name, func(t *testing.T) {
+			err := exec.Command("sh", "-c", tt.script).Run()
+			var exitErr *exec.ExitError
+			require.ErrorAs(t, err, &exitErr)
+			assert.Equal(t, tt.want, exitCode(exitErr))
+		})
+	}
+}
@@ -6,7 +6,9 @@ package cli
 import (
 	"context"
 	"fmt"
+	"io"
 	"os"
+	"strings"
 	"time"
 
 	"lucor.dev/paw/internal/paw"
@@ -39,9 +41,12 @@ func (cmd *ShowCmd) Usage() {
 {{ . }}
 
 Options:
-  -c, --clip                  Do not print the password but instead copy to the clipboard
+  -c, --clip                  Do not print the password but instead copy to the clipboard.
+                              For the other item types the first secret field is copied
+      --format=FORMAT         Sets the output format: text, json. Default to text
   -h, --help                  Displays this help and exit
       --session=SESSION_ID    Sets a session ID to use instead of the env var
+      --template=TEMPLATE     Formats the output using the Go template
 `
 	printUsage(template, cmd.Description())
 }
@@ -90,80 +95,114 @@ func (cmd *ShowCmd) Run(s paw.Storage) error {
 		return err
 	}
 
-	item, err := paw.NewItem(cmd.itemName, cmd.itemType)
+	item, err := loadItem(s, vault, cmd.itemPath)
 	if err != nil {
 		return err
 	}
 
-	item, err = s.LoadItem(vault, item.GetMetadata())
+	var pclip []byte
+	var pclipMsg string
+	out := newItemOutput(cmd.vaultName, item)
+	if cmd.clipboard {
+		// do not print the secret but instead copy to the clipboard
+		switch cmd.itemType {
+		case paw.LoginItemType, paw.PasswordItemType:
+			pclip = []byte(out.Password)
+			pclipMsg = "[✓] password copied to clipboard"
+			out.Password = ""
+		case paw.SSHKeyItemType:
+			pclip = []byte(out.PrivateKey)
+			pclipMsg = "[✓] private key copied to clipboard"
+			out.PrivateKey = ""
+		default:
+			if v, ok := item.(paw.FieldsItem); ok {
+				if f, ok := clipboardItemField(v); ok {
+					pclip = []byte(*f.Value)
+					pclipMsg = fmt.Sprintf("[✓] %s copied to clipboard", strings.ToLower(f.Label))
+					delete(out.Details, f.Name)
+				}
+			}
+		}
+	}
+
+	err = printOutput(out, func(w io.Writer) {
+		cmd.printText(w, item)
+	})
 	if err != nil {
 		return err
 	}
 
-	var pclip []byte
-	var pclipMsg string
+	if pclip != nil {
+		ctx, cancel := context.WithTimeout(context.Background(), clipboardWriteTimeout)
+		defer cancel()
+		err := writeToClipboard(ctx, pclip)
+		if err != nil {
+			return nil
+		}
+		fmt.Fprintln(os.Stderr, pclipMsg)
+	}
+	return nil
+}
+
+// printText prints the item details in text format
+func (cmd *ShowCmd) printText(w io.Writer, item paw.Item) {
 	switch cmd.itemType {
 	case paw.LoginItemType:
 		v := item.(*paw.Login)
-		fmt.Printf("URL: %s\n", v.URL)
-		fmt.Printf("Username: %s\n", v.Username)
+		fmt.Fprintf(w, "URL: %s\n", v.URL)
+		fmt.Fprintf(w, "Username: %s\n", v.Username)
 		if !cmd.clipboard {
-			fmt.Printf("Password: %s\n", v.Password.Value)
-		} else {
-			pclip = []byte(v.Password.Value)
-			pclipMsg = "[✓] password copied to clipboard"
+			fmt.Fprintf(w, "Password: %s\n", v.Password.Value)
 		}
 		if v.Note != nil {
-			fmt.Printf("Note: %s\n", v.Note.Value)
+			fmt.Fprintf(w, "Note: %s\n", v.Note.Value)
 		}
 	case paw.PasswordItemType:
 		v := item.(*paw.Password)
 		if !cmd.clipboard {
-			fmt.Printf("Password: %s\n", v.Value)
-		} else {
-			pclip = []byte(v.Value)
-			pclipMsg = "[✓] password copied to clipboard"
+			fmt.Fprintf(w, "Password: %s\n", v.Value)
 		}
 		if v.Note != nil {
-			fmt.Printf("Note: %s\n", v.Note.Value)
+			fmt.Fprintf(w, "Note: %s\n", v.Note.Value)
 		}
 	case paw.SSHKeyItemType:
 		v := item.(*paw.SSHKey)
 		if !cmd.clipboard {
-			fmt.Printf("Private key: %s\n", v.PrivateKey)
-		} else {
-			pclip = []byte(v.PrivateKey)
-			pclipMsg = "[✓] private key copied to clipboard"
+			fmt.Fprintf(w, "Private key: %s\n", v.PrivateKey)
 		}
 		if v.Passphrase != nil {
-			fmt.Printf("Passphrase: %s\n", v.Passphrase.Value)
+			fmt.Fprintf(w, "Passphrase: %s\n", v.Passphrase.Value)
 		}
-		fmt.Printf("Public key: %s\n", v.PublicKey)
-		fmt.Printf("Fingerprint: %s\n", v.Fingerprint)
+		fmt.Fprintf(w, "Public key: %s\n", v.PublicKey)
+		fmt.Fprintf(w, "Fingerprint: %s\n", v.Fingerprint)
 		addToAgent := "No"
 		if v.AddToAgent {
 			addToAgent = "Yes"
 		}
-		fmt.Printf("Add to agent: %s\n", addToAgent)
+		fmt.Fprintf(w, "Add to agent: %s\n", addToAgent)
 		if v.Note != nil {
-			fmt.Printf("Note: %s\n", v.Note.Value)
+			fmt.Fprintf(w, "Note: %s\n", v.Note.Value)
 		}
 	case paw.NoteItemType:
 		v := item.(*paw.Note)
-		fmt.Printf("Note: %s\n", v.Value)
+		fmt.Fprintf(w, "Note: %s\n", v.Value)
+	default:
+		if v, ok := item.(paw.FieldsItem); ok {
+			var skip string
+			if f, ok := clipboardItemField(v); ok && cmd.clipboard {
+				skip = f.Name
+			}
+			printItemFields(w, v, skip)
+		}
 	}
 
-	fmt.Printf("Modified: %s\n", item.GetMetadata().Modified.Local().Format(time.RFC1123))
-	fmt.Printf("Created: %s\n", item.GetMetadata().Created.Local().Format(time.RFC1123))
-
-	if pclip != nil {
-		ctx, cancel := context.WithTimeout(context.Background(), clipboardWriteTimeout)
-		defer cancel()
-		err := writeToClipboard(ctx, pclip)
-		if err != nil {
-			return nil
-		}
-		fmt.Println(pclipMsg)
+	printCustomFields(w, item)
+	if tags := item.GetMetadata().Tags; len(tags) > 0 {
+		fmt.Fprintf(w, "Tags: %s\n", strings.Join(tags, ", "))
 	}
-	return nil
+	if folder := item.GetMetadata().Folder; folder != "" {
+		fmt.Fprintf(w, "Folder: %s\n", folder)
+	}
+	fmt.Fprintf(w, "Modified: %s\n", item.GetMetadata().Modified.Local().Format(time.RFC1123))
+	fmt.Fprintf(w, "Created: %s\n", item.GetMetadata().Created.Local().Format(time.RFC1123))
 }
@@ -0,0 +1,206 @@
+// SPDX-FileCopyrightText: 2025 Luca Corbo, Paw contributors
+// SPDX-License-Identifier: AGPL-3.0-or-later
+
+package cli
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"os"
+	"time"
+
+	"lucor.dev/paw/internal/paw"
+)
+
+// SyncCmd synchronises a vault with a remote
+type SyncCmd struct {
+	vaultName string
+	remote    string
+}
+
+// Name returns the one word command name
+func (cmd *SyncCmd) Name() string {
+	return "sync"
+}
+
+// Description returns the command description
+func (cmd *SyncCmd) Description() string {
+	return "Synchronises a vault with a remote"
+}
+
+// Usage displays the command usage
+func (cmd *SyncCmd) Usage() {
+	template := `Usage: paw cli sync [OPTION] VAULT
+
+{{ . }}
+
+The remote can be a directory, e.g. shared using a file synchronisation tool,
+or a WebDAV-style HTTP server. The remote is saved on the first run and can be
+omitted afterwards.
+
+The changes made on a side only since the last synchronisation are applied to
+the other one. The items changed on both sides are merged comparing their
+modification time: the most recent change wins and the discarded one is listed
+as a conflict, see: paw cli conflict. The files are transferred encrypted with
+the vault key.
+
+If the vault does not exist, its key is copied from the remote and the vault
+password is requested.
+
+Options:
+      --format=FORMAT         Sets the output format: text, json. Default to text
+  -h, --help                  Displays this help and exit
+      --remote=REMOTE         Sets the remote: a directory path, a file:// or an
+                              http(s):// URL. The password for the HTTP remote can
+                              be specified using the PAW_SYNC_PASSWORD env var
+      --session=SESSION_ID    Sets a session ID to use instead of the env var
+      --template=TEMPLATE     Formats the output using the Go template
+`
+	printUsage(template, cmd.Description())
+}
+
+// Parse parses the arguments and set the usage for the command
+func (cmd *SyncCmd) Parse(args []string) error {
+	flags, err := newCommonFlags(flagOpts{Session: true})
+	if err != nil {
+		return err
+	}
+
+	flagSet.StringVar(&cmd.remote, "remote", "", "")
+
+	flags.Parse(cmd, args)
+	if len(flagSet.Args()) != 1 {
+		cmd.Usage()
+		os.Exit(errorClassUsage.ExitCode())
+	}
+	flags.SetEnv()
+
+	cmd.vaultName = flagSet.Arg(0)
+	return nil
+}
+
+// Run runs the command
+func (cmd *SyncCmd) Run(s paw.Storage) error {
+	appState, err := s.LoadAppState()
+	if err != nil {
+		return err
+	}
+
+	state := appState.Sync[cmd.vaultName]
+	if state == nil {
+		state = &paw.SyncState{}
+	}
+	location := cmd.remote
+	if location == "" {
+		location = state.Remote
+	}
+	if location == "" {
+		return fmt.Errorf("no remote configured for the vault %q, use the --remote option", cmd.vaultName)
+	}
+	remote, err := paw.NewRemote(location)
+	if err != nil {
+		return err
+	}
+
+	ctx := context.Background()
+	vault, err := cmd.loadVault(ctx, s, remote)
+	if err != nil {
+		return err
+	}
+
+	res, syncErr := paw.SyncVault(ctx, s, vault, remote)
+	state.Remote = remote.String()
+	state.Error = ""
+	if syncErr != nil {
+		state.Error = syncErr.Error()
+	} else {
+		state.Synced = res.Synced
+	}
+	if appState.Sync == nil {
+		appState.Sync = make(map[string]*paw.SyncState)
+	}
+	appState.Sync[cmd.vaultName] = state
+	appState.Modified = time.Now().UTC()
+	err = s.StoreAppState(appState)
+	if syncErr != nil {
+		return syncErr
+	}
+	if err != nil {
+		return err
+	}
+
+	out := syncOutput{
+		Vault:     cmd.vaultName,
+		Remote:    state.Remote,
+		Synced:    res.Synced,
+		Pulled:    []string{},
+		Pushed:    []string{},
+		Conflicts: []conflictOutput{},
+	}
+	for _, meta := range res.Pulled {
+		out.Pulled = append(out.Pulled, fmt.Sprintf("%s/%s", meta.Type, meta.Name))
+	}
+	for _, meta := range res.Pushed {
+		out.Pushed = append(out.Pushed, fmt.Sprintf("%s/%s", meta.Type, meta.Name))
+	}
+	for _, c := range res.Conflicts {
+		out.Conflicts = append(out.Conflicts, newConflictOutput(cmd.vaultName, c))
+	}
+	err = printOutput(out, func(w io.Writer) {
+		for _, v := range out.Pulled {
+			fmt.Fprintf(w, "pulled %s\n", v)
+		}
+		for _, v := range out.Pushed {
+			fmt.Fprintf(w, "pushed %s\n", v)
+		}
+		printMergeConflicts(w, out.Conflicts)
+		fmt.Fprintf(w, "[✓] vault %q synchronised with %s: %d pulled, %d pushed\n", out.Vault, out.Remote, len(out.Pulled), len(out.Pushed))
+	})
+	if err != nil {
+		return err
+	}
+	warnConflicts(vault)
+	return nil
+}
+
+// loadVault loads the vault, if the vault does not exist the key is copied
+// from the remote and an empty vault is created to be synchronised
+func (cmd *SyncCmd) loadVault(ctx context.Context, s paw.Storage, remote paw.Remote) (*paw.Vault, error) {
+	vaults, err := s.Vaults()
+	if err != nil {
+		return nil, err
+	}
+	for _, name := range vaults {
+		if name != cmd.vaultName {
+			continue
+		}
+		key, err := loadVaultKey(s, cmd.vaultName)
+		if err != nil {
+			return nil, err
+		}
+		return s.LoadVault(cmd.vaultName, key)
+	}
+
+	fmt.Fprintf(os.Stderr, "Vault %q not found, copying it from %s\n", cmd.vaultName, remote)
+	password, err := askPassword("Enter the vault password")
+	if err != nil {
+		return nil, &authError{err: err}
+	}
+	key, err := paw.PullVaultKey(ctx, s, remote, cmd.vaultName, password)
+	if err != nil {
+		return nil, err
+	}
+	return s.CreateVault(cmd.vaultName, key)
+}
+
+// syncOutput is the structured representation of a synchronisation
+type syncOutput struct {
+	Vault  string    `json:"vault"`
+	Remote string    `json:"remote"`
+	Synced time.Time `json:"synced"`
+	Pulled []string  `json:"pulled"`
+	Pushed []string  `json:"pushed"`
+	// Conflicts lists the changes discarded merging the items changed on both sides
+	Conflicts []conflictOutput `json:"conflicts"`
+}
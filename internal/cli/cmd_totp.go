@@ -0,0 +1,336 @@
+// SPDX-FileCopyrightText: 2025 Luca Corbo, Paw contributors
+// SPDX-License-Identifier: AGPL-3.0-or-later
+
+package cli
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"io"
+	"os"
+	"os/signal"
+	"strings"
+	"time"
+
+	"lucor.dev/paw/internal/paw"
+	"lucor.dev/paw/internal/qrcode"
+)
+
+// TOTPCmd prints and sets the TOTP of a login item
+type TOTPCmd struct {
+	itemPath
+	clipboard bool
+	watch     bool
+	uri       bool
+	qr        bool
+	qrFile    string
+	set       string
+	setQR     string
+}
+
+// Name returns the one word command name
+func (cmd *TOTPCmd) Name() string {
+	return "totp"
+}
+
+// Description returns the command description
+func (cmd *TOTPCmd) Description() string {
+	return "Prints and sets the TOTP of a login item"
+}
+
+// Usage displays the command usage
+func (cmd *TOTPCmd) Usage() {
+	template := `Usage: paw cli totp [OPTION] VAULT_NAME/login/ITEM_NAME
+
+{{ . }}
+
+By default the current code and the remaining seconds are printed.
+For HOTP the code for the current counter is printed and the counter is
+incremented.
+
+Options:
+  -c, --clip                  Copies the code to the clipboard
+      --format=FORMAT         Sets the output format: text, json. Default to text
+  -h, --help                  Displays this help and exit
+      --qr                    Prints the otpauth URI as QR code
+      --qr-file=FILE          Writes the otpauth URI as QR code PNG image into the file
+      --session=SESSION_ID    Sets a session ID to use instead of the env var
+      --set=VALUE             Sets the TOTP from an otpauth URI or a base32 encoded secret
+      --set-qr=FILE           Sets the TOTP decoding the QR code from a PNG or JPEG image.
+                              Use "-" to read the image from the clipboard
+      --template=TEMPLATE     Formats the output using the Go template
+      --uri                   Prints the otpauth URI
+  -w, --watch                 Refreshes the code until interrupted
+`
+	printUsage(template, cmd.Description())
+}
+
+// Parse parses the arguments and set the usage for the command
+func (cmd *TOTPCmd) Parse(args []string) error {
+	flags, err := newCommonFlags(flagOpts{Session: true})
+	if err != nil {
+		return err
+	}
+
+	flagSet.BoolVar(&cmd.clipboard, "c", false, "")
+	flagSet.BoolVar(&cmd.clipboard, "clip", false, "")
+	flagSet.BoolVar(&cmd.watch, "w", false, "")
+	flagSet.BoolVar(&cmd.watch, "watch", false, "")
+	flagSet.BoolVar(&cmd.uri, "uri", false, "")
+	flagSet.BoolVar(&cmd.qr, "qr", false, "")
+	flagSet.StringVar(&cmd.qrFile, "qr-file", "", "")
+	flagSet.StringVar(&cmd.set, "set", "", "")
+	flagSet.StringVar(&cmd.setQR, "set-qr", "", "")
+
+	flags.Parse(cmd, args)
+	if len(flagSet.Args()) != 1 {
+		cmd.Usage()
+		os.Exit(errorClassUsage.ExitCode())
+	}
+	flags.SetEnv()
+
+	if cmd.set != "" && cmd.setQR != "" {
+		return fmt.Errorf("only one of --set and --set-qr can be specified")
+	}
+
+	if cmd.clipboard || cmd.setQR == "-" {
+		err := initClipboard()
+		if err != nil {
+			return err
+		}
+	}
+
+	itemPath, err := parseItemPath(flagSet.Arg(0), itemPathOptions{fullPath: true})
+	if err != nil {
+		return err
+	}
+	if itemPath.itemType != paw.LoginItemType {
+		return fmt.Errorf("TOTP is supported only by %s items", paw.LoginItemType)
+	}
+	cmd.itemPath = itemPath
+	return nil
+}
+
+// Run runs the command
+func (cmd *TOTPCmd) Run(s paw.Storage) error {
+	key, err := loadVaultKey(s, cmd.vaultName)
+	if err != nil {
+		return err
+	}
+
+	vault, err := s.LoadVault(cmd.vaultName, key)
+	if err != nil {
+		return err
+	}
+
+	item, err := loadItem(s, vault, cmd.itemPath)
+	if err != nil {
+		return err
+	}
+
+	login := item.(*paw.Login)
+	if cmd.set != "" || cmd.setQR != "" {
+		return cmd.setTOTP(s, vault, login)
+	}
+
+	totp := login.TOTP
+	if totp == nil || totp.Secret == "" {
+		return fmt.Errorf("%w: TOTP is not configured for the item", errFieldNotFound)
+	}
+
+	if cmd.uri || cmd.qr || cmd.qrFile != "" {
+		return cmd.printOTPAuth(login)
+	}
+
+	if totp.IsHOTP() {
+		if cmd.watch {
+			return fmt.Errorf("--watch is not supported by HOTP")
+		}
+		code, err := paw.NextHOTPCode(s, vault, login)
+		if err != nil {
+			return err
+		}
+		return cmd.print(code, totp, time.Now().UTC(), true)
+	}
+
+	if !cmd.watch {
+		code, err := totp.Code(time.Now().UTC())
+		if err != nil {
+			return err
+		}
+		return cmd.print(code, totp, time.Now().UTC(), true)
+	}
+
+	ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt)
+	defer stop()
+
+	ticker := time.NewTicker(1 * time.Second)
+	defer ticker.Stop()
+
+	var last string
+	textOutput := output.format == textOutputFormat && output.template == nil
+	for {
+		now := time.Now().UTC()
+		code, err := totp.Code(now)
+		if err != nil {
+			return err
+		}
+		// in text format the remaining seconds are refreshed on the same line,
+		// otherwise the output is printed only when the code changes
+		if code != last || textOutput {
+			err = cmd.print(code, totp, now, code != last)
+			if err != nil {
+				return err
+			}
+			last = code
+		}
+
+		select {
+		case <-ctx.Done():
+			if textOutput {
+				fmt.Println()
+			}
+			return nil
+		case <-ticker.C:
+		}
+	}
+}
+
+// print prints the code generated at the specified time. When changed is true
+// the code is copied to the clipboard, if requested.
+func (cmd *TOTPCmd) print(code string, totp *paw.TOTP, now time.Time, changed bool) error {
+	out := struct {
+		Code      string  `json:"code"`
+		Remaining int     `json:"remaining,omitempty"`
+		Counter   *uint64 `json:"counter,omitempty"`
+	}{
+		Code: code,
+	}
+
+	expiration := ""
+	if totp.IsHOTP() {
+		// the counter used to generate the code
+		counter := totp.Counter - 1
+		out.Counter = &counter
+		expiration = fmt.Sprintf("counter %d", counter)
+	} else {
+		out.Remaining = int(totp.Remaining(now).Seconds())
+		expiration = fmt.Sprintf("expires in %ds", out.Remaining)
+	}
+
+	if cmd.clipboard && changed {
+		ctx, cancel := context.WithTimeout(context.Background(), clipboardWriteTimeout)
+		defer cancel()
+		err := writeToClipboard(ctx, []byte(code))
+		if err != nil {
+			return err
+		}
+		if !cmd.watch {
+			fmt.Fprintf(os.Stderr, "[✓] code copied to clipboard, %s\n", expiration)
+			return nil
+		}
+	}
+
+	return printOutput(out, func(w io.Writer) {
+		if cmd.watch {
+			fmt.Fprintf(w, "\r%s (expires in %2ds)", out.Code, out.Remaining)
+			return
+		}
+		fmt.Fprintf(w, "%s (%s)\n", out.Code, expiration)
+	})
+}
+
+// setTOTP sets the login TOTP from an otpauth URI, a base32 encoded secret or a QR code image
+func (cmd *TOTPCmd) setTOTP(s paw.Storage, vault *paw.Vault, login *paw.Login) error {
+	value := cmd.set
+	if cmd.setQR != "" {
+		var err error
+		value, err = cmd.decodeQR()
+		if err != nil {
+			return err
+		}
+	}
+
+	totp := paw.NewDefaultTOTP()
+	if strings.HasPrefix(value, paw.OTPAuthScheme+":") {
+		o, err := paw.ParseOTPAuth(value)
+		if err != nil {
+			return err
+		}
+		totp = o.TOTP
+	} else {
+		secret, err := paw.NormalizeTOTPSecret(value)
+		if err != nil {
+			return err
+		}
+		totp.Secret = secret
+	}
+
+	login.TOTP = totp
+	err := storeItem(s, vault, login)
+	if err != nil {
+		return err
+	}
+	fmt.Fprintf(os.Stderr, "[✓] TOTP set for item %q\n", cmd.itemName)
+	return nil
+}
+
+// decodeQR decodes the QR code from the image file or the clipboard
+func (cmd *TOTPCmd) decodeQR() (string, error) {
+	var r io.Reader
+	if cmd.setQR == "-" {
+		b, err := readImageFromClipboard()
+		if err != nil {
+			return "", err
+		}
+		r = bytes.NewReader(b)
+	} else {
+		f, err := os.Open(cmd.setQR)
+		if err != nil {
+			return "", fmt.Errorf("could not open the image: %w", err)
+		}
+		defer f.Close()
+		r = f
+	}
+	return qrcode.Decode(r)
+}
+
+// printOTPAuth prints the login TOTP as otpauth URI or QR code
+func (cmd *TOTPCmd) printOTPAuth(login *paw.Login) error {
+	o, err := login.OTPAuth()
+	if err != nil {
+		return err
+	}
+	uri := o.String()
+
+	if cmd.qrFile != "" {
+		b, err := qrcode.PNG(uri, 512)
+		if err != nil {
+			return err
+		}
+		err = os.WriteFile(cmd.qrFile, b, 0600)
+		if err != nil {
+			return err
+		}
+		fmt.Fprintf(os.Stderr, "[✓] QR code written to %s\n", cmd.qrFile)
+	}
+
+	if cmd.qr {
+		qr, err := qrcode.String(uri)
+		if err != nil {
+			return err
+		}
+		fmt.Print(qr)
+	}
+
+	if cmd.uri {
+		out := struct {
+			URI string `json:"uri"`
+		}{URI: uri}
+		return printOutput(out, func(w io.Writer) {
+			fmt.Fprintln(w, out.URI)
+		})
+	}
+	return nil
+}
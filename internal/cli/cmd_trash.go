@@ -0,0 +1,205 @@
+// SPDX-FileCopyrightText: 2025 Luca Corbo, Paw contributors
+// SPDX-License-Identifier: AGPL-3.0-or-later
+
+package cli
+
+import (
+	"fmt"
+	"io"
+	"os"
+	"text/tabwriter"
+	"time"
+
+	"lucor.dev/paw/internal/paw"
+)
+
+const (
+	trashListSubCmd    = "ls"
+	trashRestoreSubCmd = "restore"
+	trashPurgeSubCmd   = "purge"
+)
+
+// TrashCmd manages the items moved to the trash
+type TrashCmd struct {
+	itemPath
+	command string
+}
+
+// Name returns the one word command name
+func (cmd *TrashCmd) Name() string {
+	return "trash"
+}
+
+// Description returns the command description
+func (cmd *TrashCmd) Description() string {
+	return "Manages the items moved to the trash"
+}
+
+// Usage displays the command usage
+func (cmd *TrashCmd) Usage() {
+	template := `Usage: paw cli trash [OPTION] COMMAND VAULT_NAME[/ITEM_TYPE/ITEM_NAME]
+
+{{ . }}
+
+Commands:
+  ls         Lists the items into the trash of the vault
+  restore    Restores the item into the vault
+  purge      Deletes permanently the item, or all the items if only
+             the vault is specified
+
+The items removed from a vault are moved to the trash and purged
+automatically after the retention period, 30 days by default.
+If an item has been removed multiple times, the most recent one is used.
+
+Options:
+      --format=FORMAT         Sets the output format: text, json. Default to text
+  -h, --help                  Displays this help and exit
+      --session=SESSION_ID    Sets a session ID to use instead of the env var
+      --template=TEMPLATE     Formats the output using the Go template
+`
+	printUsage(template, cmd.Description())
+}
+
+// Parse parses the arguments and set the usage for the command
+func (cmd *TrashCmd) Parse(args []string) error {
+	flags, err := newCommonFlags(flagOpts{Session: true})
+	if err != nil {
+		return err
+	}
+
+	flags.Parse(cmd, args)
+	if len(flagSet.Args()) != 2 {
+		cmd.Usage()
+		os.Exit(errorClassUsage.ExitCode())
+	}
+	flags.SetEnv()
+
+	cmd.command = flagSet.Arg(0)
+	opts := itemPathOptions{}
+	switch cmd.command {
+	case trashListSubCmd, trashPurgeSubCmd:
+	case trashRestoreSubCmd:
+		opts.fullPath = true
+	default:
+		cmd.Usage()
+		os.Exit(errorClassUsage.ExitCode())
+	}
+
+	itemPath, err := parseItemPath(flagSet.Arg(1), opts)
+	if err != nil {
+		return err
+	}
+	if cmd.command == trashListSubCmd && (itemPath.itemType != 0 || itemPath.itemName != "") {
+		return fmt.Errorf("the ls command accepts only the vault name")
+	}
+	if cmd.command == trashPurgeSubCmd && (itemPath.itemType != 0) != (itemPath.itemName != "") {
+		return fmt.Errorf("invalid vault item path. Got %q, expected VAULT_NAME or VAULT_NAME/ITEM_TYPE/ITEM_NAME", flagSet.Arg(1))
+	}
+	cmd.itemPath = itemPath
+	return nil
+}
+
+// Run runs the command
+func (cmd *TrashCmd) Run(s paw.Storage) error {
+	appState, err := s.LoadAppState()
+	if err != nil {
+		return err
+	}
+
+	key, err := loadVaultKey(s, cmd.vaultName)
+	if err != nil {
+		return err
+	}
+
+	vault, err := s.LoadVault(cmd.vaultName, key)
+	if err != nil {
+		return err
+	}
+
+	retention := appState.Preferences.Trash.Retention()
+	now := time.Now().UTC()
+	_, err = paw.PurgeExpiredTrash(s, vault, retention, now)
+	if err != nil {
+		return err
+	}
+
+	switch cmd.command {
+	case trashListSubCmd:
+		out := []trashedItemOutput{}
+		for _, tombstone := range vault.TrashedItems() {
+			out = append(out, trashedItemOutput{
+				itemMetadataOutput: newItemMetadataOutput(cmd.vaultName, tombstone.Metadata),
+				Deleted:            tombstone.Deleted,
+				Purge:              tombstone.Deleted.Add(retention),
+			})
+		}
+		return printOutput(out, func(w io.Writer) {
+			printTrashedItems(w, out)
+		})
+	case trashRestoreSubCmd:
+		tombstone, ok := vault.TrashedItemByName(cmd.itemType, cmd.itemName)
+		if !ok {
+			return fmt.Errorf("%w: %q is not into the trash", paw.ErrItemNotFound, cmd.itemPath)
+		}
+		_, err = paw.RestoreItemFromTrash(s, vault, tombstone.ID())
+		if err != nil {
+			return err
+		}
+		fmt.Printf("[✓] item %q restored\n", cmd.itemName)
+	case trashPurgeSubCmd:
+		tombstones := vault.TrashedItems()
+		msg := fmt.Sprintf("Are you sure you want to delete permanently all the %d items into the trash?", len(tombstones))
+		if cmd.itemName != "" {
+			tombstone, ok := vault.TrashedItemByName(cmd.itemType, cmd.itemName)
+			if !ok {
+				return fmt.Errorf("%w: %q is not into the trash", paw.ErrItemNotFound, cmd.itemPath)
+			}
+			tombstones = []*paw.Tombstone{tombstone}
+			msg = fmt.Sprintf("Are you sure you want to delete permanently %q?", cmd.itemPath)
+		}
+		if len(tombstones) == 0 {
+			fmt.Println("[✓] the trash is empty")
+			return nil
+		}
+
+		confirm, err := askYesNo(msg, false)
+		if err != nil {
+			return err
+		}
+		if !confirm {
+			os.Exit(0)
+		}
+		for _, tombstone := range tombstones {
+			err = paw.PurgeItemFromTrash(s, vault, tombstone.ID())
+			if err != nil {
+				return err
+			}
+		}
+		fmt.Printf("[✓] %d items deleted permanently\n", len(tombstones))
+	}
+
+	appState.Modified = now
+	return s.StoreAppState(appState)
+}
+
+// trashedItemOutput is the structured representation of an item into the trash
+type trashedItemOutput struct {
+	itemMetadataOutput
+	Deleted time.Time `json:"deleted"`
+	Purge   time.Time `json:"purge"`
+}
+
+// printTrashedItems prints the items into the trash as a table
+func printTrashedItems(w io.Writer, items []trashedItemOutput) {
+	if len(items) == 0 {
+		fmt.Fprintln(w, "The trash is empty")
+		return
+	}
+
+	tw := tabwriter.NewWriter(w, 0, 0, 1, ' ', tabwriter.Debug)
+	fmt.Fprintln(tw, "Type\tName\tDeleted\tPurge")
+	for _, item := range items {
+		fmt.Fprintf(tw, "%s\t%s\t%s\t%s\n", item.Type, item.Name, item.Deleted.Local().Format("2006-01-02 15:04:05"), item.Purge.Local().Format("2006-01-02"))
+	}
+	tw.Flush()
+}
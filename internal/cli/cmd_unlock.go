@@ -5,6 +5,7 @@ package cli
 
 import (
 	"fmt"
+	"io"
 	"os"
 	"time"
 
@@ -36,8 +37,10 @@ func (cmd *UnlockCmd) Usage() {
 {{ . }}
 
 Options:
-  -t, --lifetime=DURATION   Sets the maximum lifetime for the session. Default to never expire
-  -h, --help            Displays this help and exit
+      --format=FORMAT         Sets the output format: text, json. Default to text
+  -h, --help                  Displays this help and exit
+  -t, --lifetime=DURATION     Sets the maximum lifetime for the session. Default to never expire
+      --template=TEMPLATE     Formats the output using the Go template
 `
 	printUsage(template, cmd.Description())
 }
@@ -55,7 +58,7 @@ func (cmd *UnlockCmd) Parse(args []string) error {
 	flags.Parse(cmd, args)
 	if len(flagSet.Args()) != 1 {
 		cmd.Usage()
-		os.Exit(1)
+		os.Exit(errorClassUsage.ExitCode())
 	}
 
 	cmd.vaultName = flagSet.Arg(0)
@@ -79,14 +82,16 @@ func (cmd *UnlockCmd) Run(s paw.Storage) error {
 		return err
 	}
 
-	fmt.Println("adding SSH keys to the agent...")
+	fmt.Fprintln(os.Stderr, "adding SSH keys to the agent...")
 	err = cmd.addSSHKeysToAgent(c, s, key)
 	if err != nil {
-		fmt.Println("could not add SSH keys to the agent:", err)
+		fmt.Fprintln(os.Stderr, "could not add SSH keys to the agent:", err)
 	}
-	fmt.Println("Session ID: ", sessionID)
-	fmt.Println("[✓] vault unlocked")
-	return nil
+	out := newSessionOutput(agent.Session{ID: sessionID, Vault: cmd.vaultName, Lifetime: cmd.life})
+	return printOutput(out, func(w io.Writer) {
+		fmt.Fprintln(w, "Session ID: ", sessionID)
+		fmt.Fprintln(w, "[✓] vault unlocked")
+	})
 }
 
 func (cmd *UnlockCmd) addSSHKeysToAgent(c agent.PawAgent, s paw.Storage, key *paw.Key) error {
@@ -113,10 +118,10 @@ func (cmd *UnlockCmd) addSSHKeysToAgent(c agent.PawAgent, s paw.Storage, key *pa
 
 		err = c.AddSSHKey(k.PrivateKey(), v.Comment)
 		if err != nil {
-			fmt.Printf("could not add SSH key to agent. Error: %q - Public key: %s", err, k.MarshalPublicKey())
+			fmt.Fprintf(os.Stderr, "could not add SSH key to agent. Error: %q - Public key: %s", err, k.MarshalPublicKey())
 			return true
 		}
-		fmt.Printf("added: %s", k.MarshalPublicKey())
+		fmt.Fprintf(os.Stderr, "added: %s", k.MarshalPublicKey())
 		return true
 	})
 	return nil
@@ -5,6 +5,7 @@ package cli
 
 import (
 	"fmt"
+	"io"
 
 	"lucor.dev/paw/internal/paw"
 )
@@ -29,7 +30,9 @@ func (cmd *VersionCmd) Usage() {
 {{ . }}
 
 Options:
-  -h, --help  Displays this help and exit
+      --format=FORMAT      Sets the output format: text, json. Default to text
+  -h, --help               Displays this help and exit
+      --template=TEMPLATE  Formats the output using the Go template
 `
 	printUsage(template, cmd.Description())
 }
@@ -48,6 +51,10 @@ func (cmd *VersionCmd) Parse(args []string) error {
 
 // Run runs the command
 func (cmd *VersionCmd) Run(s paw.Storage) error {
-	fmt.Printf("paw cli version %s\n", paw.Version())
-	return nil
+	out := struct {
+		Version string `json:"version"`
+	}{Version: paw.Version()}
+	return printOutput(out, func(w io.Writer) {
+		fmt.Fprintf(w, "paw cli version %s\n", out.Version)
+	})
 }
@@ -0,0 +1,185 @@
+// SPDX-FileCopyrightText: 2025 Luca Corbo, Paw contributors
+// SPDX-License-Identifier: AGPL-3.0-or-later
+
+package cli
+
+import (
+	"fmt"
+	"net/url"
+	"os"
+	"time"
+
+	"lucor.dev/paw/internal/paw"
+)
+
+// credentialVaultName returns the vault name used by the credential helpers.
+// If not specified the PAW_VAULT env var is used, otherwise the only vault into the storage.
+func credentialVaultName(s paw.Storage, name string) (string, error) {
+	if name != "" {
+		return name, nil
+	}
+	if name := os.Getenv(paw.ENV_VAULT); name != "" {
+		return name, nil
+	}
+	vaults, err := s.Vaults()
+	if err != nil {
+		return "", err
+	}
+	if len(vaults) != 1 {
+		return "", fmt.Errorf("%d vaults found, specify the vault using the --vault option or the %s env var", len(vaults), paw.ENV_VAULT)
+	}
+	return vaults[0], nil
+}
+
+// findLoginsByURL returns the logins that best match the URL and the match level.
+// If username is not empty only the logins with the same username are returned.
+// If tags are specified only the logins having all the tags are returned.
+func findLoginsByURL(s paw.Storage, vault *paw.Vault, u *url.URL, username string, tags ...string) ([]*paw.Login, paw.URLMatch, error) {
+	best := paw.NoURLMatch
+	var logins []*paw.Login
+	for _, meta := range vault.FilterItemMetadata(&paw.VaultFilterOptions{ItemType: paw.LoginItemType, Tags: tags}) {
+		var login *paw.Login
+		autofill := meta.Autofill
+		if autofill == nil || autofill.URL == nil {
+			// items created without autofill metadata, use the login URL
+			item, err := s.LoadItem(vault, meta)
+			if err != nil {
+				return nil, paw.NoURLMatch, err
+			}
+			login = item.(*paw.Login)
+			if login.URL == nil {
+				continue
+			}
+			autofill = &paw.Autofill{URL: login.URL.URL(), TLDPlusOne: login.URL.TLDPlusOne()}
+		}
+
+		match := autofill.MatchURL(u)
+		if match == paw.NoURLMatch || match < best {
+			continue
+		}
+
+		if login == nil {
+			item, err := s.LoadItem(vault, meta)
+			if err != nil {
+				return nil, paw.NoURLMatch, err
+			}
+			login = item.(*paw.Login)
+		}
+		if username != "" && login.Username != username {
+			continue
+		}
+
+		if match > best {
+			best = match
+			logins = nil
+		}
+		logins = append(logins, login)
+	}
+	return logins, best, nil
+}
+
+// storeCredential updates the password of the login matching the URL host and
+// the username, if any, otherwise a new login is created. If tags are
+// specified only the logins having all the tags are updated and the new login
+// is created with them.
+func storeCredential(s paw.Storage, vault *paw.Vault, u *url.URL, username string, password string, tags ...string) error {
+	logins, match, err := findLoginsByURL(s, vault, u, username, tags...)
+	if err != nil {
+		return err
+	}
+	if len(logins) > 0 && match >= paw.HostURLMatch {
+		login := logins[0]
+		if login.Password.Value == password {
+			return nil
+		}
+		previous := login.Password.Value
+		login.Password.Value = password
+		login.Password.Mode = paw.CustomPassword
+		login.Password.UpdateHistory(previous, time.Now().UTC())
+		return storeItem(s, vault, login)
+	}
+
+	item, err := paw.NewItem(u.Host, paw.LoginItemType)
+	if err != nil {
+		return err
+	}
+	if vault.HasItem(item) {
+		item.GetMetadata().Name = username + "@" + u.Host
+	}
+	if vault.HasItem(item) {
+		return fmt.Errorf("%w: %q", paw.ErrItemAlreadyExists, item.GetMetadata().Name)
+	}
+
+	login := item.(*paw.Login)
+	err = login.URL.Set(u.String())
+	if err != nil {
+		return err
+	}
+	login.Username = username
+	login.Password.Value = password
+	login.Password.Mode = paw.CustomPassword
+	login.Metadata.Subtitle = login.Subtitle()
+	login.Metadata.Tags = paw.NormalizeTags(tags)
+	login.Metadata.Autofill = &paw.Autofill{
+		URL:        login.URL.URL(),
+		TLDPlusOne: login.URL.TLDPlusOne(),
+		// the credential has been explicitly stored for a plain http URL
+		AllowHTTP: u.Scheme == "http",
+	}
+	return storeItem(s, vault, login)
+}
+
+// eraseCredential moves to the trash the logins matching the URL host and the username.
+// If password is not empty only the logins with the same password are removed.
+// If tags are specified only the logins having all the tags are removed.
+// The logins are looked up under the vault lock, after merging the changes of
+// the other writers, see paw.MergeVault.
+func eraseCredential(s paw.Storage, vault *paw.Vault, u *url.URL, username string, password string, tags ...string) error {
+	unlock, err := paw.LockVault(s, vault)
+	if err != nil {
+		return err
+	}
+	defer unlock()
+
+	_, err = paw.MergeVault(s, vault)
+	if err != nil {
+		return err
+	}
+
+	logins, match, err := findLoginsByURL(s, vault, u, username, tags...)
+	if err != nil {
+		return err
+	}
+	if match < paw.HostURLMatch {
+		return nil
+	}
+
+	now := time.Now().UTC()
+	deleted := 0
+	for _, login := range logins {
+		if password != "" && login.Password.Value != password {
+			continue
+		}
+		err = s.TrashItem(vault, login)
+		if err != nil {
+			return err
+		}
+		vault.TrashItem(login, now)
+		deleted++
+	}
+	if deleted == 0 {
+		return nil
+	}
+
+	appState, err := s.LoadAppState()
+	if err != nil {
+		return err
+	}
+	vault.Modified = now
+	err = s.StoreVault(vault)
+	if err != nil {
+		return err
+	}
+	appState.Modified = now
+	return s.StoreAppState(appState)
+}
@@ -0,0 +1,39 @@
+// SPDX-FileCopyrightText: 2025 Luca Corbo, Paw contributors
+// SPDX-License-Identifier: AGPL-3.0-or-later
+
+package cli
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"lucor.dev/paw/internal/paw"
+)
+
+// newCredentialTestVault returns a storage rooted into a temporary dir with an empty vault
+func newCredentialTestVault(t *testing.T) (paw.Storage, *paw.Vault) {
+	s, err := paw.NewOSStorageRooted(t.TempDir())
+	require.NoError(t, err)
+	key, err := s.CreateVaultKey("test", "secret")
+	require.NoError(t, err)
+	vault, err := s.CreateVault("test", key)
+	require.NoError(t, err)
+	return s, vault
+}
+
+// storeCredentialTestLogin stores a login for the URL into the vault
+func storeCredentialTestLogin(t *testing.T, s paw.Storage, vault *paw.Vault, name string, rawURL string, username string, allowHTTP bool) *paw.Login {
+	login := paw.NewLogin()
+	login.Name = name
+	login.Username = username
+	login.Password.Value = name + "-password"
+	require.NoError(t, login.URL.Set(rawURL))
+	login.Autofill = &paw.Autofill{
+		URL:        login.URL.URL(),
+		TLDPlusOne: login.URL.TLDPlusOne(),
+		AllowHTTP:  allowHTTP,
+	}
+	require.NoError(t, storeItem(s, vault, login))
+	return login
+}
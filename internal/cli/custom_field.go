@@ -0,0 +1,118 @@
+// SPDX-FileCopyrightText: 2025 Luca Corbo, Paw contributors
+// SPDX-License-Identifier: AGPL-3.0-or-later
+
+package cli
+
+import (
+	"fmt"
+	"io"
+	"strings"
+	"time"
+
+	"lucor.dev/paw/internal/paw"
+)
+
+// parseCustomField parses a custom field in the NAME[:TYPE][=VALUE] format.
+// The value is asked if not specified, the text type is used if not specified.
+func parseCustomField(spec string) (*paw.CustomField, error) {
+	name, value, hasValue := strings.Cut(spec, "=")
+	fieldType := paw.TextCustomField
+	if i := strings.LastIndex(name, ":"); i != -1 {
+		t, err := paw.CustomFieldTypeFromString(name[i+1:])
+		if err != nil {
+			return nil, err
+		}
+		name, fieldType = name[:i], t
+	}
+
+	if !hasValue {
+		var err error
+		prompt := fmt.Sprintf("%s (%s)", name, fieldType)
+		if fieldType.IsSecret() {
+			value, err = askPassword(prompt)
+		} else {
+			value, err = ask(prompt)
+		}
+		if err != nil {
+			return nil, err
+		}
+	}
+	return paw.NewCustomField(name, fieldType, value)
+}
+
+// setCustomFields sets the custom fields specified in the NAME[:TYPE][=VALUE] format
+func setCustomFields(item paw.Item, specs []string) error {
+	if len(specs) == 0 {
+		return nil
+	}
+	v, ok := item.(paw.CustomFielder)
+	if !ok {
+		return fmt.Errorf("custom fields are not supported by the %s item", item.GetMetadata().Type)
+	}
+	for _, spec := range specs {
+		f, err := parseCustomField(spec)
+		if err != nil {
+			return err
+		}
+		err = v.GetCustomFields().Set(f)
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// removeCustomFields removes the custom fields with the specified names
+func removeCustomFields(item paw.Item, names []string) error {
+	if len(names) == 0 {
+		return nil
+	}
+	v, ok := item.(paw.CustomFielder)
+	if !ok {
+		return fmt.Errorf("custom fields are not supported by the %s item", item.GetMetadata().Type)
+	}
+	for _, name := range names {
+		if !v.GetCustomFields().Remove(name) {
+			return fmt.Errorf("%w: the item has no custom field named %q", errFieldNotFound, name)
+		}
+	}
+	return nil
+}
+
+// customFieldOutput is the structured representation of a custom field
+type customFieldOutput struct {
+	Name  string `json:"name"`
+	Type  string `json:"type"`
+	Value string `json:"value,omitempty"`
+}
+
+func newCustomFieldsOutput(item paw.Item) []customFieldOutput {
+	v, ok := item.(paw.CustomFielder)
+	if !ok {
+		return nil
+	}
+	out := []customFieldOutput{}
+	for _, f := range *v.GetCustomFields() {
+		out = append(out, customFieldOutput{Name: f.Name, Type: string(f.Type), Value: f.Value})
+	}
+	if len(out) == 0 {
+		return nil
+	}
+	return out
+}
+
+// printCustomFields prints the custom fields in text format. The TOTP fields are printed as code.
+func printCustomFields(w io.Writer, item paw.Item) {
+	v, ok := item.(paw.CustomFielder)
+	if !ok || len(*v.GetCustomFields()) == 0 {
+		return
+	}
+	fmt.Fprintln(w, "Custom fields:")
+	for _, f := range *v.GetCustomFields() {
+		value, err := f.Resolve(time.Now().UTC())
+		if err != nil {
+			value = fmt.Sprintf("<%s>", err)
+		}
+		fmt.Fprintf(w, "  %s: %s\n", f.Name, value)
+	}
+}
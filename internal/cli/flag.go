@@ -8,6 +8,7 @@ import (
 	"fmt"
 	"io"
 	"os"
+	"strings"
 
 	"lucor.dev/paw/internal/paw"
 )
@@ -20,6 +21,10 @@ type CommonFlags struct {
 	Help bool
 	// SessionID is the session ID
 	SessionID string
+	// Format is the output format
+	Format string
+	// Template is the Go template used to format the output
+	Template string
 }
 
 type flagOpts struct {
@@ -31,6 +36,8 @@ func newCommonFlags(o flagOpts) (*CommonFlags, error) {
 	flags := &CommonFlags{}
 	flagSet.BoolVar(&flags.Help, "help", false, "")
 	flagSet.BoolVar(&flags.Help, "h", false, "")
+	flagSet.StringVar(&flags.Format, "format", string(textOutputFormat), "")
+	flagSet.StringVar(&flags.Template, "template", "", "")
 	if o.Session {
 		flagSet.StringVar(&flags.SessionID, "session", "", "")
 	}
@@ -51,10 +58,37 @@ func (f *CommonFlags) Parse(cmd Cmd, args []string) {
 		fmt.Println("[✗]", err)
 		fmt.Println()
 		cmd.Usage()
-		os.Exit(1)
+		os.Exit(errorClassUsage.ExitCode())
 	}
 	if f.Help {
 		cmd.Usage()
 		os.Exit(0)
 	}
+
+	if f.Format == "" {
+		return
+	}
+	format, err := outputFormatFromString(f.Format)
+	if err != nil {
+		exitWithError(err, errorClassUsage)
+	}
+	output.format = format
+	if f.Template != "" {
+		err = output.setTemplate(f.Template)
+		if err != nil {
+			exitWithError(err, errorClassUsage)
+		}
+	}
+}
+
+// stringsFlag is a flag that can be specified multiple times
+type stringsFlag []string
+
+func (f *stringsFlag) String() string {
+	return strings.Join(*f, ",")
+}
+
+func (f *stringsFlag) Set(v string) error {
+	*f = append(*f, v)
+	return nil
 }
@@ -0,0 +1,123 @@
+// SPDX-FileCopyrightText: 2025 Luca Corbo, Paw contributors
+// SPDX-License-Identifier: AGPL-3.0-or-later
+
+package cli
+
+import (
+	"fmt"
+	"io"
+	"strings"
+
+	"lucor.dev/paw/internal/paw"
+)
+
+// askItemFields asks the values of the item fields.
+// The current values are proposed as default, for the secret fields an empty value keeps the current one.
+func askItemFields(item paw.FieldsItem) error {
+	for _, f := range item.ItemFields() {
+		prompt := f.Label
+		if len(f.Options) > 0 {
+			prompt = fmt.Sprintf("%s (%s)", f.Label, strings.Join(f.Options, "/"))
+		}
+		var value string
+		var err error
+		switch {
+		case f.Type.IsSecret() && *f.Value != "":
+			value, err = askPassword(fmt.Sprintf("%s (leave empty to keep the current value)", prompt))
+			if value == "" {
+				value = *f.Value
+			}
+		case f.Type.IsSecret():
+			value, err = askPassword(prompt)
+		case *f.Value != "":
+			value, err = askWithDefault(prompt, *f.Value)
+		default:
+			value, err = ask(prompt)
+		}
+		if err != nil {
+			return err
+		}
+		*f.Value = value
+	}
+	return paw.NormalizeItemFields(item)
+}
+
+// setItemFields sets the item fields specified in the NAME=VALUE format
+func setItemFields(item paw.Item, specs []string) error {
+	if len(specs) == 0 {
+		return nil
+	}
+	v, ok := item.(paw.FieldsItem)
+	if !ok {
+		return fmt.Errorf("the --set option is not supported by the %s item, use the --field option for custom fields", item.GetMetadata().Type)
+	}
+	for _, spec := range specs {
+		name, value, ok := strings.Cut(spec, "=")
+		if !ok {
+			return fmt.Errorf("invalid field %q, expected NAME=VALUE", spec)
+		}
+		f, ok := paw.ItemFieldByName(v, strings.TrimSpace(name))
+		if !ok {
+			return fmt.Errorf("%w: %q is not a field of the %s item", errFieldNotFound, name, item.GetMetadata().Type)
+		}
+		*f.Value = value
+	}
+	return paw.NormalizeItemFields(v)
+}
+
+// itemFieldsUsage returns the usage lines listing the fields of the items composed only of plain fields
+func itemFieldsUsage(prefix string) string {
+	lines := []string{}
+	for _, t := range paw.ItemTypes() {
+		item, err := paw.NewItem("", t)
+		if err != nil {
+			continue
+		}
+		v, ok := item.(paw.FieldsItem)
+		if !ok {
+			continue
+		}
+		names := []string{prefix}
+		for _, f := range v.ItemFields() {
+			names = append(names, f.Name)
+		}
+		lines = append(lines, fmt.Sprintf("  %-16s%s", t, strings.Join(names, ", ")))
+	}
+	return strings.Join(lines, "\n")
+}
+
+// newItemFieldsOutput returns the item field values by name
+func newItemFieldsOutput(item paw.Item) map[string]string {
+	v, ok := item.(paw.FieldsItem)
+	if !ok {
+		return nil
+	}
+	out := map[string]string{}
+	for _, f := range v.ItemFields() {
+		if *f.Value != "" {
+			out[f.Name] = *f.Value
+		}
+	}
+	return out
+}
+
+// printItemFields prints the non empty item fields in text format skipping the named field
+func printItemFields(w io.Writer, item paw.FieldsItem, skip string) {
+	for _, f := range item.ItemFields() {
+		if f.Name == skip || *f.Value == "" {
+			continue
+		}
+		fmt.Fprintf(w, "%s: %s\n", f.Label, *f.Value)
+	}
+}
+
+// clipboardItemField returns the first secret field of the item, that is the
+// value copied to the clipboard
+func clipboardItemField(item paw.FieldsItem) (*paw.ItemField, bool) {
+	for _, f := range item.ItemFields() {
+		if f.Type.IsSecret() {
+			return f, true
+		}
+	}
+	return nil, false
+}
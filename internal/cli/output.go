@@ -0,0 +1,296 @@
+// SPDX-FileCopyrightText: 2025 Luca Corbo, Paw contributors
+// SPDX-License-Identifier: AGPL-3.0-or-later
+
+package cli
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"text/template"
+	"time"
+
+	"filippo.io/age"
+
+	"lucor.dev/paw/internal/agent"
+	"lucor.dev/paw/internal/paw"
+)
+
+// outputFormat represents the format used to print the command output
+type outputFormat string
+
+const (
+	textOutputFormat outputFormat = "text"
+	jsonOutputFormat outputFormat = "json"
+)
+
+// outputFormatFromString returns the output format from a string
+func outputFormatFromString(v string) (outputFormat, error) {
+	switch outputFormat(v) {
+	case textOutputFormat, jsonOutputFormat:
+		return outputFormat(v), nil
+	}
+	return "", fmt.Errorf("invalid output format %q, expected one of: text, json", v)
+}
+
+// outputOptions holds the options to print the command output
+type outputOptions struct {
+	format   outputFormat
+	template *template.Template
+}
+
+// output holds the output options set by the common flags
+var output = &outputOptions{format: textOutputFormat}
+
+// setTemplate parses the Go template used to print the command output
+func (o *outputOptions) setTemplate(text string) error {
+	tpl, err := template.New("output").Funcs(template.FuncMap{
+		"json": func(v interface{}) (string, error) {
+			b, err := json.Marshal(v)
+			return string(b), err
+		},
+	}).Parse(text)
+	if err != nil {
+		return fmt.Errorf("could not parse the template: %w", err)
+	}
+	o.template = tpl
+	return nil
+}
+
+// print prints the data to w according to the output options.
+// The text function is used to print the data in text format when a template is not specified.
+func (o *outputOptions) print(w io.Writer, data interface{}, text func(w io.Writer)) error {
+	if o.template != nil {
+		err := o.template.Execute(w, data)
+		if err != nil {
+			return fmt.Errorf("could not execute the template: %w", err)
+		}
+		return nil
+	}
+	if o.format == jsonOutputFormat {
+		enc := json.NewEncoder(w)
+		enc.SetIndent("", "  ")
+		return enc.Encode(data)
+	}
+	text(w)
+	return nil
+}
+
+// printOutput prints the data to the standard output according to the output options
+func printOutput(data interface{}, text func(w io.Writer)) error {
+	return output.print(os.Stdout, data, text)
+}
+
+// errorClass represents a class of errors returned by the CLI commands.
+// Each class has its own exit code.
+type errorClass string
+
+const (
+	// errorClassGeneric is the class for errors without a specific class
+	errorClassGeneric errorClass = "error"
+	// errorClassUsage is the class for invalid arguments and options
+	errorClassUsage errorClass = "usage"
+	// errorClassNotFound is the class for vaults, items and files not found
+	errorClassNotFound errorClass = "not_found"
+	// errorClassAuth is the class for invalid passwords and sessions
+	errorClassAuth errorClass = "auth"
+	// errorClassConflict is the class for items already present into the vault
+	errorClassConflict errorClass = "conflict"
+)
+
+// ExitCode returns the exit code for the error class
+func (c errorClass) ExitCode() int {
+	switch c {
+	case errorClassUsage:
+		return 2
+	case errorClassNotFound:
+		return 3
+	case errorClassAuth:
+		return 4
+	case errorClassConflict:
+		return 5
+	}
+	return 1
+}
+
+// errFieldNotFound is returned when a field is not defined for an item
+var errFieldNotFound = errors.New("field not found")
+
+// authError is returned when the vault key cannot be obtained
+type authError struct {
+	err error
+}
+
+func (e *authError) Error() string {
+	return e.err.Error()
+}
+
+func (e *authError) Unwrap() error {
+	return e.err
+}
+
+// classifyError returns the class of the error. Fallback is returned if the error has not a specific class.
+func classifyError(err error, fallback errorClass) errorClass {
+	var ae *authError
+	var invalidPasswordError *age.NoIdentityMatchError
+	switch {
+	case errors.As(err, &ae), errors.As(err, &invalidPasswordError):
+		return errorClassAuth
+	case errors.Is(err, paw.ErrItemNotFound), errors.Is(err, paw.ErrVaultNotFound), errors.Is(err, errFieldNotFound), errors.Is(err, os.ErrNotExist), errors.Is(err, paw.ErrAttachmentNotFound):
+		return errorClassNotFound
+	case errors.Is(err, paw.ErrItemAlreadyExists), errors.Is(err, paw.ErrAttachmentAlreadyExists):
+		return errorClassConflict
+	}
+	return fallback
+}
+
+// errorOutput is the structured representation of an error
+type errorOutput struct {
+	Error struct {
+		Class   errorClass `json:"class"`
+		Message string     `json:"message"`
+	} `json:"error"`
+}
+
+// exitWithError prints the error to the standard error according to the
+// output format and exits with the exit code of the error class
+func exitWithError(err error, fallback errorClass) {
+	class := classifyError(err, fallback)
+	if output.format == jsonOutputFormat {
+		v := errorOutput{}
+		v.Error.Class = class
+		v.Error.Message = err.Error()
+		_ = json.NewEncoder(os.Stderr).Encode(v)
+	} else {
+		fmt.Fprintf(os.Stderr, "[✗] %s\n", err)
+	}
+	os.Exit(class.ExitCode())
+}
+
+// vaultOutput is the structured representation of a vault
+type vaultOutput struct {
+	Name string `json:"name"`
+}
+
+// itemMetadataOutput is the structured representation of an item metadata
+type itemMetadataOutput struct {
+	Vault    string    `json:"vault"`
+	ID       string    `json:"id"`
+	Type     string    `json:"type"`
+	Name     string    `json:"name"`
+	Subtitle string    `json:"subtitle,omitempty"`
+	Tags     []string  `json:"tags,omitempty"`
+	Folder   string    `json:"folder,omitempty"`
+	Created  time.Time `json:"created"`
+	Modified time.Time `json:"modified"`
+}
+
+func newItemMetadataOutput(vaultName string, meta *paw.Metadata) itemMetadataOutput {
+	return itemMetadataOutput{
+		Vault:    vaultName,
+		ID:       meta.ID(),
+		Type:     meta.Type.String(),
+		Name:     meta.Name,
+		Subtitle: meta.Subtitle,
+		Tags:     meta.Tags,
+		Folder:   meta.Folder,
+		Created:  meta.Created,
+		Modified: meta.Modified,
+	}
+}
+
+// itemOutput is the structured representation of an item.
+// Fields not defined by the item type are omitted.
+type itemOutput struct {
+	itemMetadataOutput
+	URL         string `json:"url,omitempty"`
+	Username    string `json:"username,omitempty"`
+	Password    string `json:"password,omitempty"`
+	PrivateKey  string `json:"private_key,omitempty"`
+	Passphrase  string `json:"passphrase,omitempty"`
+	PublicKey   string `json:"public_key,omitempty"`
+	Fingerprint string `json:"fingerprint,omitempty"`
+	AddToAgent  *bool  `json:"add_to_agent,omitempty"`
+	Note        string `json:"note,omitempty"`
+	// Details holds the values of the items composed only of plain fields, by field name
+	Details map[string]string `json:"details,omitempty"`
+
+	Fields []customFieldOutput `json:"fields,omitempty"`
+}
+
+func newItemOutput(vaultName string, item paw.Item) itemOutput {
+	v := itemOutput{
+		itemMetadataOutput: newItemMetadataOutput(vaultName, item.GetMetadata()),
+	}
+	switch item := item.(type) {
+	case *paw.Login:
+		if item.URL != nil {
+			v.URL = item.URL.String()
+		}
+		v.Username = item.Username
+		if item.Password != nil {
+			v.Password = item.Password.Value
+		}
+		if item.Note != nil {
+			v.Note = item.Note.Value
+		}
+	case *paw.Password:
+		v.Password = item.Value
+		if item.Note != nil {
+			v.Note = item.Note.Value
+		}
+	case *paw.SSHKey:
+		v.PrivateKey = item.PrivateKey
+		if item.Passphrase != nil {
+			v.Passphrase = item.Passphrase.Value
+		}
+		v.PublicKey = item.PublicKey
+		v.Fingerprint = item.Fingerprint
+		v.AddToAgent = &item.AddToAgent
+		if item.Note != nil {
+			v.Note = item.Note.Value
+		}
+	case *paw.Note:
+		v.Note = item.Value
+	}
+	v.Details = newItemFieldsOutput(item)
+	v.Fields = newCustomFieldsOutput(item)
+	return v
+}
+
+// sessionOutput is the structured representation of an agent session
+type sessionOutput struct {
+	ID       string `json:"id"`
+	Vault    string `json:"vault"`
+	Lifetime int64  `json:"lifetime"`
+}
+
+func newSessionOutput(session agent.Session) sessionOutput {
+	return sessionOutput{
+		ID:       session.ID,
+		Vault:    session.Vault,
+		Lifetime: int64(session.Lifetime.Round(time.Second).Seconds()),
+	}
+}
+
+// resultOutput is the structured representation of the result of a command
+// changing a vault or an item
+type resultOutput struct {
+	Vault  string              `json:"vault"`
+	Action string              `json:"action"`
+	Item   *itemMetadataOutput `json:"item,omitempty"`
+}
+
+func newItemResultOutput(vaultName string, action string, meta *paw.Metadata) resultOutput {
+	item := newItemMetadataOutput(vaultName, meta)
+	return resultOutput{Vault: vaultName, Action: action, Item: &item}
+}
+
+// printResult prints the result of a command, the message is printed in text format
+func printResult(out interface{}, message string) error {
+	return printOutput(out, func(w io.Writer) {
+		fmt.Fprintf(w, "[✓] %s\n", message)
+	})
+}
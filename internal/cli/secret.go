@@ -0,0 +1,97 @@
+// SPDX-FileCopyrightText: 2025 Luca Corbo, Paw contributors
+// SPDX-License-Identifier: AGPL-3.0-or-later
+
+package cli
+
+import (
+	"fmt"
+	"net/url"
+	"strings"
+
+	"lucor.dev/paw/internal/paw"
+)
+
+// secretRefScheme is the scheme of the secret references in URI form
+const secretRefScheme = "paw://"
+
+// secretRef is a reference to the field of a vault item
+type secretRef struct {
+	itemPath
+	field string
+}
+
+func (r secretRef) String() string {
+	return secretRefScheme + r.itemPath.String() + "/" + r.field
+}
+
+// parseSecretRef parses a secret reference. Both the forms are supported:
+//   - VAULT_NAME/ITEM_TYPE/ITEM_NAME:FIELD
+//   - paw://VAULT_NAME/ITEM_TYPE/ITEM_NAME/FIELD
+func parseSecretRef(ref string) (secretRef, error) {
+	var path, field string
+	if strings.HasPrefix(ref, secretRefScheme) {
+		parts := strings.Split(strings.TrimPrefix(ref, secretRefScheme), "/")
+		if len(parts) != 4 {
+			return secretRef{}, fmt.Errorf("invalid secret reference %q, expected %sVAULT_NAME/ITEM_TYPE/ITEM_NAME/FIELD", ref, secretRefScheme)
+		}
+		for i, v := range parts {
+			unescaped, err := url.PathUnescape(v)
+			if err != nil {
+				return secretRef{}, fmt.Errorf("invalid secret reference %q: %w", ref, err)
+			}
+			parts[i] = unescaped
+		}
+		path, field = strings.Join(parts[:3], "/"), parts[3]
+	} else {
+		i := strings.LastIndex(ref, ":")
+		if i == -1 {
+			return secretRef{}, fmt.Errorf("invalid secret reference %q, expected VAULT_NAME/ITEM_TYPE/ITEM_NAME:FIELD", ref)
+		}
+		path, field = ref[:i], ref[i+1:]
+	}
+
+	if field == "" {
+		return secretRef{}, fmt.Errorf("invalid secret reference %q: the field is empty", ref)
+	}
+	ip, err := parseItemPath(path, itemPathOptions{fullPath: true})
+	if err != nil {
+		return secretRef{}, err
+	}
+	return secretRef{itemPath: ip, field: field}, nil
+}
+
+// secretResolver resolves the secret references loading the vaults once
+type secretResolver struct {
+	storage paw.Storage
+	vaults  map[string]*paw.Vault
+}
+
+func newSecretResolver(s paw.Storage) *secretResolver {
+	return &secretResolver{
+		storage: s,
+		vaults:  make(map[string]*paw.Vault),
+	}
+}
+
+// Resolve returns the value of the referenced item field.
+// The vault key is obtained from the session, if any, otherwise the vault password is asked.
+func (r *secretResolver) Resolve(ref secretRef) (string, error) {
+	vault, ok := r.vaults[ref.vaultName]
+	if !ok {
+		key, err := loadVaultKey(r.storage, ref.vaultName)
+		if err != nil {
+			return "", err
+		}
+		vault, err = r.storage.LoadVault(ref.vaultName, key)
+		if err != nil {
+			return "", err
+		}
+		r.vaults[ref.vaultName] = vault
+	}
+
+	item, err := loadItem(r.storage, vault, ref.itemPath)
+	if err != nil {
+		return "", err
+	}
+	return loadItemField(r.storage, vault, item, ref.field)
+}
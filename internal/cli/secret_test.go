@@ -0,0 +1,107 @@
+// SPDX-FileCopyrightText: 2025 Luca Corbo, Paw contributors
+// SPDX-License-Identifier: AGPL-3.0-or-later
+
+package cli
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"lucor.dev/paw/internal/paw"
+)
+
+func TestParseSecretRef(t *testing.T) {
+	tests := map[string]struct {
+		ref     string
+		want    secretRef
+		wantErr bool
+	}{
+		"path form": {
+			ref:  "work/login/github:password",
+			want: secretRef{itemPath: itemPath{vaultName: "work", itemType: paw.LoginItemType, itemName: "github"}, field: "password"},
+		},
+		"path form with colon into the item name": {
+			ref:  "work/login/host:8080:username",
+			want: secretRef{itemPath: itemPath{vaultName: "work", itemType: paw.LoginItemType, itemName: "host:8080"}, field: "username"},
+		},
+		"uri form": {
+			ref:  "paw://work/login/github/password",
+			want: secretRef{itemPath: itemPath{vaultName: "work", itemType: paw.LoginItemType, itemName: "github"}, field: "password"},
+		},
+		"uri form escaped": {
+			ref:  "paw://work/note/my%20note/custom%20field",
+			want: secretRef{itemPath: itemPath{vaultName: "work", itemType: paw.NoteItemType, itemName: "my note"}, field: "custom field"},
+		},
+		"path form missing field": {
+			ref:     "work/login/github",
+			wantErr: true,
+		},
+		"path form empty field": {
+			ref:     "work/login/github:",
+			wantErr: true,
+		},
+		"path form missing item name": {
+			ref:     "work/login:password",
+			wantErr: true,
+		},
+		"path form empty item name": {
+			ref:     "work/login/:password",
+			wantErr: true,
+		},
+		"path form invalid type": {
+			ref:     "work/unknown/github:password",
+			wantErr: true,
+		},
+		"path form too many elements": {
+			ref:     "work/login/github/other:password",
+			wantErr: true,
+		},
+		"uri form missing field": {
+			ref:     "paw://work/login/github",
+			wantErr: true,
+		},
+		"uri form empty field": {
+			ref:     "paw://work/login/github/",
+			wantErr: true,
+		},
+		"uri form too many elements": {
+			ref:     "paw://work/login/github/password/other",
+			wantErr: true,
+		},
+		"uri form invalid escape": {
+			ref:     "paw://work/login/git%zzhub/password",
+			wantErr: true,
+		},
+		"uri form empty vault": {
+			ref:     "paw:///login/github/password",
+			wantErr: true,
+		},
+		"empty": {
+			ref:     "",
+			wantErr: true,
+		},
+	}
+	for name, tt := range tests {
+		t.Run(name, func(t *testing.T) {
+			got, err := parseSecretRef(tt.ref)
+			if tt.wantErr {
+				assert.Error(t, err)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, tt.want, got)
+		})
+	}
+}
+
+func TestSecretRef_String(t *testing.T) {
+	ref, err := parseSecretRef("work/login/github:password")
+	require.NoError(t, err)
+	assert.Equal(t, "paw://work/login/github/password", ref.String())
+
+	again, err := parseSecretRef(ref.String())
+	require.NoError(t, err)
+	assert.Equal(t, ref, again)
+}
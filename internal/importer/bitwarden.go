@@ -0,0 +1,184 @@
+// SPDX-FileCopyrightText: 2025 Luca Corbo, Paw contributors
+// SPDX-License-Identifier: AGPL-3.0-or-later
+
+package importer
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"time"
+
+	"lucor.dev/paw/internal/paw"
+)
+
+// Bitwarden custom field types
+const (
+	bitwardenTextField    = 0
+	bitwardenHiddenField  = 1
+	bitwardenBooleanField = 2
+)
+
+// Bitwarden item types
+const (
+	bitwardenLogin      = 1
+	bitwardenSecureNote = 2
+	bitwardenCard       = 3
+	bitwardenIdentity   = 4
+	bitwardenSSHKey     = 5
+)
+
+type bitwardenExport struct {
+	Encrypted bool            `json:"encrypted"`
+	Items     []bitwardenItem `json:"items"`
+}
+
+type bitwardenItem struct {
+	Type         int                    `json:"type"`
+	Name         string                 `json:"name"`
+	Notes        string                 `json:"notes"`
+	CreationDate time.Time              `json:"creationDate"`
+	RevisionDate time.Time              `json:"revisionDate"`
+	Fields       []bitwardenField       `json:"fields"`
+	Login        *bitwardenLoginData    `json:"login"`
+	Card         map[string]interface{} `json:"card"`
+	Identity     map[string]interface{} `json:"identity"`
+	SSHKey       *bitwardenSSHKeyData   `json:"sshKey"`
+}
+
+type bitwardenField struct {
+	Name  string `json:"name"`
+	Value string `json:"value"`
+	Type  int    `json:"type"`
+}
+
+type bitwardenLoginData struct {
+	Username string `json:"username"`
+	Password string `json:"password"`
+	TOTP     string `json:"totp"`
+	URIs     []struct {
+		URI string `json:"uri"`
+	} `json:"uris"`
+}
+
+type bitwardenSSHKeyData struct {
+	PrivateKey     string `json:"privateKey"`
+	PublicKey      string `json:"publicKey"`
+	KeyFingerprint string `json:"keyFingerprint"`
+}
+
+func importBitwarden(r io.Reader) ([]paw.Item, error) {
+	data := &bitwardenExport{}
+	err := json.NewDecoder(r).Decode(data)
+	if err != nil {
+		return nil, err
+	}
+	if data.Encrypted {
+		return nil, errors.New("encrypted exports are not supported")
+	}
+
+	items := []paw.Item{}
+	for _, v := range data.Items {
+		note := v.Notes
+		fields := paw.CustomFields{}
+		for _, f := range v.Fields {
+			fieldType := paw.TextCustomField
+			if f.Type == bitwardenHiddenField {
+				fieldType = paw.HiddenCustomField
+			}
+			addCustomField(&fields, f.Name, fieldType, f.Value)
+		}
+
+		var item paw.Item
+		switch v.Type {
+		case bitwardenLogin:
+			l := login{
+				name:     v.Name,
+				note:     note,
+				fields:   fields,
+				created:  v.CreationDate,
+				modified: v.RevisionDate,
+			}
+			if v.Login != nil {
+				l.username = v.Login.Username
+				l.password = v.Login.Password
+				l.totp = v.Login.TOTP
+				for i, u := range v.Login.URIs {
+					if i == 0 {
+						l.url = u.URI
+						continue
+					}
+					addCustomField(&l.fields, "URL", paw.URLCustomField, u.URI)
+				}
+			}
+			item = l.item()
+		case bitwardenSSHKey:
+			if v.SSHKey == nil {
+				return nil, fmt.Errorf("SSH key data missing for item %q", v.Name)
+			}
+			item = makeSSHKey(v.Name, v.SSHKey.PrivateKey, v.SSHKey.PublicKey, v.SSHKey.KeyFingerprint, note, fields)
+		case bitwardenCard:
+			item = makeFieldsItem(paw.CardItemType, v.Name, bitwardenCardValues(v.Card, note), fields)
+		case bitwardenIdentity:
+			item = makeFieldsItem(paw.IdentityItemType, v.Name, bitwardenIdentityValues(v.Identity, note), fields)
+		default:
+			item = makeNote(v.Name, note, fields)
+		}
+		setTimes(item.GetMetadata(), v.CreationDate, v.RevisionDate)
+		items = append(items, item)
+	}
+	return items, nil
+}
+
+// bitwardenCardValues returns the values of a Bitwarden card
+func bitwardenCardValues(m map[string]interface{}, note string) []fieldValue {
+	get := mapValue(m)
+	expiry := ""
+	if get("expMonth") != "" && get("expYear") != "" {
+		expiry = get("expMonth") + "/" + get("expYear")
+	}
+	return []fieldValue{
+		{name: "cardholder", label: "Cardholder", value: get("cardholderName")},
+		{name: "brand", label: "Brand", value: get("brand")},
+		{name: "number", label: "Number", value: get("number")},
+		{name: "expiry", label: "Expiry", value: expiry},
+		{name: "cvv", label: "CVV", value: get("code")},
+		{name: "notes", label: "Notes", value: note},
+	}
+}
+
+// bitwardenIdentityValues returns the values of a Bitwarden identity
+func bitwardenIdentityValues(m map[string]interface{}, note string) []fieldValue {
+	get := mapValue(m)
+	address := appendField(appendField(get("address1"), "", get("address2")), "", get("address3"))
+	return []fieldValue{
+		{name: "title", label: "Title", value: get("title")},
+		{name: "first_name", label: "First name", value: get("firstName")},
+		{name: "middle_name", label: "Middle name", value: get("middleName")},
+		{name: "last_name", label: "Last name", value: get("lastName")},
+		{name: "username", label: "Username", value: get("username")},
+		{name: "company", label: "Company", value: get("company")},
+		{name: "email", label: "Email", value: get("email")},
+		{name: "phone", label: "Phone", value: get("phone")},
+		{name: "address", label: "Address", value: address},
+		{name: "city", label: "City", value: get("city")},
+		{name: "region", label: "Region", value: get("state")},
+		{name: "postal_code", label: "Postal code", value: get("postalCode")},
+		{name: "country", label: "Country", value: get("country")},
+		{name: "ssn", label: "Social security number", secret: true, value: get("ssn")},
+		{name: "passport_number", label: "Passport number", secret: true, value: get("passportNumber")},
+		{name: "license_number", label: "License number", secret: true, value: get("licenseNumber")},
+		{name: "notes", label: "Notes", value: note},
+	}
+}
+
+// mapValue returns a function returning the map values as string, empty if nil
+func mapValue(m map[string]interface{}) func(key string) string {
+	return func(key string) string {
+		if m[key] == nil {
+			return ""
+		}
+		return fmt.Sprint(m[key])
+	}
+}
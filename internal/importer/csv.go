@@ -0,0 +1,96 @@
+// SPDX-FileCopyrightText: 2025 Luca Corbo, Paw contributors
+// SPDX-License-Identifier: AGPL-3.0-or-later
+
+package importer
+
+import (
+	"encoding/csv"
+	"errors"
+	"io"
+	"strconv"
+	"strings"
+	"time"
+
+	"lucor.dev/paw/internal/paw"
+)
+
+// csvColumns maps the known CSV header names to the login fields.
+// Chrome exports name,url,username,password,note while Firefox exports
+// url,username,password,httpRealm,formActionOrigin,guid,timeCreated,timeLastUsed,timePasswordChanged
+var csvColumns = map[string]string{
+	"name":                "name",
+	"title":               "name",
+	"url":                 "url",
+	"username":            "username",
+	"password":            "password",
+	"note":                "note",
+	"notes":               "note",
+	"totp":                "totp",
+	"timecreated":         "created",
+	"timepasswordchanged": "modified",
+}
+
+func importCSV(r io.Reader) ([]paw.Item, error) {
+	cr := csv.NewReader(r)
+	cr.FieldsPerRecord = -1
+
+	header, err := cr.Read()
+	if err != nil {
+		if errors.Is(err, io.EOF) {
+			return nil, errors.New("missing CSV header")
+		}
+		return nil, err
+	}
+
+	columns := map[string]int{}
+	for i, h := range header {
+		h = strings.ToLower(strings.TrimSpace(strings.TrimPrefix(h, "\ufeff")))
+		if field, ok := csvColumns[h]; ok {
+			columns[field] = i
+		}
+	}
+	if _, ok := columns["password"]; !ok {
+		return nil, errors.New("missing password column in CSV header")
+	}
+
+	items := []paw.Item{}
+	for {
+		record, err := cr.Read()
+		if errors.Is(err, io.EOF) {
+			break
+		}
+		if err != nil {
+			return nil, err
+		}
+
+		value := func(field string) string {
+			i, ok := columns[field]
+			if !ok || i >= len(record) {
+				return ""
+			}
+			return record[i]
+		}
+
+		l := login{
+			name:     value("name"),
+			url:      value("url"),
+			username: value("username"),
+			password: value("password"),
+			note:     value("note"),
+			totp:     value("totp"),
+			created:  csvTime(value("created")),
+			modified: csvTime(value("modified")),
+		}
+		items = append(items, l.item())
+	}
+	return items, nil
+}
+
+// csvTime parses a time expressed in milliseconds since the epoch as exported by Firefox
+func csvTime(v string) time.Time {
+	ms, err := strconv.ParseInt(v, 10, 64)
+	if err != nil || ms <= 0 {
+		return time.Time{}
+	}
+	return time.UnixMilli(ms)
+}
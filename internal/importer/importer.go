@@ -0,0 +1,323 @@
+// SPDX-FileCopyrightText: 2025 Luca Corbo, Paw contributors
+// SPDX-License-Identifier: AGPL-3.0-or-later
+
+// Package importer provides the importers to read items exported by Paw and
+// other password managers
+package importer
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"strings"
+	"time"
+
+	"lucor.dev/paw/internal/paw"
+	"lucor.dev/paw/internal/sshkey"
+)
+
+// Format represents an import format
+type Format string
+
+const (
+	// Paw is the Paw JSON export format
+	Paw Format = "paw"
+	// Bitwarden is the Bitwarden unencrypted JSON export format
+	Bitwarden Format = "bitwarden"
+	// OnePassword is the 1Password 1PUX export format
+	OnePassword Format = "1password"
+	// KeePass is the KeePass 2 XML export format
+	KeePass Format = "keepass"
+	// CSV is the Chrome and Firefox CSV passwords export format
+	CSV Format = "csv"
+)
+
+// Formats lists the supported import formats
+var Formats = []Format{Paw, Bitwarden, OnePassword, KeePass, CSV}
+
+// Label returns the format label used in the UI
+func (f Format) Label() string {
+	switch f {
+	case Paw:
+		return "Paw (JSON)"
+	case Bitwarden:
+		return "Bitwarden (JSON)"
+	case OnePassword:
+		return "1Password (1PUX)"
+	case KeePass:
+		return "KeePass 2 (XML)"
+	case CSV:
+		return "Chrome / Firefox (CSV)"
+	}
+	return "Invalid format"
+}
+
+// FormatFromString returns the import format from a string
+func FormatFromString(v string) (Format, error) {
+	for _, f := range Formats {
+		if string(f) == v {
+			return f, nil
+		}
+	}
+	return "", fmt.Errorf("invalid import format %q", v)
+}
+
+// Import reads the items from r according to the format.
+// Item names are made unique by item type.
+func Import(format Format, r io.Reader) (*paw.Imported, error) {
+	var items []paw.Item
+	var err error
+	switch format {
+	case Paw:
+		imported := &paw.Imported{}
+		err = json.NewDecoder(r).Decode(imported)
+		items = imported.Items
+	case Bitwarden:
+		items, err = importBitwarden(r)
+	case OnePassword:
+		items, err = importOnePassword(r)
+	case KeePass:
+		items, err = importKeePass(r)
+	case CSV:
+		items, err = importCSV(r)
+	default:
+		err = fmt.Errorf("invalid import format %q", format)
+	}
+	if err != nil {
+		return nil, fmt.Errorf("could not import from %s: %w", format, err)
+	}
+	uniqueNames(items)
+	return &paw.Imported{Items: items}, nil
+}
+
+// uniqueNames makes the item names unique by item type appending a counter
+func uniqueNames(items []paw.Item) {
+	names := map[paw.ItemType]map[string]bool{}
+	for _, item := range items {
+		meta := item.GetMetadata()
+		if names[meta.Type] == nil {
+			names[meta.Type] = map[string]bool{}
+		}
+		name := meta.Name
+		for n := 1; names[meta.Type][name]; n++ {
+			name = fmt.Sprintf("%s (%d)", meta.Name, n)
+		}
+		meta.Name = name
+		names[meta.Type][name] = true
+	}
+}
+
+// login holds the data common to the login entries of the supported formats
+type login struct {
+	name     string
+	url      string
+	username string
+	password string
+	note     string
+	totp     string
+	fields   paw.CustomFields
+	created  time.Time
+	modified time.Time
+}
+
+func (l login) item() *paw.Login {
+	item := paw.NewLogin()
+	item.Name = l.name
+	item.Username = l.username
+	item.Password.Value = l.password
+	item.Password.Mode = paw.CustomPassword
+	item.Note.Value = l.note
+	item.Fields = l.fields
+
+	if l.url != "" && item.URL.Set(l.url) == nil {
+		item.Autofill.URL = item.URL.URL()
+		item.Autofill.TLDPlusOne = item.URL.TLDPlusOne()
+	}
+	if item.Name == "" {
+		item.Name = item.URL.URL().Hostname()
+	}
+	if item.Name == "" {
+		item.Name = "Untitled login"
+	}
+	if l.totp != "" {
+		if totp, err := parseTOTP(l.totp); err == nil {
+			item.TOTP = totp
+		} else {
+			addCustomField(&item.Fields, "TOTP", paw.TextCustomField, l.totp)
+		}
+	}
+	item.Metadata.Subtitle = item.Subtitle()
+	setTimes(item.Metadata, l.created, l.modified)
+	return item
+}
+
+func makePassword(name string, password string, note string, fields paw.CustomFields) *paw.Password {
+	item := paw.NewPassword()
+	item.Name = name
+	if item.Name == "" {
+		item.Name = "Untitled password"
+	}
+	item.Value = password
+	item.Mode = paw.CustomPassword
+	item.Note.Value = note
+	item.Fields = fields
+	return item
+}
+
+func makeNote(name string, note string, fields paw.CustomFields) *paw.Note {
+	item := paw.NewNote()
+	item.Name = name
+	if item.Name == "" {
+		item.Name = "Untitled note"
+	}
+	item.Value = note
+	item.Fields = fields
+	return item
+}
+
+func makeSSHKey(name string, privateKey string, publicKey string, fingerprint string, note string, fields paw.CustomFields) *paw.SSHKey {
+	item := paw.NewSSHKey()
+	item.Name = name
+	if item.Name == "" {
+		item.Name = "Untitled SSH key"
+	}
+	item.PrivateKey = privateKey
+	item.PublicKey = publicKey
+	item.Fingerprint = fingerprint
+	item.Note.Value = note
+	item.Fields = fields
+
+	// normalize the key if supported
+	if sk, err := sshkey.ParseKey([]byte(privateKey)); err == nil {
+		item.PrivateKey = string(sk.MarshalPrivateKey())
+		item.PublicKey = string(sk.MarshalPublicKey())
+		item.Fingerprint = sk.Fingerprint()
+	}
+	item.Metadata.Subtitle = item.Subtitle()
+	return item
+}
+
+// makeFieldsItem returns an item composed only of plain fields setting the
+// values by field name. Values that are not valid for the field, or whose name
+// is not a field of the item, are added as custom fields using the label as name.
+func makeFieldsItem(itemType paw.ItemType, name string, values []fieldValue, fields paw.CustomFields) paw.Item {
+	item, err := paw.NewItem(name, itemType)
+	if err != nil {
+		panic(err)
+	}
+	v := item.(paw.FieldsItem)
+	if name == "" {
+		item.GetMetadata().Name = "Untitled " + strings.ToLower(itemType.Label())
+	}
+	set := map[string]bool{}
+	for _, fv := range values {
+		if fv.value == "" {
+			continue
+		}
+		f, ok := paw.ItemFieldByName(v, fv.name)
+		if ok && !set[fv.name] {
+			previous := *f.Value
+			*f.Value = fv.value
+			if paw.NormalizeItemFields(v) == nil {
+				set[fv.name] = true
+				continue
+			}
+			*f.Value = previous
+		}
+		fieldType := paw.TextCustomField
+		if fv.secret || (ok && f.Type.IsSecret()) {
+			fieldType = paw.HiddenCustomField
+		}
+		addCustomField(&fields, fv.label, fieldType, fv.value)
+	}
+	// the values left are valid, normalize to update the subtitle
+	_ = paw.NormalizeItemFields(v)
+	*item.(paw.CustomFielder).GetCustomFields() = fields
+	return item
+}
+
+// fieldValue is a value to import into an item composed only of plain fields
+type fieldValue struct {
+	// name is the item field name
+	name string
+	// label is the custom field name used when the value cannot be set as item field
+	label string
+	// secret reports whether the value should be hidden if imported as custom field
+	secret bool
+	value  string
+}
+
+// setTimes sets the creation and modification times, if defined
+func setTimes(meta *paw.Metadata, created time.Time, modified time.Time) {
+	if !created.IsZero() {
+		meta.Created = created.UTC()
+	}
+	if !modified.IsZero() {
+		meta.Modified = modified.UTC()
+	}
+}
+
+// addCustomField adds a custom field skipping the empty values.
+// The text type is used if the value is not valid for the field type and
+// a numeric suffix is added to the name if a field with the same name exists.
+func addCustomField(fields *paw.CustomFields, name string, fieldType paw.CustomFieldType, value string) {
+	if value == "" {
+		return
+	}
+	name = strings.TrimSpace(name)
+	if name == "" {
+		name = "Untitled field"
+	}
+	unique := name
+	for n := 1; ; n++ {
+		if _, ok := fields.Get(unique); !ok {
+			break
+		}
+		unique = fmt.Sprintf("%s (%d)", name, n)
+	}
+	f, err := paw.NewCustomField(unique, fieldType, value)
+	if err != nil {
+		f = &paw.CustomField{Name: unique, Type: paw.TextCustomField, Value: value}
+		if fieldType.IsSecret() {
+			f.Type = paw.HiddenCustomField
+		}
+	}
+	*fields = append(*fields, f)
+}
+
+// appendField appends a "label: value" line to the text
+func appendField(text string, label string, value string) string {
+	if value == "" {
+		return text
+	}
+	line := value
+	if label != "" {
+		line = label + ": " + value
+	}
+	if text == "" {
+		return line
+	}
+	return text + "\n" + line
+}
+
+// parseTOTP parses a TOTP secret from an otpauth URI or a base32 encoded secret
+func parseTOTP(v string) (*paw.TOTP, error) {
+	v = strings.TrimSpace(v)
+	if strings.HasPrefix(v, paw.OTPAuthScheme+"://") {
+		o, err := paw.ParseOTPAuth(v)
+		if err != nil {
+			return nil, err
+		}
+		return o.TOTP, nil
+	}
+	if strings.Contains(v, "://") {
+		return nil, fmt.Errorf("unsupported TOTP format")
+	}
+	secret, err := paw.NormalizeTOTPSecret(v)
+	if err != nil {
+		return nil, err
+	}
+	totp := paw.NewDefaultTOTP()
+	totp.Secret = secret
+	return totp, nil
+}
@@ -0,0 +1,336 @@
+// SPDX-FileCopyrightText: 2025 Luca Corbo, Paw contributors
+// SPDX-License-Identifier: AGPL-3.0-or-later
+
+package importer
+
+import (
+	"archive/zip"
+	"bytes"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"lucor.dev/paw/internal/paw"
+)
+
+func TestImportBitwarden(t *testing.T) {
+	data := `{
+  "encrypted": false,
+  "items": [
+    {
+      "type": 1,
+      "name": "example",
+      "notes": "my note",
+      "creationDate": "2024-01-02T10:00:00.000Z",
+      "revisionDate": "2024-02-03T10:00:00.000Z",
+      "fields": [{"name": "pin", "value": "1234", "type": 1}],
+      "login": {
+        "username": "john",
+        "password": "secret",
+        "totp": "otpauth://totp/example:john?secret=JBSWY3DPEHPK3PXP&digits=8&period=60&algorithm=SHA256",
+        "uris": [{"uri": "https://www.example.com/login"}, {"uri": "https://example.org"}]
+      }
+    },
+    {"type": 2, "name": "note", "notes": "secure note", "secureNote": {"type": 0}},
+    {"type": 3, "name": "card", "notes": "main card", "card": {"brand": "Visa", "number": "4111 1111 1111 1111", "expMonth": "1", "expYear": "2030", "code": "12345"}},
+    {"type": 5, "name": "key", "sshKey": {"privateKey": "private", "publicKey": "public", "keyFingerprint": "fingerprint"}},
+    {"type": 1, "name": "example", "login": {"password": "other"}},
+    {"type": 4, "name": "me", "identity": {"firstName": "John", "lastName": "Doe", "address1": "Main St 1", "address2": "Apt 2", "email": "john@example.com", "ssn": "123-45-6789"}}
+  ]
+}`
+	imported, err := Import(Bitwarden, strings.NewReader(data))
+	require.NoError(t, err)
+	require.Len(t, imported.Items, 6)
+
+	login := imported.Items[0].(*paw.Login)
+	assert.Equal(t, "example", login.Name)
+	assert.Equal(t, "john", login.Username)
+	assert.Equal(t, "john", login.Metadata.Subtitle)
+	assert.Equal(t, "secret", login.Password.Value)
+	assert.Equal(t, "my note", login.Note.Value)
+	assert.Equal(t, paw.CustomFields{
+		{Name: "pin", Type: paw.HiddenCustomField, Value: "1234"},
+		{Name: "URL", Type: paw.URLCustomField, Value: "https://example.org"},
+	}, login.Fields)
+	assert.Equal(t, "https://www.example.com/login", login.URL.String())
+	assert.Equal(t, "example.com", login.Autofill.TLDPlusOne)
+	assert.Equal(t, &paw.TOTP{Secret: "JBSWY3DPEHPK3PXP", Digits: 8, Interval: 60, Hash: paw.SHA256}, login.TOTP)
+	assert.Equal(t, time.Date(2024, 1, 2, 10, 0, 0, 0, time.UTC), login.Created)
+	assert.Equal(t, time.Date(2024, 2, 3, 10, 0, 0, 0, time.UTC), login.Modified)
+	assert.NotEmpty(t, login.UUID)
+
+	note := imported.Items[1].(*paw.Note)
+	assert.Equal(t, "secure note", note.Value)
+
+	card := imported.Items[2].(*paw.Card)
+	assert.Equal(t, "Visa", card.Brand)
+	assert.Equal(t, "4111111111111111", card.Number)
+	assert.Equal(t, "01/2030", card.Expiry)
+	assert.Equal(t, "main card", card.Notes)
+	assert.Equal(t, "Visa •••• 1111", card.Metadata.Subtitle)
+	// the invalid CVV is kept as custom field
+	assert.Equal(t, "", card.CVV)
+	assert.Equal(t, paw.CustomFields{{Name: "CVV", Type: paw.HiddenCustomField, Value: "12345"}}, card.Fields)
+
+	key := imported.Items[3].(*paw.SSHKey)
+	assert.Equal(t, "private", key.PrivateKey)
+	assert.Equal(t, "fingerprint", key.Fingerprint)
+
+	duplicated := imported.Items[4].(*paw.Login)
+	assert.Equal(t, "example (1)", duplicated.Name)
+
+	identity := imported.Items[5].(*paw.Identity)
+	assert.Equal(t, "John", identity.FirstName)
+	assert.Equal(t, "Doe", identity.LastName)
+	assert.Equal(t, "Main St 1\nApt 2", identity.Address)
+	assert.Equal(t, "john@example.com", identity.Email)
+	assert.Equal(t, paw.CustomFields{{Name: "Social security number", Type: paw.HiddenCustomField, Value: "123-45-6789"}}, identity.Fields)
+
+	_, err = Import(Bitwarden, strings.NewReader(`{"encrypted": true}`))
+	assert.Error(t, err)
+}
+
+func TestImportOnePassword(t *testing.T) {
+	data := `{
+  "accounts": [{
+    "vaults": [{
+      "items": [
+        {
+          "categoryUuid": "001",
+          "createdAt": 1704189600,
+          "updatedAt": 1706954400,
+          "overview": {"title": "example", "url": "https://example.com"},
+          "details": {
+            "loginFields": [
+              {"value": "john", "designation": "username"},
+              {"value": "secret", "designation": "password"}
+            ],
+            "notesPlain": "my note",
+            "sections": [{
+              "fields": [
+                {"title": "one-time password", "value": {"totp": "JBSWY3DPEHPK3PXP"}},
+                {"title": "email", "value": {"email": {"email_address": "john@example.com"}}},
+                {"title": "pin", "value": {"concealed": "1234"}}
+              ]
+            }]
+          }
+        },
+        {"categoryUuid": "005", "overview": {"title": "wifi"}, "details": {"password": "p4ss"}},
+        {"categoryUuid": "003", "overview": {"title": "note"}, "details": {"notesPlain": "secure note"}},
+        {
+          "categoryUuid": "114",
+          "overview": {"title": "key"},
+          "details": {"sections": [{"fields": [{"title": "private key", "value": {"sshKey": {"privateKey": "private", "metadata": {"publicKey": "public", "fingerprint": "fingerprint"}}}}]}]}
+        },
+        {
+          "categoryUuid": "002",
+          "overview": {"title": "card"},
+          "details": {"sections": [{"fields": [
+            {"id": "ccnum", "title": "number", "value": {"creditCardNumber": "4111111111111111"}},
+            {"id": "expiry", "title": "expiry date", "value": {"monthYear": 203001}},
+            {"id": "cvv", "title": "verification number", "value": {"concealed": "123"}},
+            {"id": "bank", "title": "issuing bank", "value": {"string": "ACME"}}
+          ]}]}
+        },
+        {
+          "categoryUuid": "109",
+          "overview": {"title": "router"},
+          "details": {"sections": [{"fields": [
+            {"id": "network_name", "title": "network name", "value": {"string": "home"}},
+            {"id": "wireless_password", "title": "wireless network password", "value": {"concealed": "p4ss"}},
+            {"id": "wireless_security", "title": "wireless security", "value": {"menu": "wpa2p"}}
+          ]}]}
+        },
+        {
+          "categoryUuid": "109",
+          "overview": {"title": "office"},
+          "details": {"sections": [{"fields": [
+            {"id": "wireless_security", "title": "wireless security", "value": {"menu": "wpa3"}}
+          ]}]}
+        }
+      ]
+    }]
+  }]
+}`
+	buf := &bytes.Buffer{}
+	zw := zip.NewWriter(buf)
+	w, err := zw.Create("export.data")
+	require.NoError(t, err)
+	_, err = w.Write([]byte(data))
+	require.NoError(t, err)
+	require.NoError(t, zw.Close())
+
+	imported, err := Import(OnePassword, buf)
+	require.NoError(t, err)
+	require.Len(t, imported.Items, 7)
+
+	login := imported.Items[0].(*paw.Login)
+	assert.Equal(t, "example", login.Name)
+	assert.Equal(t, "john", login.Username)
+	assert.Equal(t, "secret", login.Password.Value)
+	assert.Equal(t, "my note", login.Note.Value)
+	assert.Equal(t, paw.CustomFields{
+		{Name: "email", Type: paw.EmailCustomField, Value: "john@example.com"},
+		{Name: "pin", Type: paw.HiddenCustomField, Value: "1234"},
+	}, login.Fields)
+	assert.Equal(t, "https://example.com", login.URL.String())
+	assert.Equal(t, "JBSWY3DPEHPK3PXP", login.TOTP.Secret)
+	assert.Equal(t, time.Unix(1704189600, 0).UTC(), login.Created)
+
+	password := imported.Items[1].(*paw.Password)
+	assert.Equal(t, "p4ss", password.Value)
+
+	note := imported.Items[2].(*paw.Note)
+	assert.Equal(t, "secure note", note.Value)
+
+	key := imported.Items[3].(*paw.SSHKey)
+	assert.Equal(t, "public", key.PublicKey)
+
+	card := imported.Items[4].(*paw.Card)
+	assert.Equal(t, "4111111111111111", card.Number)
+	assert.Equal(t, "01/2030", card.Expiry)
+	assert.Equal(t, "123", card.CVV)
+	assert.Equal(t, paw.CustomFields{{Name: "issuing bank", Type: paw.TextCustomField, Value: "ACME"}}, card.Fields)
+
+	wifi := imported.Items[5].(*paw.WiFi)
+	assert.Equal(t, "home", wifi.SSID)
+	assert.Equal(t, "p4ss", wifi.Password)
+	// the unknown security type is kept as custom field
+	assert.Equal(t, paw.CustomFields{{Name: "wireless security", Type: paw.TextCustomField, Value: "wpa2p"}}, wifi.Fields)
+
+	office := imported.Items[6].(*paw.WiFi)
+	assert.Equal(t, "WPA3", office.Security)
+	assert.Empty(t, office.Fields)
+}
+
+func TestImportKeePass(t *testing.T) {
+	data := `<?xml version="1.0" encoding="utf-8" standalone="yes"?>
+<KeePassFile>
+  <Meta>
+    <RecycleBinEnabled>True</RecycleBinEnabled>
+    <RecycleBinUUID>YmluYmluYmluYmluYmluYg==</RecycleBinUUID>
+  </Meta>
+  <Root>
+    <Group>
+      <UUID>cm9vdHJvb3Ryb290cm9vdA==</UUID>
+      <Name>Root</Name>
+      <Entry>
+        <String><Key>Title</Key><Value>example</Value></String>
+        <String><Key>UserName</Key><Value>john</Value></String>
+        <String><Key>Password</Key><Value ProtectInMemory="True">secret</Value></String>
+        <String><Key>URL</Key><Value>https://example.com</Value></String>
+        <String><Key>Notes</Key><Value>my note</Value></String>
+        <String><Key>pin</Key><Value ProtectInMemory="True">1234</Value></String>
+        <String><Key>account</Key><Value>42</Value></String>
+        <String><Key>TimeOtp-Secret-Base32</Key><Value>JBSWY3DPEHPK3PXP</Value></String>
+        <String><Key>TimeOtp-Algorithm</Key><Value>HMAC-SHA-512</Value></String>
+        <Times>
+          <CreationTime>2024-01-02T10:00:00Z</CreationTime>
+          <LastModificationTime>2024-02-03T10:00:00Z</LastModificationTime>
+        </Times>
+        <History>
+          <Entry>
+            <String><Key>Title</Key><Value>old</Value></String>
+          </Entry>
+        </History>
+      </Entry>
+      <Group>
+        <UUID>c3Vic3Vic3Vic3Vic3ViYg==</UUID>
+        <Name>Sub</Name>
+        <Entry>
+          <String><Key>Title</Key><Value>wifi</Value></String>
+          <String><Key>Password</Key><Value>p4ss</Value></String>
+        </Entry>
+      </Group>
+      <Group>
+        <UUID>YmluYmluYmluYmluYmluYg==</UUID>
+        <Name>Recycle Bin</Name>
+        <Entry>
+          <String><Key>Title</Key><Value>deleted</Value></String>
+        </Entry>
+      </Group>
+    </Group>
+  </Root>
+</KeePassFile>`
+
+	imported, err := Import(KeePass, strings.NewReader(data))
+	require.NoError(t, err)
+	require.Len(t, imported.Items, 2)
+
+	login := imported.Items[0].(*paw.Login)
+	assert.Equal(t, "example", login.Name)
+	assert.Equal(t, "john", login.Username)
+	assert.Equal(t, "secret", login.Password.Value)
+	assert.Equal(t, "my note", login.Note.Value)
+	assert.Equal(t, paw.CustomFields{
+		{Name: "pin", Type: paw.HiddenCustomField, Value: "1234"},
+		{Name: "account", Type: paw.TextCustomField, Value: "42"},
+	}, login.Fields)
+	assert.Equal(t, &paw.TOTP{Secret: "JBSWY3DPEHPK3PXP", Digits: 6, Interval: 30, Hash: paw.SHA512}, login.TOTP)
+	assert.Equal(t, time.Date(2024, 2, 3, 10, 0, 0, 0, time.UTC), login.Modified)
+
+	password := imported.Items[1].(*paw.Password)
+	assert.Equal(t, "wifi", password.Name)
+	assert.Equal(t, "p4ss", password.Value)
+}
+
+func TestImportCSV(t *testing.T) {
+	tests := map[string]struct {
+		data string
+		want []*paw.Login
+	}{
+		"chrome": {
+			data: "name,url,username,password,note\nexample.com,https://example.com/,john,secret,my note\n",
+			want: []*paw.Login{
+				{Metadata: &paw.Metadata{Name: "example.com"}, Username: "john", Password: &paw.Password{Value: "secret"}, Note: &paw.Note{Value: "my note"}},
+			},
+		},
+		"firefox": {
+			data: "\"url\",\"username\",\"password\",\"httpRealm\",\"formActionOrigin\",\"guid\",\"timeCreated\",\"timeLastUsed\",\"timePasswordChanged\"\n" +
+				"\"https://example.com\",\"john\",\"secret\",,\"https://example.com\",\"{guid}\",\"1704189600000\",\"1704189600000\",\"1706954400000\"\n",
+			want: []*paw.Login{
+				{Metadata: &paw.Metadata{Name: "example.com", Created: time.Unix(1704189600, 0).UTC(), Modified: time.Unix(1706954400, 0).UTC()}, Username: "john", Password: &paw.Password{Value: "secret"}, Note: &paw.Note{}},
+			},
+		},
+	}
+
+	for name, tt := range tests {
+		t.Run(name, func(t *testing.T) {
+			imported, err := Import(CSV, strings.NewReader(tt.data))
+			require.NoError(t, err)
+			require.Len(t, imported.Items, len(tt.want))
+			for i, want := range tt.want {
+				got := imported.Items[i].(*paw.Login)
+				assert.Equal(t, want.Name, got.Name)
+				assert.Equal(t, want.Username, got.Username)
+				assert.Equal(t, want.Password.Value, got.Password.Value)
+				assert.Equal(t, want.Note.Value, got.Note.Value)
+				assert.Equal(t, "example.com", got.Autofill.TLDPlusOne)
+				if !want.Created.IsZero() {
+					assert.Equal(t, want.Created, got.Created)
+					assert.Equal(t, want.Modified, got.Modified)
+				}
+			}
+		})
+	}
+
+	_, err := Import(CSV, strings.NewReader("url,username\nhttps://example.com,john\n"))
+	assert.Error(t, err)
+}
+
+func TestParseTOTP(t *testing.T) {
+	totp, err := parseTOTP("jbsw y3dp ehpk 3pxp")
+	require.NoError(t, err)
+	assert.Equal(t, "JBSWY3DPEHPK3PXP", totp.Secret)
+
+	totp, err = parseTOTP("otpauth://hotp/example?secret=JBSWY3DPEHPK3PXP&counter=1")
+	require.NoError(t, err)
+	assert.True(t, totp.IsHOTP())
+	assert.Equal(t, uint64(1), totp.Counter)
+
+	_, err = parseTOTP("steam://JBSWY3DPEHPK3PXP")
+	assert.Error(t, err)
+}
@@ -0,0 +1,168 @@
+// SPDX-FileCopyrightText: 2025 Luca Corbo, Paw contributors
+// SPDX-License-Identifier: AGPL-3.0-or-later
+
+package importer
+
+import (
+	"encoding/xml"
+	"fmt"
+	"io"
+	"net/url"
+	"strings"
+	"time"
+
+	"lucor.dev/paw/internal/paw"
+)
+
+// KeePass standard entry fields
+const (
+	keepassTitle    = "Title"
+	keepassUserName = "UserName"
+	keepassPassword = "Password"
+	keepassURL      = "URL"
+	keepassNotes    = "Notes"
+	// keepassOTP is the field used by KeePassXC to store the otpauth URI
+	keepassOTP = "otp"
+	// keepassTimeOTP is the prefix for the fields used by KeePass to store the TOTP settings
+	keepassTimeOTP = "TimeOtp-"
+)
+
+type keepassFile struct {
+	Meta struct {
+		RecycleBinEnabled string `xml:"RecycleBinEnabled"`
+		RecycleBinUUID    string `xml:"RecycleBinUUID"`
+	} `xml:"Meta"`
+	Root struct {
+		Groups []keepassGroup `xml:"Group"`
+	} `xml:"Root"`
+}
+
+type keepassGroup struct {
+	UUID    string         `xml:"UUID"`
+	Name    string         `xml:"Name"`
+	Entries []keepassEntry `xml:"Entry"`
+	Groups  []keepassGroup `xml:"Group"`
+}
+
+type keepassEntry struct {
+	Strings []struct {
+		Key   string `xml:"Key"`
+		Value struct {
+			Text      string `xml:",chardata"`
+			Protected string `xml:"ProtectInMemory,attr"`
+		} `xml:"Value"`
+	} `xml:"String"`
+	Times struct {
+		CreationTime         string `xml:"CreationTime"`
+		LastModificationTime string `xml:"LastModificationTime"`
+	} `xml:"Times"`
+}
+
+func importKeePass(r io.Reader) ([]paw.Item, error) {
+	data := &keepassFile{}
+	err := xml.NewDecoder(r).Decode(data)
+	if err != nil {
+		return nil, err
+	}
+
+	recycleBin := ""
+	if strings.EqualFold(data.Meta.RecycleBinEnabled, "true") {
+		recycleBin = data.Meta.RecycleBinUUID
+	}
+
+	items := []paw.Item{}
+	var walk func(groups []keepassGroup)
+	walk = func(groups []keepassGroup) {
+		for _, g := range groups {
+			if recycleBin != "" && g.UUID == recycleBin {
+				continue
+			}
+			for _, e := range g.Entries {
+				items = append(items, keepassEntryToPaw(e))
+			}
+			walk(g.Groups)
+		}
+	}
+	walk(data.Root.Groups)
+	return items, nil
+}
+
+func keepassEntryToPaw(e keepassEntry) paw.Item {
+	fields := map[string]string{}
+	customFields := paw.CustomFields{}
+	for _, s := range e.Strings {
+		fields[s.Key] = s.Value.Text
+	}
+	for _, s := range e.Strings {
+		switch s.Key {
+		case keepassTitle, keepassUserName, keepassPassword, keepassURL, keepassNotes, keepassOTP:
+			continue
+		}
+		if strings.HasPrefix(s.Key, keepassTimeOTP) {
+			continue
+		}
+		fieldType := paw.TextCustomField
+		if strings.EqualFold(s.Value.Protected, "true") {
+			fieldType = paw.HiddenCustomField
+		}
+		addCustomField(&customFields, s.Key, fieldType, s.Value.Text)
+	}
+	note := fields[keepassNotes]
+
+	totp := fields[keepassOTP]
+	if secret := fields[keepassTimeOTP+"Secret-Base32"]; totp == "" && secret != "" {
+		totp = keepassTimeOTPURI(secret, fields)
+	}
+
+	created := keepassTime(e.Times.CreationTime)
+	modified := keepassTime(e.Times.LastModificationTime)
+
+	var item paw.Item
+	switch {
+	case fields[keepassURL] != "" || fields[keepassUserName] != "" || totp != "":
+		l := login{
+			name:     fields[keepassTitle],
+			url:      fields[keepassURL],
+			username: fields[keepassUserName],
+			password: fields[keepassPassword],
+			note:     note,
+			totp:     totp,
+			fields:   customFields,
+		}
+		item = l.item()
+	case fields[keepassPassword] != "":
+		item = makePassword(fields[keepassTitle], fields[keepassPassword], note, customFields)
+	default:
+		item = makeNote(fields[keepassTitle], note, customFields)
+	}
+	setTimes(item.GetMetadata(), created, modified)
+	return item
+}
+
+// keepassTimeOTPURI returns the otpauth URI from the KeePass TimeOtp fields
+func keepassTimeOTPURI(secret string, fields map[string]string) string {
+	q := url.Values{}
+	q.Set("secret", secret)
+	switch fields[keepassTimeOTP+"Algorithm"] {
+	case "HMAC-SHA-256":
+		q.Set("algorithm", "SHA256")
+	case "HMAC-SHA-512":
+		q.Set("algorithm", "SHA512")
+	}
+	if v := fields[keepassTimeOTP+"Length"]; v != "" {
+		q.Set("digits", v)
+	}
+	if v := fields[keepassTimeOTP+"Period"]; v != "" {
+		q.Set("period", v)
+	}
+	return fmt.Sprintf("otpauth://totp/?%s", q.Encode())
+}
+
+// keepassTime parses a time from the XML export, zero time is returned if invalid
+func keepassTime(v string) time.Time {
+	t, err := time.Parse(time.RFC3339, v)
+	if err != nil {
+		return time.Time{}
+	}
+	return t
+}
@@ -0,0 +1,306 @@
+// SPDX-FileCopyrightText: 2025 Luca Corbo, Paw contributors
+// SPDX-License-Identifier: AGPL-3.0-or-later
+
+package importer
+
+import (
+	"archive/zip"
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"time"
+
+	"lucor.dev/paw/internal/paw"
+)
+
+// 1Password item categories
+const (
+	onePasswordLogin         = "001"
+	onePasswordCard          = "002"
+	onePasswordNote          = "003"
+	onePasswordIdentity      = "004"
+	onePasswordPassword      = "005"
+	onePasswordLicense       = "100"
+	onePasswordRouter        = "109"
+	onePasswordAPICredential = "112"
+	onePasswordSSHKey        = "114"
+)
+
+// onePasswordFieldsCategory describes a 1Password category imported as an item composed only of plain fields
+type onePasswordFieldsCategory struct {
+	itemType paw.ItemType
+	// names maps the 1Password field IDs to the item field names
+	names map[string]string
+}
+
+// onePasswordFieldsCategories holds the 1Password categories imported as items composed only of plain fields
+var onePasswordFieldsCategories = map[string]onePasswordFieldsCategory{
+	onePasswordCard: {paw.CardItemType, map[string]string{
+		"cardholder": "cardholder", "type": "brand", "ccnum": "number", "expiry": "expiry", "cvv": "cvv", "pin": "pin",
+	}},
+	onePasswordIdentity: {paw.IdentityItemType, map[string]string{
+		"firstname": "first_name", "lastname": "last_name", "birthdate": "birthday", "email": "email", "defphone": "phone", "company": "company",
+	}},
+	onePasswordLicense: {paw.LicenseItemType, map[string]string{
+		"product_version": "version", "reg_code": "license_key", "reg_name": "licensed_to", "reg_email": "email", "order_date": "purchased", "expiry_date": "expires",
+	}},
+	onePasswordRouter: {paw.WiFiItemType, map[string]string{
+		"network_name": "ssid", "wireless_password": "password", "wireless_security": "security",
+	}},
+	onePasswordAPICredential: {paw.APICredentialItemType, map[string]string{
+		"username": "key", "credential": "secret", "hostname": "endpoint", "expires": "expires",
+	}},
+}
+
+// onePasswordDataFile is the file into the 1PUX archive containing the items
+const onePasswordDataFile = "export.data"
+
+type onePasswordExport struct {
+	Accounts []struct {
+		Vaults []struct {
+			Items []onePasswordItem `json:"items"`
+		} `json:"vaults"`
+	} `json:"accounts"`
+}
+
+type onePasswordItem struct {
+	CategoryUUID string `json:"categoryUuid"`
+	State        string `json:"state"`
+	CreatedAt    int64  `json:"createdAt"`
+	UpdatedAt    int64  `json:"updatedAt"`
+	Overview     struct {
+		Title string `json:"title"`
+		URL   string `json:"url"`
+		URLs  []struct {
+			URL string `json:"url"`
+		} `json:"urls"`
+	} `json:"overview"`
+	Details struct {
+		LoginFields []struct {
+			Value       string `json:"value"`
+			Designation string `json:"designation"`
+		} `json:"loginFields"`
+		NotesPlain string `json:"notesPlain"`
+		Password   string `json:"password"`
+		Sections   []struct {
+			Title  string             `json:"title"`
+			Fields []onePasswordField `json:"fields"`
+		} `json:"sections"`
+	} `json:"details"`
+}
+
+type onePasswordField struct {
+	ID    string                     `json:"id"`
+	Title string                     `json:"title"`
+	Value map[string]json.RawMessage `json:"value"`
+}
+
+type onePasswordSSHKeyValue struct {
+	PrivateKey string `json:"privateKey"`
+	Metadata   struct {
+		PublicKey   string `json:"publicKey"`
+		Fingerprint string `json:"fingerprint"`
+	} `json:"metadata"`
+}
+
+func importOnePassword(r io.Reader) ([]paw.Item, error) {
+	b, err := io.ReadAll(r)
+	if err != nil {
+		return nil, err
+	}
+	zr, err := zip.NewReader(bytes.NewReader(b), int64(len(b)))
+	if err != nil {
+		return nil, fmt.Errorf("invalid 1PUX archive: %w", err)
+	}
+	f, err := zr.Open(onePasswordDataFile)
+	if err != nil {
+		return nil, fmt.Errorf("invalid 1PUX archive: %w", err)
+	}
+	defer f.Close()
+
+	data := &onePasswordExport{}
+	err = json.NewDecoder(f).Decode(data)
+	if err != nil {
+		return nil, err
+	}
+
+	items := []paw.Item{}
+	for _, account := range data.Accounts {
+		for _, vault := range account.Vaults {
+			for _, v := range vault.Items {
+				item, err := onePasswordItemToPaw(v)
+				if err != nil {
+					return nil, err
+				}
+				items = append(items, item)
+			}
+		}
+	}
+	return items, nil
+}
+
+func onePasswordItemToPaw(v onePasswordItem) (paw.Item, error) {
+	created := unixTime(v.CreatedAt)
+	modified := unixTime(v.UpdatedAt)
+
+	note := v.Details.NotesPlain
+	fields := paw.CustomFields{}
+	values := []fieldValue{}
+	category, isFieldsCategory := onePasswordFieldsCategories[v.CategoryUUID]
+	var totp string
+	var sshKey *onePasswordSSHKeyValue
+	for _, section := range v.Details.Sections {
+		for _, field := range section.Fields {
+			if raw, ok := field.Value["address"]; ok && isFieldsCategory {
+				values = append(values, onePasswordAddressValues(raw)...)
+				continue
+			}
+			if name, ok := category.names[field.ID]; ok {
+				fieldType := onePasswordFieldType(field)
+				values = append(values, fieldValue{name: name, label: field.Title, secret: fieldType.IsSecret(), value: onePasswordFieldValue(field)})
+				continue
+			}
+			if raw, ok := field.Value["totp"]; ok && totp == "" {
+				_ = json.Unmarshal(raw, &totp)
+				continue
+			}
+			if raw, ok := field.Value["sshKey"]; ok && sshKey == nil {
+				sshKey = &onePasswordSSHKeyValue{}
+				err := json.Unmarshal(raw, sshKey)
+				if err != nil {
+					return nil, fmt.Errorf("invalid SSH key for item %q: %w", v.Overview.Title, err)
+				}
+				continue
+			}
+			addCustomField(&fields, field.Title, onePasswordFieldType(field), onePasswordFieldValue(field))
+		}
+	}
+
+	var item paw.Item
+	switch v.CategoryUUID {
+	case onePasswordLogin:
+		l := login{
+			name:     v.Overview.Title,
+			url:      v.Overview.URL,
+			note:     note,
+			totp:     totp,
+			fields:   fields,
+			created:  created,
+			modified: modified,
+		}
+		for _, u := range v.Overview.URLs {
+			if u.URL == l.url {
+				continue
+			}
+			if l.url == "" {
+				l.url = u.URL
+				continue
+			}
+			addCustomField(&l.fields, "URL", paw.URLCustomField, u.URL)
+		}
+		for _, f := range v.Details.LoginFields {
+			switch f.Designation {
+			case "username":
+				l.username = f.Value
+			case "password":
+				l.password = f.Value
+			}
+		}
+		item = l.item()
+	case onePasswordPassword:
+		item = makePassword(v.Overview.Title, v.Details.Password, note, fields)
+	case onePasswordSSHKey:
+		if sshKey == nil {
+			return nil, errors.New("SSH key data missing for item " + v.Overview.Title)
+		}
+		item = makeSSHKey(v.Overview.Title, sshKey.PrivateKey, sshKey.Metadata.PublicKey, sshKey.Metadata.Fingerprint, note, fields)
+	default:
+		addCustomField(&fields, "TOTP", paw.TOTPCustomField, totp)
+		if isFieldsCategory {
+			values = append(values, fieldValue{name: "notes", label: "Notes", value: note})
+			item = makeFieldsItem(category.itemType, v.Overview.Title, values, fields)
+			break
+		}
+		// secure notes and any other category are imported as note
+		item = makeNote(v.Overview.Title, note, fields)
+	}
+	setTimes(item.GetMetadata(), created, modified)
+	return item, nil
+}
+
+// onePasswordFieldType returns the custom field type for a section field value
+func onePasswordFieldType(field onePasswordField) paw.CustomFieldType {
+	for kind := range field.Value {
+		switch kind {
+		case "concealed":
+			return paw.HiddenCustomField
+		case "email":
+			return paw.EmailCustomField
+		case "url":
+			return paw.URLCustomField
+		case "date":
+			return paw.DateCustomField
+		}
+	}
+	return paw.TextCustomField
+}
+
+// onePasswordFieldValue returns the text representation of a section field value
+func onePasswordFieldValue(field onePasswordField) string {
+	for kind, raw := range field.Value {
+		switch kind {
+		case "email":
+			var email struct {
+				Address string `json:"email_address"`
+			}
+			if json.Unmarshal(raw, &email) == nil {
+				return email.Address
+			}
+		case "date", "monthYear":
+			var n int64
+			if json.Unmarshal(raw, &n) == nil && kind == "date" {
+				return time.Unix(n, 0).UTC().Format("2006-01-02")
+			}
+			if n > 0 {
+				return fmt.Sprintf("%d/%d", n%100, n/100)
+			}
+		}
+		var s string
+		if json.Unmarshal(raw, &s) == nil {
+			return s
+		}
+		return string(raw)
+	}
+	return ""
+}
+
+// onePasswordAddressValues returns the identity values of an address field
+func onePasswordAddressValues(raw json.RawMessage) []fieldValue {
+	var address struct {
+		Street  string `json:"street"`
+		City    string `json:"city"`
+		State   string `json:"state"`
+		Zip     string `json:"zip"`
+		Country string `json:"country"`
+	}
+	if json.Unmarshal(raw, &address) != nil {
+		return nil
+	}
+	return []fieldValue{
+		{name: "address", label: "Address", value: address.Street},
+		{name: "city", label: "City", value: address.City},
+		{name: "region", label: "Region", value: address.State},
+		{name: "postal_code", label: "Postal code", value: address.Zip},
+		{name: "country", label: "Country", value: address.Country},
+	}
+}
+
+// unixTime returns the time from the seconds since the epoch, zero time is returned if not set
+func unixTime(sec int64) time.Time {
+	if sec <= 0 {
+		return time.Time{}
+	}
+	return time.Unix(sec, 0)
+}
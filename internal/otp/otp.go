@@ -11,6 +11,7 @@ import (
 	"encoding/base32"
 	"encoding/binary"
 	"errors"
+	"fmt"
 	"hash"
 	"math"
 	"strconv"
@@ -21,22 +22,37 @@ import (
 const (
 	DefaultInterval = 30
 	DefaultDigits   = 6
-	t0              = 0 // t0 is the Unix time to start counting time steps (default value is 0)
+	MinDigits       = 6  // MinDigits is the minimum number of digits as defined into the RFC4226
+	MaxDigits       = 10 // MaxDigits is the maximum number of digits a 31-bit code can fill
+	t0              = 0  // t0 is the Unix time to start counting time steps (default value is 0)
 )
 
 // TOTPFromBase32 generates a TOTP (Time-Based One-Time Password) value as
 // defined into the RFC6238 from a base32 encoded secret
 func TOTPFromBase32(h func() hash.Hash, decodedKey string, t time.Time, interval int, digits int) (string, error) {
-	// padding the decodedKey if needed
-	for len(decodedKey)%8 != 0 {
-		decodedKey += "="
+	secret, err := decodeBase32(decodedKey)
+	if err != nil {
+		return "", err
 	}
+	return TOTP(h, secret, t, interval, digits)
+}
 
-	secret, err := base32.StdEncoding.DecodeString(strings.ToUpper(decodedKey))
+// HOTPFromBase32 generates an HOTP (HMAC-Based One-Time Password) value as
+// defined into the RFC4226 from a base32 encoded secret
+func HOTPFromBase32(h func() hash.Hash, decodedKey string, count uint64, digits int) (string, error) {
+	secret, err := decodeBase32(decodedKey)
 	if err != nil {
 		return "", err
 	}
-	return TOTP(h, secret, t, interval, digits)
+	return HOTP(h, secret, count, digits)
+}
+
+// decodeBase32 decodes the base32 encoded key, padding if needed
+func decodeBase32(decodedKey string) ([]byte, error) {
+	for len(decodedKey)%8 != 0 {
+		decodedKey += "="
+	}
+	return base32.StdEncoding.DecodeString(strings.ToUpper(decodedKey))
 }
 
 // TOTP generates a TOTP (Time-Based One-Time Password) value as defined into the RFC6238
@@ -60,8 +76,8 @@ func numTimeSteps(t time.Time, interval int, t0 int64) uint64 {
 // Note: if the hash function is nil, defaults to SHA1
 // Reference: https://datatracker.ietf.org/doc/html/rfc4226
 func HOTP(h func() hash.Hash, key []byte, count uint64, digits int) (string, error) {
-	if digits < 1 {
-		return "", errors.New("digits value must be greater than 0. RFC suggests it must be at least a 6-digit value")
+	if digits < MinDigits || digits > MaxDigits {
+		return "", fmt.Errorf("digits value must be between %d and %d", MinDigits, MaxDigits)
 	}
 
 	// Generate the hash
@@ -82,7 +98,7 @@ func HOTP(h func() hash.Hash, key []byte, count uint64, digits int) (string, err
 	code := binary.BigEndian.Uint32(dbc)
 
 	// hotp is code % (10^digits)
-	otp := code % uint32(math.Pow10(digits))
+	otp := uint64(code) % uint64(math.Pow10(digits))
 
 	// value is the string representation of otp
 	value := strconv.Itoa(int(otp))
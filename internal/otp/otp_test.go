@@ -405,3 +405,20 @@ func TestTOTPDigitsOutput(t *testing.T) {
 	require.NoError(t, err)
 	require.Equal(t, "003475", v)
 }
+
+func TestHOTPDigits(t *testing.T) {
+	secret := []byte("12345678901234567890")
+	for _, digits := range []int{0, 5, 11, 20} {
+		_, err := HOTP(sha1.New, secret, 0, digits)
+		require.Error(t, err, "digits %d", digits)
+	}
+	for digits := MinDigits; digits <= MaxDigits; digits++ {
+		v, err := HOTP(sha1.New, secret, 0, digits)
+		require.NoError(t, err)
+		require.Len(t, v, digits)
+	}
+	// the RFC4226 count 0 code is 1284755224, see Appendix D
+	v, err := HOTP(sha1.New, secret, 0, MaxDigits)
+	require.NoError(t, err)
+	require.Equal(t, "1284755224", v)
+}
@@ -11,4 +11,6 @@ type AppState struct {
 	Modified time.Time `json:"modified,omitempty"`
 	// Preferences contains the application preferences
 	Preferences *Preferences `json:"preferences,omitempty"`
+	// Sync contains the synchronisation state by vault name
+	Sync map[string]*SyncState `json:"sync,omitempty"`
 }
@@ -0,0 +1,252 @@
+// SPDX-FileCopyrightText: 2025 Luca Corbo, Paw contributors
+// SPDX-License-Identifier: AGPL-3.0-or-later
+
+package paw
+
+import (
+	"bufio"
+	"bytes"
+	"errors"
+	"fmt"
+	"io"
+	"io/fs"
+	"log"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync"
+	"time"
+)
+
+const (
+	// tmpFileSuffix is the suffix of the temporary files written by atomicFile
+	tmpFileSuffix = ".tmp"
+	// staleTmpFileAge is the age after that a temporary file is considered left by a crashed writer
+	staleTmpFileAge = 10 * time.Minute
+
+	ageHeader        = "age-encryption.org/v1\n"
+	ageArmorHeader   = "-----BEGIN AGE ENCRYPTED FILE-----"
+	ageArmorFooter   = "-----END AGE ENCRYPTED FILE-----"
+	ageHeaderMACLine = "--- "
+	// the payload has a 16 bytes nonce followed by at least a chunk with its 16 bytes tag
+	ageMinPayloadSize = 16 + 16
+)
+
+// atomicFile is a file written to a temporary file into the same dir and
+// renamed once committed, so that readers never see it half-written.
+// Close discards the changes if not committed.
+type atomicFile struct {
+	*os.File
+	name      string
+	committed bool
+}
+
+// createAtomicFile returns an atomicFile that replaces the named file on commit
+func createAtomicFile(name string) (*atomicFile, error) {
+	dir, base := filepath.Split(name)
+	f, err := os.CreateTemp(dir, base+".*"+tmpFileSuffix)
+	if err != nil {
+		return nil, err
+	}
+	err = f.Chmod(0600)
+	if err != nil {
+		f.Close()
+		os.Remove(f.Name())
+		return nil, err
+	}
+	return &atomicFile{File: f, name: name}, nil
+}
+
+// Commit flushes the content to the disk and replaces the file
+func (f *atomicFile) Commit() error {
+	if f.committed {
+		return nil
+	}
+	err := f.File.Sync()
+	if err != nil {
+		f.Close()
+		return fmt.Errorf("could not sync the file: %w", err)
+	}
+	err = f.File.Close()
+	if err != nil {
+		os.Remove(f.File.Name())
+		return fmt.Errorf("could not close the file: %w", err)
+	}
+	err = os.Rename(f.File.Name(), f.name)
+	if err != nil {
+		os.Remove(f.File.Name())
+		return fmt.Errorf("could not replace the file: %w", err)
+	}
+	f.committed = true
+	syncDir(filepath.Dir(f.name))
+	return nil
+}
+
+// Close discards the changes if the file has not been committed
+func (f *atomicFile) Close() error {
+	if f.committed {
+		return nil
+	}
+	f.committed = true
+	err := f.File.Close()
+	os.Remove(f.File.Name())
+	return err
+}
+
+// WriteFileAtomic writes data to the named file with 0600 permissions. The data
+// is written to a temporary file into the same dir then renamed, so that the
+// file is never left half-written.
+func WriteFileAtomic(name string, data []byte) error {
+	f, err := createAtomicFile(name)
+	if err != nil {
+		return err
+	}
+	defer f.Close()
+	_, err = f.Write(data)
+	if err != nil {
+		return err
+	}
+	return f.Commit()
+}
+
+// syncDir flushes the dir entries to the disk so that a rename survives a
+// crash. Errors are ignored: not all the platforms allow to sync a dir.
+func syncDir(dir string) {
+	d, err := os.Open(dir)
+	if err != nil {
+		return
+	}
+	d.Sync()
+	d.Close()
+}
+
+// isTmpFile reports whether the file name is a temporary file written by atomicFile
+func isTmpFile(name string) bool {
+	return strings.HasSuffix(name, tmpFileSuffix)
+}
+
+// checkAgeFile checks the structure of an age encrypted file, either binary
+// or armored, without decrypting it. It detects the files left half-written by
+// an interrupted write: an empty file, an incomplete header or payload.
+// A file truncated in the middle of the payload can be detected only decrypting it.
+func checkAgeFile(name string) error {
+	f, err := os.Open(name)
+	if err != nil {
+		return err
+	}
+	defer f.Close()
+
+	r := bufio.NewReader(f)
+	peek, _ := r.Peek(len(ageArmorHeader))
+	if string(peek) == ageArmorHeader {
+		// only the end of the file is read, the footer can be followed by spaces
+		fi, err := f.Stat()
+		if err != nil {
+			return err
+		}
+		off := fi.Size() - int64(len(ageArmorFooter)+64)
+		if off < 0 {
+			off = 0
+		}
+		tail := make([]byte, fi.Size()-off)
+		_, err = f.ReadAt(tail, off)
+		if err != nil && !errors.Is(err, io.EOF) {
+			return err
+		}
+		if !bytes.HasSuffix(bytes.TrimSpace(tail), []byte(ageArmorFooter)) {
+			return errors.New("incomplete armored age file")
+		}
+		return nil
+	}
+
+	line, err := r.ReadString('\n')
+	if err != nil || line != ageHeader {
+		if line == "" && errors.Is(err, io.EOF) {
+			return errors.New("empty file")
+		}
+		return errors.New("invalid age header")
+	}
+	header := int64(len(line))
+	for {
+		line, err = r.ReadString('\n')
+		if err != nil {
+			return errors.New("incomplete age header")
+		}
+		header += int64(len(line))
+		if strings.HasPrefix(line, ageHeaderMACLine) {
+			break
+		}
+	}
+	fi, err := f.Stat()
+	if err != nil {
+		return err
+	}
+	if fi.Size()-header < ageMinPayloadSize {
+		return errors.New("incomplete age payload")
+	}
+	return nil
+}
+
+// recoveredVaults holds the vault dirs already recovered by the process
+var recoveredVaults sync.Map
+
+// recoverVault cleans up the vault dir after an interrupted write the first
+// time the vault is loaded by the process, see recoverVaultFiles.
+// The issues are logged, the fsck command reports and repairs them.
+func recoverVault(s Storage, name string) {
+	dir := vaultRootPath(s, name)
+	if _, done := recoveredVaults.LoadOrStore(dir, true); done {
+		return
+	}
+	for _, err := range recoverVaultFiles(dir, time.Now()) {
+		log.Printf("vault %q recovery: %s", name, err)
+	}
+}
+
+// recoverVaultFiles cleans up the vault dir after an interrupted write: the
+// temporary files left by a crashed writer are removed, a key rotation is
+// rolled back and the encrypted files left half-written are reported. Only the
+// structure of the files is checked, see checkAgeFile. The temporary files are
+// removed once stale to not interfere with a concurrent writer.
+func recoverVaultFiles(dir string, now time.Time) []error {
+	var errs []error
+	fi, err := os.Stat(filepath.Join(dir, rotationDirName))
+	if err == nil && now.Sub(fi.ModTime()) >= staleTmpFileAge {
+		err = recoverKeyRotation(dir)
+		if err != nil {
+			errs = append(errs, err)
+		}
+	}
+	filepath.WalkDir(dir, func(path string, d fs.DirEntry, err error) error {
+		if err != nil {
+			if !errors.Is(err, os.ErrNotExist) {
+				errs = append(errs, err)
+			}
+			return nil
+		}
+		if d.IsDir() {
+			if d.Name() == ".git" || d.Name() == rotationDirName {
+				return fs.SkipDir
+			}
+			return nil
+		}
+		switch {
+		case isTmpFile(path):
+			fi, err := d.Info()
+			if err != nil || now.Sub(fi.ModTime()) < staleTmpFileAge {
+				return nil
+			}
+			err = os.Remove(path)
+			if err != nil {
+				errs = append(errs, fmt.Errorf("could not remove the temporary file %s: %w", path, err))
+			}
+		case filepath.Ext(path) == ".age":
+			err := checkAgeFile(path)
+			if err != nil {
+				errs = append(errs, fmt.Errorf("%s may be corrupted, see paw cli fsck: %w", path, err))
+			}
+		}
+		return nil
+	})
+	return errs
+}
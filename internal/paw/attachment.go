@@ -0,0 +1,173 @@
+// SPDX-FileCopyrightText: 2025 Luca Corbo, Paw contributors
+// SPDX-License-Identifier: AGPL-3.0-or-later
+
+package paw
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"path/filepath"
+	"strings"
+	"time"
+)
+
+// AttachmentMaxSize is the maximum size in bytes of an attachment
+const AttachmentMaxSize = 10 << 20
+
+var (
+	// ErrAttachmentAlreadyExists is returned when an attachment with the same name is already present into the item
+	ErrAttachmentAlreadyExists = errors.New("attachment already exists")
+	// ErrAttachmentNotFound is returned when an attachment is not present into the item
+	ErrAttachmentNotFound = errors.New("attachment not found")
+	// ErrAttachmentTooLarge is returned when an attachment exceeds AttachmentMaxSize
+	ErrAttachmentTooLarge = fmt.Errorf("attachment exceeds the maximum size of %d MiB", AttachmentMaxSize>>20)
+)
+
+// Attachment represents a file attached to an item.
+// The content is stored encrypted separately from the item, see AttachmentStorage.
+type Attachment struct {
+	// ID is the attachment unique identifier used to store the content
+	ID string `json:"id"`
+	// Name is the file name
+	Name string `json:"name"`
+	// Size is the size in bytes of the content
+	Size int64 `json:"size"`
+	// Created holds the date the file has been attached
+	Created time.Time `json:"created"`
+}
+
+// AttachmentByName returns the attachment with the specified name
+func (m *Metadata) AttachmentByName(name string) (*Attachment, bool) {
+	for _, a := range m.Attachments {
+		if a.Name == name {
+			return a, true
+		}
+	}
+	return nil, false
+}
+
+// AddAttachment stores encrypted the content read from r as attachment of the item, then
+// stores the item and the vault. The content is streamed and cannot exceed AttachmentMaxSize.
+func AddAttachment(s Storage, vault *Vault, item Item, name string, r io.Reader) (*Attachment, error) {
+	name = strings.TrimSpace(filepath.Base(name))
+	if name == "" || name == "." || name == string(filepath.Separator) {
+		return nil, errors.New("the attachment name cannot be empty")
+	}
+	meta := item.GetMetadata()
+	if _, ok := meta.AttachmentByName(name); ok {
+		return nil, fmt.Errorf("%w: %q is already attached to %q", ErrAttachmentAlreadyExists, name, meta.Name)
+	}
+
+	now := time.Now().UTC()
+	attachment := &Attachment{
+		ID:      newUUID(),
+		Name:    name,
+		Created: now,
+	}
+	size, err := s.StoreAttachment(vault, attachment, &limitedReader{r: r, n: AttachmentMaxSize})
+	if err != nil {
+		_ = s.DeleteAttachment(vault, attachment)
+		return nil, err
+	}
+	attachment.Size = size
+
+	meta.Attachments = append(meta.Attachments, attachment)
+	meta.Modified = now
+	err = storeItemWithAttachments(s, vault, item)
+	if err != nil {
+		meta.Attachments = meta.Attachments[:len(meta.Attachments)-1]
+		_ = s.DeleteAttachment(vault, attachment)
+		return nil, err
+	}
+	return attachment, nil
+}
+
+// OpenAttachment returns a reader decrypting the content of the item attachment.
+// The caller must close the reader.
+func OpenAttachment(s Storage, vault *Vault, item Item, name string) (io.ReadCloser, *Attachment, error) {
+	attachment, ok := item.GetMetadata().AttachmentByName(name)
+	if !ok {
+		return nil, nil, fmt.Errorf("%w: %q is not attached to %q", ErrAttachmentNotFound, name, item.GetMetadata().Name)
+	}
+	r, err := s.LoadAttachment(vault, attachment)
+	if err != nil {
+		return nil, nil, err
+	}
+	return r, attachment, nil
+}
+
+// RemoveAttachment deletes the item attachment, then stores the item and the vault
+func RemoveAttachment(s Storage, vault *Vault, item Item, name string) error {
+	meta := item.GetMetadata()
+	attachment, ok := meta.AttachmentByName(name)
+	if !ok {
+		return fmt.Errorf("%w: %q is not attached to %q", ErrAttachmentNotFound, name, meta.Name)
+	}
+
+	attachments := make([]*Attachment, 0, len(meta.Attachments)-1)
+	for _, a := range meta.Attachments {
+		if a.ID != attachment.ID {
+			attachments = append(attachments, a)
+		}
+	}
+	meta.Attachments = attachments
+	meta.Modified = time.Now().UTC()
+	err := storeItemWithAttachments(s, vault, item)
+	if err != nil {
+		return err
+	}
+	// the content is deleted once not referenced anymore
+	return s.DeleteAttachment(vault, attachment)
+}
+
+// deleteAttachments deletes the content of all the attachments ignoring the errors
+func deleteAttachments(s Storage, vault *Vault, meta *Metadata) {
+	for _, attachment := range meta.Attachments {
+		_ = s.DeleteAttachment(vault, attachment)
+	}
+}
+
+// storeItemWithAttachments stores the item and the vault with the updated attachments
+func storeItemWithAttachments(s Storage, vault *Vault, item Item) error {
+	unlock, err := LockVault(s, vault)
+	if err != nil {
+		return err
+	}
+	defer unlock()
+
+	err = s.StoreItem(vault, item)
+	if err != nil {
+		return err
+	}
+	err = vault.AddItem(item)
+	if err != nil {
+		return err
+	}
+	vault.Modified = item.GetMetadata().Modified
+	return s.StoreVault(vault)
+}
+
+// limitedReader reads from r returning ErrAttachmentTooLarge once n bytes are exceeded
+type limitedReader struct {
+	r io.Reader
+	n int64
+}
+
+func (l *limitedReader) Read(p []byte) (int, error) {
+	if int64(len(p)) > l.n+1 {
+		p = p[:l.n+1]
+	}
+	n, err := l.r.Read(p)
+	l.n -= int64(n)
+	if l.n < 0 {
+		return 0, ErrAttachmentTooLarge
+	}
+	return n, err
+}
+
+// readCloser is a reader that closes the underlying source
+type readCloser struct {
+	io.Reader
+	io.Closer
+}
@@ -0,0 +1,92 @@
+// SPDX-FileCopyrightText: 2025 Luca Corbo, Paw contributors
+// SPDX-License-Identifier: AGPL-3.0-or-later
+
+package paw
+
+import (
+	"bytes"
+	"io"
+	"os"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestAttachment_OSStorage(t *testing.T) {
+	root, err := os.MkdirTemp(os.TempDir(), "paw")
+	require.NoError(t, err)
+	defer os.RemoveAll(root)
+
+	s, err := NewOSStorageRooted(root)
+	require.NoError(t, err)
+	key, err := s.CreateVaultKey("test", "secret")
+	require.NoError(t, err)
+	vault, err := s.CreateVault("test", key)
+	require.NoError(t, err)
+
+	note := NewNote()
+	note.Name = "note"
+	require.NoError(t, s.StoreItem(vault, note))
+	require.NoError(t, vault.AddItem(note))
+
+	content := "recovery codes"
+	attachment, err := AddAttachment(s, vault, note, "/tmp/codes.txt", strings.NewReader(content))
+	require.NoError(t, err)
+	assert.Equal(t, "codes.txt", attachment.Name)
+	assert.Equal(t, int64(len(content)), attachment.Size)
+
+	// the content is stored encrypted
+	b, err := os.ReadFile(attachmentPath(s, vault.Name, attachment.ID))
+	require.NoError(t, err)
+	assert.NotContains(t, string(b), content)
+
+	_, err = AddAttachment(s, vault, note, "codes.txt", strings.NewReader(content))
+	assert.ErrorIs(t, err, ErrAttachmentAlreadyExists)
+
+	// the attachment is referenced from the stored item and vault
+	stored, err := s.LoadVault(vault.Name, key)
+	require.NoError(t, err)
+	meta, ok := stored.ItemMetadataByName(NoteItemType, "note")
+	require.True(t, ok)
+	require.Len(t, meta.Attachments, 1)
+	item, err := s.LoadItem(stored, meta)
+	require.NoError(t, err)
+
+	r, _, err := OpenAttachment(s, stored, item, "codes.txt")
+	require.NoError(t, err)
+	got, err := io.ReadAll(r)
+	require.NoError(t, err)
+	require.NoError(t, r.Close())
+	assert.Equal(t, content, string(got))
+
+	_, err = AddAttachment(s, vault, note, "large.bin", bytes.NewReader(make([]byte, AttachmentMaxSize+1)))
+	assert.ErrorIs(t, err, ErrAttachmentTooLarge)
+	_, ok = note.AttachmentByName("large.bin")
+	assert.False(t, ok)
+
+	require.NoError(t, RemoveAttachment(s, vault, note, "codes.txt"))
+	assert.NoFileExists(t, attachmentPath(s, vault.Name, attachment.ID))
+	assert.Empty(t, note.Attachments)
+	assert.ErrorIs(t, RemoveAttachment(s, vault, note, "codes.txt"), ErrAttachmentNotFound)
+
+	// the attachments are deleted once the item is purged from the trash
+	attachment, err = AddAttachment(s, vault, note, "codes.txt", strings.NewReader(content))
+	require.NoError(t, err)
+	require.NoError(t, MoveItemToTrash(s, vault, note))
+	assert.FileExists(t, attachmentPath(s, vault.Name, attachment.ID))
+	require.NoError(t, PurgeItemFromTrash(s, vault, note.ID()))
+	assert.NoFileExists(t, attachmentPath(s, vault.Name, attachment.ID))
+}
+
+func TestLimitedReader(t *testing.T) {
+	r := &limitedReader{r: strings.NewReader("12345"), n: 5}
+	b, err := io.ReadAll(r)
+	require.NoError(t, err)
+	assert.Equal(t, "12345", string(b))
+
+	r = &limitedReader{r: strings.NewReader("123456"), n: 5}
+	_, err = io.ReadAll(r)
+	assert.ErrorIs(t, err, ErrAttachmentTooLarge)
+}
@@ -3,10 +3,70 @@
 
 package paw
 
-import "net/url"
+import (
+	"net/url"
+	"strings"
+
+	"golang.org/x/net/publicsuffix"
+)
 
 type Autofill struct {
 	AllowHTTP  bool     `json:"allow_http,omitempty"`
 	TLDPlusOne string   `json:"tld_plus_one,omitempty"`
 	URL        *url.URL `json:"url,omitempty"`
 }
+
+// URLMatch represents how much an autofill URL matches a requested URL
+type URLMatch int
+
+const (
+	// NoURLMatch is returned when the URLs do not match
+	NoURLMatch URLMatch = iota
+	// DomainURLMatch is returned when the URLs share the same TLD+1
+	DomainURLMatch
+	// HostURLMatch is returned when the URLs share the same scheme and host
+	HostURLMatch
+	// PathURLMatch is returned when the URLs share the same scheme and host
+	// and the autofill URL path is a prefix of the requested one
+	PathURLMatch
+)
+
+// MatchURL returns how much the autofill URL matches u
+func (a *Autofill) MatchURL(u *url.URL) URLMatch {
+	if a == nil || u == nil || u.Hostname() == "" {
+		return NoURLMatch
+	}
+
+	if a.URL != nil && a.URL.Host != "" && strings.EqualFold(a.URL.Scheme, u.Scheme) && strings.EqualFold(a.URL.Host, u.Host) {
+		path := strings.Trim(a.URL.Path, "/")
+		if path == "" {
+			return HostURLMatch
+		}
+		reqPath := strings.Trim(u.Path, "/")
+		if reqPath == "" {
+			// the path is not part of the request
+			return HostURLMatch
+		}
+		if reqPath == path || strings.HasPrefix(reqPath, path+"/") {
+			return PathURLMatch
+		}
+		// the path is specified and it does not match, the item is for a different resource
+		return NoURLMatch
+	}
+
+	tldPlusOne := a.TLDPlusOne
+	if tldPlusOne == "" && a.URL != nil {
+		tldPlusOne = a.URL.Hostname()
+	}
+	if tldPlusOne == "" {
+		return NoURLMatch
+	}
+	reqTLDPlusOne, err := publicsuffix.EffectiveTLDPlusOne(u.Hostname())
+	if err != nil {
+		reqTLDPlusOne = u.Hostname()
+	}
+	if strings.EqualFold(tldPlusOne, reqTLDPlusOne) {
+		return DomainURLMatch
+	}
+	return NoURLMatch
+}
@@ -0,0 +1,101 @@
+// SPDX-FileCopyrightText: 2025 Luca Corbo, Paw contributors
+// SPDX-License-Identifier: AGPL-3.0-or-later
+
+package paw
+
+import (
+	"net/url"
+	"testing"
+)
+
+func TestAutofill_MatchURL(t *testing.T) {
+	mustParse := func(rawURL string) *url.URL {
+		u, err := url.Parse(rawURL)
+		if err != nil {
+			t.Fatal(err)
+		}
+		return u
+	}
+
+	tests := []struct {
+		name     string
+		autofill *Autofill
+		url      string
+		want     URLMatch
+	}{
+		{
+			name:     "nil autofill",
+			autofill: nil,
+			url:      "https://github.com",
+			want:     NoURLMatch,
+		},
+		{
+			name:     "same host",
+			autofill: &Autofill{URL: mustParse("https://github.com"), TLDPlusOne: "github.com"},
+			url:      "https://github.com/lucor/paw.git",
+			want:     HostURLMatch,
+		},
+		{
+			name:     "same host and path prefix",
+			autofill: &Autofill{URL: mustParse("https://github.com/lucor/"), TLDPlusOne: "github.com"},
+			url:      "https://github.com/lucor/paw.git",
+			want:     PathURLMatch,
+		},
+		{
+			name:     "same host and path not requested",
+			autofill: &Autofill{URL: mustParse("https://github.com/lucor/"), TLDPlusOne: "github.com"},
+			url:      "https://github.com",
+			want:     HostURLMatch,
+		},
+		{
+			name:     "same host and different path",
+			autofill: &Autofill{URL: mustParse("https://github.com/fyne-io"), TLDPlusOne: "github.com"},
+			url:      "https://github.com/lucor/paw.git",
+			want:     NoURLMatch,
+		},
+		{
+			name:     "path is not a segment prefix",
+			autofill: &Autofill{URL: mustParse("https://github.com/luc"), TLDPlusOne: "github.com"},
+			url:      "https://github.com/lucor/paw.git",
+			want:     NoURLMatch,
+		},
+		{
+			name:     "subdomain",
+			autofill: &Autofill{URL: mustParse("https://github.com"), TLDPlusOne: "github.com"},
+			url:      "https://gist.github.com",
+			want:     DomainURLMatch,
+		},
+		{
+			name:     "different scheme",
+			autofill: &Autofill{URL: mustParse("https://example.com"), TLDPlusOne: "example.com"},
+			url:      "http://example.com",
+			want:     DomainURLMatch,
+		},
+		{
+			name:     "different port",
+			autofill: &Autofill{URL: mustParse("https://example.com:8443"), TLDPlusOne: "example.com"},
+			url:      "https://example.com",
+			want:     DomainURLMatch,
+		},
+		{
+			name:     "different domain",
+			autofill: &Autofill{URL: mustParse("https://gitlab.com"), TLDPlusOne: "gitlab.com"},
+			url:      "https://github.com",
+			want:     NoURLMatch,
+		},
+		{
+			name:     "TLD+1 only",
+			autofill: &Autofill{TLDPlusOne: "github.com"},
+			url:      "https://api.github.com",
+			want:     DomainURLMatch,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := tt.autofill.MatchURL(mustParse(tt.url))
+			if got != tt.want {
+				t.Errorf("MatchURL() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
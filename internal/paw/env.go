@@ -6,4 +6,7 @@ package paw
 const (
 	ENV_HOME    = "PAW_HOME"    // The env var name can be used to override the Paw HOME directory
 	ENV_SESSION = "PAW_SESSION" // The env var name can be used to specify a Paw session ID
+	ENV_VAULT   = "PAW_VAULT"   // The env var name can be used to specify the vault used by the credential helpers
+
+	ENV_SYNC_PASSWORD = "PAW_SYNC_PASSWORD" // The env var name can be used to specify the password for the sync remote
 )
@@ -0,0 +1,27 @@
+// SPDX-FileCopyrightText: 2025 Luca Corbo, Paw contributors
+// SPDX-License-Identifier: AGPL-3.0-or-later
+
+//go:build darwin || dragonfly || freebsd || linux || netbsd || openbsd
+
+package paw
+
+import (
+	"errors"
+	"os"
+	"syscall"
+)
+
+// tryLockFile acquires an exclusive advisory lock on the file without blocking.
+// It returns false if the lock is held by another process.
+func tryLockFile(f *os.File) (bool, error) {
+	err := syscall.Flock(int(f.Fd()), syscall.LOCK_EX|syscall.LOCK_NB)
+	if errors.Is(err, syscall.EWOULDBLOCK) {
+		return false, nil
+	}
+	return err == nil, err
+}
+
+// unlockFile releases the advisory lock on the file
+func unlockFile(f *os.File) error {
+	return syscall.Flock(int(f.Fd()), syscall.LOCK_UN)
+}
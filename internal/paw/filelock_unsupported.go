@@ -0,0 +1,18 @@
+// SPDX-FileCopyrightText: 2025 Luca Corbo, Paw contributors
+// SPDX-License-Identifier: AGPL-3.0-or-later
+
+//go:build !darwin && !dragonfly && !freebsd && !linux && !netbsd && !openbsd && !windows
+
+package paw
+
+import "os"
+
+// tryLockFile is a no-op on the platforms without advisory locks
+func tryLockFile(f *os.File) (bool, error) {
+	return true, nil
+}
+
+// unlockFile is a no-op on the platforms without advisory locks
+func unlockFile(f *os.File) error {
+	return nil
+}
@@ -5,6 +5,8 @@ package paw
 
 import (
 	"fmt"
+	"reflect"
+	"sort"
 )
 
 // ItemType represents the Item type
@@ -21,59 +23,83 @@ const (
 	LoginItemType
 	// SSHKeyItemType is the SSH Key Item type
 	SSHKeyItemType
+	// CardItemType is the Payment Card Item type
+	CardItemType
+	// IdentityItemType is the Identity Item type
+	IdentityItemType
+	// APICredentialItemType is the API Credential Item type
+	APICredentialItemType
+	// LicenseItemType is the Software License Item type
+	LicenseItemType
+	// WiFiItemType is the Wi-Fi network Item type
+	WiFiItemType
 )
 
+// itemTypeInfo holds the details of a registered item type
+type itemTypeInfo struct {
+	name    string
+	label   string
+	newItem func() Item
+}
+
+// itemTypes holds the registered item types
+var itemTypes = map[ItemType]*itemTypeInfo{}
+
+// RegisterItemType registers an item type making it available to NewItem,
+// to the storage and to the importer. Name is the string representation used
+// in the item paths and in the exported files, label is the name used in the UI.
+// It panics if the item type or its name is already registered.
+func RegisterItemType(itemType ItemType, name string, label string, newItem func() Item) {
+	for t, info := range itemTypes {
+		if t == itemType || info.name == name {
+			panic(fmt.Sprintf("item type %q already registered", name))
+		}
+	}
+	itemTypes[itemType] = &itemTypeInfo{name: name, label: label, newItem: newItem}
+}
+
+// ItemTypes returns the registered item types sorted by name
+func ItemTypes() []ItemType {
+	types := make([]ItemType, 0, len(itemTypes))
+	for t := range itemTypes {
+		types = append(types, t)
+	}
+	sort.Slice(types, func(i, j int) bool {
+		return itemTypes[types[i]].name < itemTypes[types[j]].name
+	})
+	return types
+}
+
 // Label returns the item type label used in the UI
 func (it ItemType) Label() string {
-	switch it {
-	case MetadataItemType:
+	if it == MetadataItemType {
 		return "Metadata"
-	case NoteItemType:
-		return "Note"
-	case PasswordItemType:
-		return "Password"
-	case LoginItemType:
-		return "Login"
-	case SSHKeyItemType:
-		return "SSH key"
+	}
+	if info, ok := itemTypes[it]; ok {
+		return info.label
 	}
 	return "Invalid item type"
 }
 
 // String returns the item type string representation
 func (it ItemType) String() string {
-	switch it {
-	case MetadataItemType:
+	if it == MetadataItemType {
 		return "metadata"
-	case NoteItemType:
-		return "note"
-	case PasswordItemType:
-		return "password"
-	case LoginItemType:
-		return "login"
-	case SSHKeyItemType:
-		return "ssh_key"
+	}
+	if info, ok := itemTypes[it]; ok {
+		return info.name
 	}
 	return "invalid"
 }
 
 // ItemTypeFromString returns the item type from a string
 func ItemTypeFromString(v string) (ItemType, error) {
-	var itemType ItemType
-	var err error
-	switch v {
-	case LoginItemType.String():
-		itemType = LoginItemType
-	case NoteItemType.String():
-		itemType = NoteItemType
-	case PasswordItemType.String():
-		itemType = PasswordItemType
-	case SSHKeyItemType.String():
-		itemType = SSHKeyItemType
-	default:
-		err = fmt.Errorf("invalid item type %q", v)
+	for t, info := range itemTypes {
+		if info.name == v {
+			return t, nil
+		}
 	}
-	return itemType, err
+	return 0, fmt.Errorf("invalid item type %q", v)
 }
 
 // Item wraps all methods allow to generate a password with paw
@@ -86,20 +112,24 @@ type Item interface {
 	fmt.Stringer
 }
 
+// NewItem returns a new item of the specified type
 func NewItem(name string, itemType ItemType) (Item, error) {
-	var item Item
-	switch itemType {
-	case LoginItemType:
-		item = NewLogin()
-	case NoteItemType:
-		item = NewNote()
-	case PasswordItemType:
-		item = NewPassword()
-	case SSHKeyItemType:
-		item = NewSSHKey()
-	default:
+	info, ok := itemTypes[itemType]
+	if !ok {
 		return nil, fmt.Errorf("invalid item type %q", itemType)
 	}
+	item := info.newItem()
 	item.GetMetadata().Name = name
 	return item, nil
 }
+
+// emptyItem returns an item of the specified type with all the fields set to
+// their zero value, ready to be decoded
+func emptyItem(itemType ItemType) (Item, error) {
+	info, ok := itemTypes[itemType]
+	if !ok {
+		return nil, fmt.Errorf("invalid item type %q", itemType)
+	}
+	t := reflect.TypeOf(info.newItem()).Elem()
+	return reflect.New(t).Interface().(Item), nil
+}
@@ -0,0 +1,61 @@
+// SPDX-FileCopyrightText: 2025 Luca Corbo, Paw contributors
+// SPDX-License-Identifier: AGPL-3.0-or-later
+
+package paw
+
+import "net/url"
+
+func init() {
+	RegisterItemType(APICredentialItemType, "api_credential", "API credential", func() Item { return NewAPICredential() })
+}
+
+// Declare conformity to Item interface
+var _ Item = (*APICredential)(nil)
+var _ CustomFielder = (*APICredential)(nil)
+var _ FieldsItem = (*APICredential)(nil)
+
+// APICredential represents the credential to access an API
+type APICredential struct {
+	*Metadata `json:"metadata,omitempty"`
+
+	Key      string `json:"key,omitempty"`
+	Secret   string `json:"secret,omitempty"`
+	Endpoint string `json:"endpoint,omitempty"`
+	Expires  string `json:"expires,omitempty"`
+	Notes    string `json:"notes,omitempty"`
+	// Fields holds the custom fields
+	Fields CustomFields `json:"fields,omitempty"`
+}
+
+// NewAPICredential returns a new API credential
+func NewAPICredential() *APICredential {
+	return &APICredential{
+		Metadata: newMetadata(APICredentialItemType),
+	}
+}
+
+// GetCustomFields implements CustomFielder.
+func (i *APICredential) GetCustomFields() *CustomFields {
+	return &i.Fields
+}
+
+// ItemFields implements FieldsItem.
+func (i *APICredential) ItemFields() []*ItemField {
+	return []*ItemField{
+		{Name: "key", Label: "Key", Type: TextCustomField, Value: &i.Key},
+		{Name: "secret", Label: "Secret", Type: HiddenCustomField, Value: &i.Secret},
+		{Name: "endpoint", Label: "Endpoint", Type: URLCustomField, Value: &i.Endpoint},
+		{Name: "expires", Label: "Expires", Type: DateCustomField, Value: &i.Expires},
+		{Name: "notes", Label: "Notes", Type: TextCustomField, Multiline: true, Value: &i.Notes},
+	}
+}
+
+// Subtitle implements MetadataSubtitler.
+// It returns the endpoint host.
+func (i *APICredential) Subtitle() string {
+	u, err := url.Parse(i.Endpoint)
+	if err != nil {
+		return ""
+	}
+	return u.Host
+}
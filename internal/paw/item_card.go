@@ -0,0 +1,95 @@
+// SPDX-FileCopyrightText: 2025 Luca Corbo, Paw contributors
+// SPDX-License-Identifier: AGPL-3.0-or-later
+
+package paw
+
+import (
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+)
+
+func init() {
+	RegisterItemType(CardItemType, "card", "Payment card", func() Item { return NewCard() })
+}
+
+// Declare conformity to Item interface
+var _ Item = (*Card)(nil)
+var _ CustomFielder = (*Card)(nil)
+var _ FieldsItem = (*Card)(nil)
+
+// Card represents a payment card
+type Card struct {
+	*Metadata `json:"metadata,omitempty"`
+
+	Cardholder string `json:"cardholder,omitempty"`
+	Brand      string `json:"brand,omitempty"`
+	Number     string `json:"number,omitempty"`
+	// Expiry is the expiration date in the MM/YYYY format
+	Expiry string `json:"expiry,omitempty"`
+	CVV    string `json:"cvv,omitempty"`
+	PIN    string `json:"pin,omitempty"`
+	Notes  string `json:"notes,omitempty"`
+	// Fields holds the custom fields
+	Fields CustomFields `json:"fields,omitempty"`
+}
+
+// NewCard returns a new payment card
+func NewCard() *Card {
+	return &Card{
+		Metadata: newMetadata(CardItemType),
+	}
+}
+
+// GetCustomFields implements CustomFielder.
+func (i *Card) GetCustomFields() *CustomFields {
+	return &i.Fields
+}
+
+// ItemFields implements FieldsItem.
+func (i *Card) ItemFields() []*ItemField {
+	return []*ItemField{
+		{Name: "cardholder", Label: "Cardholder", Type: TextCustomField, Value: &i.Cardholder},
+		{Name: "brand", Label: "Brand", Type: TextCustomField, Value: &i.Brand},
+		{Name: "number", Label: "Number", Type: HiddenCustomField, Normalize: digitsOnly(12, 19), Value: &i.Number},
+		{Name: "expiry", Label: "Expiry", Type: TextCustomField, Normalize: normalizeCardExpiry, Value: &i.Expiry},
+		{Name: "cvv", Label: "CVV", Type: HiddenCustomField, Normalize: digitsOnly(3, 4), Value: &i.CVV},
+		{Name: "pin", Label: "PIN", Type: HiddenCustomField, Normalize: digitsOnly(4, 12), Value: &i.PIN},
+		{Name: "notes", Label: "Notes", Type: TextCustomField, Multiline: true, Value: &i.Notes},
+	}
+}
+
+// Subtitle implements MetadataSubtitler.
+// It returns the brand and the last four digits of the card number.
+func (i *Card) Subtitle() string {
+	last4 := i.Number
+	if len(last4) > 4 {
+		last4 = last4[len(last4)-4:]
+	}
+	if last4 != "" {
+		last4 = "•••• " + last4
+	}
+	return strings.TrimSpace(i.Brand + " " + last4)
+}
+
+// normalizeCardExpiry normalizes a card expiry date in the MM/YY or MM/YYYY format to MM/YYYY
+func normalizeCardExpiry(v string) (string, error) {
+	errInvalid := errors.New("expected MM/YY or MM/YYYY")
+	month, year, ok := strings.Cut(strings.ReplaceAll(v, " ", ""), "/")
+	if !ok {
+		return "", errInvalid
+	}
+	m, err := strconv.Atoi(month)
+	if err != nil || m < 1 || m > 12 {
+		return "", errInvalid
+	}
+	y, err := strconv.Atoi(year)
+	if err != nil || (len(year) != 2 && len(year) != 4) {
+		return "", errInvalid
+	}
+	if len(year) == 2 {
+		y += 2000
+	}
+	return fmt.Sprintf("%02d/%04d", m, y), nil
+}
@@ -0,0 +1,242 @@
+// SPDX-FileCopyrightText: 2025 Luca Corbo, Paw contributors
+// SPDX-License-Identifier: AGPL-3.0-or-later
+
+package paw
+
+import (
+	"errors"
+	"fmt"
+	"net/mail"
+	"net/url"
+	"strings"
+	"time"
+)
+
+// CustomFieldType represents the type of a custom field
+type CustomFieldType string
+
+const (
+	// TextCustomField is a plain text field
+	TextCustomField CustomFieldType = "text"
+	// HiddenCustomField is a secret field, its value is concealed by default
+	HiddenCustomField CustomFieldType = "hidden"
+	// URLCustomField is an URL field
+	URLCustomField CustomFieldType = "url"
+	// EmailCustomField is an email address field
+	EmailCustomField CustomFieldType = "email"
+	// DateCustomField is a date field in the YYYY-MM-DD format
+	DateCustomField CustomFieldType = "date"
+	// TOTPCustomField is a TOTP field. The value is a base32 secret or an otpauth URI.
+	TOTPCustomField CustomFieldType = "totp"
+)
+
+// CustomFieldDateLayout is the layout of the date custom fields
+const CustomFieldDateLayout = "2006-01-02"
+
+// CustomFieldTypes returns the supported custom field types
+func CustomFieldTypes() []CustomFieldType {
+	return []CustomFieldType{
+		TextCustomField,
+		HiddenCustomField,
+		URLCustomField,
+		EmailCustomField,
+		DateCustomField,
+		TOTPCustomField,
+	}
+}
+
+// CustomFieldTypeFromString returns the custom field type from a string
+func CustomFieldTypeFromString(v string) (CustomFieldType, error) {
+	for _, t := range CustomFieldTypes() {
+		if string(t) == strings.ToLower(v) {
+			return t, nil
+		}
+	}
+	return "", fmt.Errorf("invalid custom field type %q", v)
+}
+
+// Label returns the custom field type label used in the UI
+func (t CustomFieldType) Label() string {
+	switch t {
+	case TextCustomField:
+		return "Text"
+	case HiddenCustomField:
+		return "Hidden"
+	case URLCustomField:
+		return "URL"
+	case EmailCustomField:
+		return "Email"
+	case DateCustomField:
+		return "Date"
+	case TOTPCustomField:
+		return "TOTP"
+	}
+	return "Invalid field type"
+}
+
+// IsSecret reports whether the field value should be concealed
+func (t CustomFieldType) IsSecret() bool {
+	return t == HiddenCustomField || t == TOTPCustomField
+}
+
+// CustomField is an user-defined field of an item
+type CustomField struct {
+	Name  string          `json:"name"`
+	Type  CustomFieldType `json:"type"`
+	Value string          `json:"value,omitempty"`
+}
+
+// NewCustomField returns a new custom field validating and normalizing the value
+func NewCustomField(name string, fieldType CustomFieldType, value string) (*CustomField, error) {
+	f := &CustomField{
+		Name:  strings.TrimSpace(name),
+		Type:  fieldType,
+		Value: value,
+	}
+	err := f.Normalize()
+	if err != nil {
+		return nil, err
+	}
+	return f, nil
+}
+
+// Normalize validates the field normalizing its value according the type
+func (f *CustomField) Normalize() error {
+	if f.Name == "" {
+		return errors.New("the custom field name is required")
+	}
+	if f.Type == "" {
+		f.Type = TextCustomField
+	}
+	value, err := normalizeFieldValue(f.Type, f.Value)
+	if err != nil {
+		return fmt.Errorf("the custom field %q is %w", f.Name, err)
+	}
+	f.Value = value
+	return nil
+}
+
+// normalizeFieldValue validates the value normalizing it according the field type.
+// The returned errors are meant to follow "the field is".
+func normalizeFieldValue(fieldType CustomFieldType, value string) (string, error) {
+	if fieldType != TextCustomField && fieldType != HiddenCustomField {
+		value = strings.TrimSpace(value)
+	}
+	if value == "" {
+		return "", nil
+	}
+
+	switch fieldType {
+	case TextCustomField, HiddenCustomField:
+	case URLCustomField:
+		u, err := url.Parse(value)
+		if err != nil || u.Scheme == "" || u.Host == "" {
+			return "", errors.New("not a valid URL")
+		}
+	case EmailCustomField:
+		addr, err := mail.ParseAddress(value)
+		if err != nil {
+			return "", errors.New("not a valid email address")
+		}
+		value = addr.Address
+	case DateCustomField:
+		_, err := time.Parse(CustomFieldDateLayout, value)
+		if err != nil {
+			return "", errors.New("not a valid date, expected YYYY-MM-DD")
+		}
+	case TOTPCustomField:
+		if strings.HasPrefix(value, OTPAuthScheme+":") {
+			o, err := ParseOTPAuth(value)
+			if err != nil {
+				return "", fmt.Errorf("not valid: %w", err)
+			}
+			if o.IsHOTP() {
+				return "", errors.New("not valid: HOTP is not supported")
+			}
+			return value, nil
+		}
+		secret, err := NormalizeTOTPSecret(value)
+		if err != nil {
+			return "", fmt.Errorf("not valid: %w", err)
+		}
+		value = secret
+	default:
+		return "", fmt.Errorf("of invalid type %q", fieldType)
+	}
+	return value, nil
+}
+
+// TOTP returns the TOTP configuration of a TOTP field
+func (f *CustomField) TOTP() (*TOTP, error) {
+	if f.Type != TOTPCustomField {
+		return nil, fmt.Errorf("the custom field %q is not a TOTP field", f.Name)
+	}
+	if strings.HasPrefix(f.Value, OTPAuthScheme+":") {
+		o, err := ParseOTPAuth(f.Value)
+		if err != nil {
+			return nil, err
+		}
+		return o.TOTP, nil
+	}
+	t := NewDefaultTOTP()
+	t.Secret = f.Value
+	return t, nil
+}
+
+// Resolve returns the field value to use, for TOTP fields the code for the specified time
+func (f *CustomField) Resolve(now time.Time) (string, error) {
+	if f.Type != TOTPCustomField {
+		return f.Value, nil
+	}
+	t, err := f.TOTP()
+	if err != nil {
+		return "", err
+	}
+	return t.Code(now)
+}
+
+// CustomFields is the list of the custom fields of an item
+type CustomFields []*CustomField
+
+// CustomFielder is implemented by the items that support the custom fields
+type CustomFielder interface {
+	Item
+	GetCustomFields() *CustomFields
+}
+
+// Get returns the field with the specified name. Names are case-insensitive.
+func (cf *CustomFields) Get(name string) (*CustomField, bool) {
+	for _, f := range *cf {
+		if strings.EqualFold(f.Name, strings.TrimSpace(name)) {
+			return f, true
+		}
+	}
+	return nil, false
+}
+
+// Set adds the field or replaces the one with the same name
+func (cf *CustomFields) Set(field *CustomField) error {
+	err := field.Normalize()
+	if err != nil {
+		return err
+	}
+	for i, f := range *cf {
+		if strings.EqualFold(f.Name, field.Name) {
+			(*cf)[i] = field
+			return nil
+		}
+	}
+	*cf = append(*cf, field)
+	return nil
+}
+
+// Remove removes the field with the specified name reporting whether it was found
+func (cf *CustomFields) Remove(name string) bool {
+	for i, f := range *cf {
+		if strings.EqualFold(f.Name, strings.TrimSpace(name)) {
+			*cf = append((*cf)[:i], (*cf)[i+1:]...)
+			return true
+		}
+	}
+	return false
+}
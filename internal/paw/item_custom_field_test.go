@@ -0,0 +1,104 @@
+// SPDX-FileCopyrightText: 2025 Luca Corbo, Paw contributors
+// SPDX-License-Identifier: AGPL-3.0-or-later
+
+package paw
+
+import (
+	"encoding/json"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestNewCustomField(t *testing.T) {
+	tests := []struct {
+		name      string
+		fieldType CustomFieldType
+		value     string
+		want      string
+		wantErr   bool
+	}{
+		{name: "text", fieldType: TextCustomField, value: " keep spaces ", want: " keep spaces "},
+		{name: "hidden", fieldType: HiddenCustomField, value: "s3cr3t", want: "s3cr3t"},
+		{name: "default type", fieldType: "", value: "value", want: "value"},
+		{name: "url", fieldType: URLCustomField, value: " https://example.com/path ", want: "https://example.com/path"},
+		{name: "invalid url", fieldType: URLCustomField, value: "example", wantErr: true},
+		{name: "email", fieldType: EmailCustomField, value: "John <john@example.com>", want: "john@example.com"},
+		{name: "invalid email", fieldType: EmailCustomField, value: "john", wantErr: true},
+		{name: "date", fieldType: DateCustomField, value: "2025-03-01", want: "2025-03-01"},
+		{name: "invalid date", fieldType: DateCustomField, value: "01/03/2025", wantErr: true},
+		{name: "totp secret", fieldType: TOTPCustomField, value: "jbsw y3dp ehpk 3pxp", want: "JBSWY3DPEHPK3PXP"},
+		{name: "totp uri", fieldType: TOTPCustomField, value: "otpauth://totp/a?secret=JBSWY3DPEHPK3PXP", want: "otpauth://totp/a?secret=JBSWY3DPEHPK3PXP"},
+		{name: "hotp uri", fieldType: TOTPCustomField, value: "otpauth://hotp/a?secret=JBSWY3DPEHPK3PXP&counter=1", wantErr: true},
+		{name: "invalid totp", fieldType: TOTPCustomField, value: "not base32!", wantErr: true},
+		{name: "empty value", fieldType: DateCustomField, value: "", want: ""},
+		{name: "invalid type", fieldType: "phone", value: "123", wantErr: true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			f, err := NewCustomField("field", tt.fieldType, tt.value)
+			if tt.wantErr {
+				assert.Error(t, err)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, tt.want, f.Value)
+		})
+	}
+
+	_, err := NewCustomField(" ", TextCustomField, "value")
+	assert.Error(t, err, "name is required")
+}
+
+func TestCustomField_Resolve(t *testing.T) {
+	now := time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC)
+
+	f, err := NewCustomField("text", TextCustomField, "value")
+	require.NoError(t, err)
+	v, err := f.Resolve(now)
+	require.NoError(t, err)
+	assert.Equal(t, "value", v)
+
+	f, err = NewCustomField("otp", TOTPCustomField, "JBSWY3DPEHPK3PXP")
+	require.NoError(t, err)
+	totp := NewDefaultTOTP()
+	totp.Secret = "JBSWY3DPEHPK3PXP"
+	want, err := totp.Code(now)
+	require.NoError(t, err)
+	v, err = f.Resolve(now)
+	require.NoError(t, err)
+	assert.Equal(t, want, v)
+}
+
+func TestCustomFields(t *testing.T) {
+	login := NewLogin()
+	fields := login.GetCustomFields()
+
+	require.NoError(t, fields.Set(&CustomField{Name: "Account", Type: TextCustomField, Value: "42"}))
+	require.NoError(t, fields.Set(&CustomField{Name: "pin", Type: HiddenCustomField, Value: "1234"}))
+	assert.Len(t, login.Fields, 2)
+
+	// names are case-insensitive
+	require.NoError(t, fields.Set(&CustomField{Name: "account", Type: TextCustomField, Value: "43"}))
+	assert.Len(t, login.Fields, 2)
+	f, ok := fields.Get("ACCOUNT")
+	require.True(t, ok)
+	assert.Equal(t, "43", f.Value)
+
+	assert.Error(t, fields.Set(&CustomField{Name: "site", Type: URLCustomField, Value: "invalid"}))
+	assert.Len(t, login.Fields, 2)
+
+	assert.True(t, fields.Remove("Pin"))
+	assert.False(t, fields.Remove("pin"))
+	_, ok = fields.Get("pin")
+	assert.False(t, ok)
+
+	// the fields are encoded with the item
+	b, err := json.Marshal(login)
+	require.NoError(t, err)
+	decoded := &Login{}
+	require.NoError(t, json.Unmarshal(b, decoded))
+	assert.Equal(t, login.Fields, decoded.Fields)
+}
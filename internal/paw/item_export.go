@@ -0,0 +1,30 @@
+// SPDX-FileCopyrightText: 2025 Luca Corbo, Paw contributors
+// SPDX-License-Identifier: AGPL-3.0-or-later
+
+package paw
+
+import (
+	"fmt"
+)
+
+// ExportItems loads all the vault items returning them grouped by item type.
+// The returned value, once encoded to JSON, can be decoded using Imported.
+func ExportItems(s ItemStorage, vault *Vault) (map[string][]Item, error) {
+	data := map[string][]Item{}
+	var err error
+	vault.Range(func(id string, meta *Metadata) bool {
+		var item Item
+		item, err = s.LoadItem(vault, meta)
+		if err != nil {
+			err = fmt.Errorf("could not load item %q: %w", meta.Name, err)
+			return false
+		}
+		itemType := item.GetMetadata().Type.String()
+		data[itemType] = append(data[itemType], item)
+		return true
+	})
+	if err != nil {
+		return nil, err
+	}
+	return data, nil
+}
@@ -0,0 +1,86 @@
+// SPDX-FileCopyrightText: 2025 Luca Corbo, Paw contributors
+// SPDX-License-Identifier: AGPL-3.0-or-later
+
+package paw
+
+import (
+	"fmt"
+	"strings"
+)
+
+// ItemField describes a field of an item composed only of plain fields
+type ItemField struct {
+	// Name is the field identifier, e.g. used by the CLI
+	Name string
+	// Label is the field label used in the UI
+	Label string
+	// Type defines how the value is validated and shown
+	Type CustomFieldType
+	// Multiline reports whether the value can span multiple lines
+	Multiline bool
+	// Options optionally lists the allowed values
+	Options []string
+	// Normalize optionally validates and normalizes the value after the type validation
+	Normalize func(v string) (string, error)
+	// Value points to the item value
+	Value *string
+}
+
+// FieldsItem is implemented by the items composed only of plain fields.
+// The fields can be viewed and edited without item type specific code.
+type FieldsItem interface {
+	Item
+	ItemFields() []*ItemField
+}
+
+// ItemFieldByName returns the item field with the specified name
+func ItemFieldByName(item FieldsItem, name string) (*ItemField, bool) {
+	for _, f := range item.ItemFields() {
+		if f.Name == name {
+			return f, true
+		}
+	}
+	return nil, false
+}
+
+// NormalizeItemFields validates and normalizes the item field values.
+// The metadata subtitle is updated if the item implements MetadataSubtitler.
+func NormalizeItemFields(item FieldsItem) error {
+	for _, f := range item.ItemFields() {
+		value, err := normalizeFieldValue(f.Type, *f.Value)
+		if err != nil {
+			return fmt.Errorf("the field %q is %w", f.Label, err)
+		}
+		if value != "" && f.Normalize != nil {
+			value, err = f.Normalize(value)
+			if err != nil {
+				return fmt.Errorf("the field %q is not valid: %w", f.Label, err)
+			}
+		}
+		*f.Value = value
+	}
+	if v, ok := item.(MetadataSubtitler); ok {
+		item.GetMetadata().Subtitle = v.Subtitle()
+	}
+	return nil
+}
+
+// digitsOnly returns a normalizer that removes spaces and dashes from the value
+// and ensures it contains only digits with a length between min and max
+func digitsOnly(min int, max int) func(v string) (string, error) {
+	return func(v string) (string, error) {
+		v = strings.NewReplacer(" ", "", "-", "").Replace(v)
+		for _, r := range v {
+			if r < '0' || r > '9' {
+				return "", fmt.Errorf("only digits are allowed")
+			}
+		}
+		if len(v) < min || len(v) > max {
+			if min == max {
+				return "", fmt.Errorf("expected %d digits", min)
+			}
+			return "", fmt.Errorf("expected from %d to %d digits", min, max)
+		}
+		return v, nil
+	}
+}
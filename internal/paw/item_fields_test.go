@@ -0,0 +1,75 @@
+// SPDX-FileCopyrightText: 2025 Luca Corbo, Paw contributors
+// SPDX-License-Identifier: AGPL-3.0-or-later
+
+package paw
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestNormalizeItemFields_Card(t *testing.T) {
+	tests := []struct {
+		name    string
+		field   string
+		value   string
+		want    string
+		wantErr bool
+	}{
+		{name: "number with spaces", field: "number", value: "4111 1111 1111 1111", want: "4111111111111111"},
+		{name: "number with letters", field: "number", value: "4111 1111 1111 111a", wantErr: true},
+		{name: "number too short", field: "number", value: "4111", wantErr: true},
+		{name: "expiry short year", field: "expiry", value: "1/30", want: "01/2030"},
+		{name: "expiry long year", field: "expiry", value: "12/2031", want: "12/2031"},
+		{name: "expiry invalid month", field: "expiry", value: "13/30", wantErr: true},
+		{name: "expiry invalid format", field: "expiry", value: "2030-12", wantErr: true},
+		{name: "cvv", field: "cvv", value: "123", want: "123"},
+		{name: "cvv too long", field: "cvv", value: "12345", wantErr: true},
+		{name: "empty", field: "cvv", value: "", want: ""},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			card := NewCard()
+			f, ok := ItemFieldByName(card, tt.field)
+			require.True(t, ok)
+			*f.Value = tt.value
+			err := NormalizeItemFields(card)
+			if tt.wantErr {
+				assert.Error(t, err)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, tt.want, *f.Value)
+		})
+	}
+}
+
+func TestNormalizeItemFields(t *testing.T) {
+	identity := NewIdentity()
+	identity.FirstName = "John"
+	identity.LastName = "Doe"
+	identity.Email = " John Doe <john@example.com> "
+	require.NoError(t, NormalizeItemFields(identity))
+	assert.Equal(t, "john@example.com", identity.Email)
+	assert.Equal(t, "John Doe", identity.Metadata.Subtitle)
+
+	identity.Birthday = "01/01/1970"
+	assert.EqualError(t, NormalizeItemFields(identity), `the field "Birthday" is not a valid date, expected YYYY-MM-DD`)
+
+	wifi := NewWiFi()
+	wifi.SSID = "home"
+	wifi.Security = "wpa3"
+	require.NoError(t, NormalizeItemFields(wifi))
+	assert.Equal(t, "WPA3", wifi.Security)
+	wifi.Security = "unknown"
+	assert.Error(t, NormalizeItemFields(wifi))
+
+	api := NewAPICredential()
+	api.Endpoint = "https://api.example.com/v1"
+	require.NoError(t, NormalizeItemFields(api))
+	assert.Equal(t, "api.example.com", api.Metadata.Subtitle)
+	api.Endpoint = "example"
+	assert.Error(t, NormalizeItemFields(api))
+}
@@ -0,0 +1,70 @@
+// SPDX-FileCopyrightText: 2025 Luca Corbo, Paw contributors
+// SPDX-License-Identifier: AGPL-3.0-or-later
+
+package paw
+
+import "strings"
+
+func init() {
+	RegisterItemType(IdentityItemType, "identity", "Identity", func() Item { return NewIdentity() })
+}
+
+// Declare conformity to Item interface
+var _ Item = (*Identity)(nil)
+var _ CustomFielder = (*Identity)(nil)
+var _ FieldsItem = (*Identity)(nil)
+
+// Identity represents the personal details and the address of a person
+type Identity struct {
+	*Metadata `json:"metadata,omitempty"`
+
+	FirstName  string `json:"first_name,omitempty"`
+	LastName   string `json:"last_name,omitempty"`
+	Birthday   string `json:"birthday,omitempty"`
+	Email      string `json:"email,omitempty"`
+	Phone      string `json:"phone,omitempty"`
+	Company    string `json:"company,omitempty"`
+	Address    string `json:"address,omitempty"`
+	City       string `json:"city,omitempty"`
+	Region     string `json:"region,omitempty"`
+	PostalCode string `json:"postal_code,omitempty"`
+	Country    string `json:"country,omitempty"`
+	Notes      string `json:"notes,omitempty"`
+	// Fields holds the custom fields
+	Fields CustomFields `json:"fields,omitempty"`
+}
+
+// NewIdentity returns a new identity
+func NewIdentity() *Identity {
+	return &Identity{
+		Metadata: newMetadata(IdentityItemType),
+	}
+}
+
+// GetCustomFields implements CustomFielder.
+func (i *Identity) GetCustomFields() *CustomFields {
+	return &i.Fields
+}
+
+// ItemFields implements FieldsItem.
+func (i *Identity) ItemFields() []*ItemField {
+	return []*ItemField{
+		{Name: "first_name", Label: "First name", Type: TextCustomField, Value: &i.FirstName},
+		{Name: "last_name", Label: "Last name", Type: TextCustomField, Value: &i.LastName},
+		{Name: "birthday", Label: "Birthday", Type: DateCustomField, Value: &i.Birthday},
+		{Name: "email", Label: "Email", Type: EmailCustomField, Value: &i.Email},
+		{Name: "phone", Label: "Phone", Type: TextCustomField, Value: &i.Phone},
+		{Name: "company", Label: "Company", Type: TextCustomField, Value: &i.Company},
+		{Name: "address", Label: "Address", Type: TextCustomField, Multiline: true, Value: &i.Address},
+		{Name: "city", Label: "City", Type: TextCustomField, Value: &i.City},
+		{Name: "region", Label: "Region", Type: TextCustomField, Value: &i.Region},
+		{Name: "postal_code", Label: "Postal code", Type: TextCustomField, Value: &i.PostalCode},
+		{Name: "country", Label: "Country", Type: TextCustomField, Value: &i.Country},
+		{Name: "notes", Label: "Notes", Type: TextCustomField, Multiline: true, Value: &i.Notes},
+	}
+}
+
+// Subtitle implements MetadataSubtitler.
+func (i *Identity) Subtitle() string {
+	return strings.TrimSpace(i.FirstName + " " + i.LastName)
+}
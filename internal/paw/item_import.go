@@ -22,28 +22,128 @@ func (i *Imported) UnmarshalJSON(data []byte) error {
 		return err
 	}
 	for itemType, messages := range v {
-		var t Item
+		t, err := ItemTypeFromString(itemType)
+		if err != nil {
+			return fmt.Errorf("unknown item type: %s", itemType)
+		}
 		for _, message := range messages {
-			switch itemType {
-			case NoteItemType.String():
-				t = NewNote()
-			case PasswordItemType.String():
-				t = NewPassword()
-			case LoginItemType.String():
-				t = NewLogin()
-			case SSHKeyItemType.String():
-				t = NewSSHKey()
-			default:
-				return fmt.Errorf("unknown item type: %s", itemType)
+			item, err := NewItem("", t)
+			if err != nil {
+				return err
 			}
 
-			err := json.Unmarshal(message, t)
+			err = json.Unmarshal(message, item)
 			if err != nil {
 				return err
 			}
-			i.Items = append(i.Items, t)
+			i.Items = append(i.Items, item)
 		}
 
 	}
 	return nil
 }
+
+// OnConflict defines how to handle an imported item when an item with the same
+// type and name is already present into the vault
+type OnConflict string
+
+const (
+	// OnConflictSkip does not import the item
+	OnConflictSkip OnConflict = "skip"
+	// OnConflictOverwrite replaces the existing item with the imported one
+	OnConflictOverwrite OnConflict = "overwrite"
+	// OnConflictRename imports the item using an unique name
+	OnConflictRename OnConflict = "rename"
+)
+
+// OnConflictFromString returns the conflict strategy from a string
+func OnConflictFromString(v string) (OnConflict, error) {
+	switch OnConflict(v) {
+	case OnConflictSkip, OnConflictOverwrite, OnConflictRename:
+		return OnConflict(v), nil
+	}
+	return "", fmt.Errorf("invalid conflict strategy %q, expected one of: skip, overwrite, rename", v)
+}
+
+// ImportAction represents the action to perform to import an item into the vault
+type ImportAction string
+
+const (
+	ImportCreate    ImportAction = "create"
+	ImportOverwrite ImportAction = "overwrite"
+	ImportRename    ImportAction = "rename"
+	ImportSkip      ImportAction = "skip"
+)
+
+// ImportEntry is an imported item along with the action to perform to add it into the vault
+type ImportEntry struct {
+	Item   Item
+	Action ImportAction
+	// Name is the item name as found into the imported data.
+	// It differs from the item name when the item is renamed.
+	Name string
+}
+
+// Resolve returns the entries to import into the vault resolving the name
+// conflicts according to onConflict. Items are updated in place: renamed items
+// get an unique name, overwriting items get the ID of the existing ones and all
+// the other items get a new ID if missing or already used into the vault.
+// The attachments content is not exported so the imported items have no attachments,
+// the overwriting items keep the attachments of the existing ones.
+func (i *Imported) Resolve(vault *Vault, onConflict OnConflict) []ImportEntry {
+	names := map[ItemType]map[string]*Metadata{}
+	ids := map[string]bool{}
+	vault.Range(func(id string, meta *Metadata) bool {
+		if names[meta.Type] == nil {
+			names[meta.Type] = map[string]*Metadata{}
+		}
+		names[meta.Type][meta.Name] = meta
+		ids[id] = true
+		return true
+	})
+
+	entries := make([]ImportEntry, 0, len(i.Items))
+	for _, item := range i.Items {
+		meta := item.GetMetadata()
+		entry := ImportEntry{Item: item, Name: meta.Name, Action: ImportCreate}
+		meta.Attachments = nil
+		if names[meta.Type] == nil {
+			names[meta.Type] = map[string]*Metadata{}
+		}
+
+		if existing, ok := names[meta.Type][meta.Name]; ok {
+			switch onConflict {
+			case OnConflictOverwrite:
+				entry.Action = ImportOverwrite
+				meta.UUID = existing.UUID
+				meta.Attachments = existing.Attachments
+			case OnConflictRename:
+				entry.Action = ImportRename
+				meta.Name = uniqueName(names[meta.Type], meta.Name)
+			default:
+				entry.Action = ImportSkip
+				entries = append(entries, entry)
+				continue
+			}
+		}
+
+		if entry.Action != ImportOverwrite && (meta.UUID == "" || ids[meta.UUID]) {
+			meta.UUID = newUUID()
+		}
+
+		names[meta.Type][meta.Name] = meta
+		ids[meta.ID()] = true
+		entries = append(entries, entry)
+	}
+	return entries
+}
+
+// uniqueName returns a name not present in names appending a counter to name
+func uniqueName(names map[string]*Metadata, name string) string {
+	for n := 1; ; n++ {
+		v := fmt.Sprintf("%s (%d)", name, n)
+		if _, ok := names[v]; !ok {
+			return v
+		}
+	}
+}
@@ -0,0 +1,96 @@
+// SPDX-FileCopyrightText: 2025 Luca Corbo, Paw contributors
+// SPDX-License-Identifier: AGPL-3.0-or-later
+
+package paw
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestImported_Resolve(t *testing.T) {
+	makeNote := func(name string) *Note {
+		note := NewNote()
+		note.Name = name
+		return note
+	}
+
+	tests := []struct {
+		name       string
+		onConflict OnConflict
+		want       []ImportAction
+		wantNames  []string
+	}{
+		{
+			name:       "skip",
+			onConflict: OnConflictSkip,
+			want:       []ImportAction{ImportSkip, ImportCreate},
+			wantNames:  []string{"existing", "new"},
+		},
+		{
+			name:       "overwrite",
+			onConflict: OnConflictOverwrite,
+			want:       []ImportAction{ImportOverwrite, ImportCreate},
+			wantNames:  []string{"existing", "new"},
+		},
+		{
+			name:       "rename",
+			onConflict: OnConflictRename,
+			want:       []ImportAction{ImportRename, ImportCreate},
+			wantNames:  []string{"existing (2)", "new"},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			existing := makeNote("existing")
+			v := NewVault(nil, "test")
+			v.AddItem(existing)
+			v.AddItem(makeNote("existing (1)"))
+
+			imported := &Imported{Items: []Item{makeNote("existing"), makeNote("new")}}
+			entries := imported.Resolve(v, tt.onConflict)
+			require.Len(t, entries, len(tt.want))
+			for i, entry := range entries {
+				assert.Equal(t, tt.want[i], entry.Action)
+				assert.Equal(t, tt.wantNames[i], entry.Item.GetMetadata().Name)
+			}
+			if tt.onConflict == OnConflictOverwrite {
+				assert.Equal(t, existing.ID(), entries[0].Item.ID())
+			}
+		})
+	}
+}
+
+func TestImported_ResolveAssignsID(t *testing.T) {
+	note := NewNote()
+	note.Name = "note"
+	v := NewVault(nil, "test")
+	v.AddItem(note)
+
+	// same ID of an item into the vault but different name, i.e. renamed after the export
+	sameID := NewNote()
+	sameID.UUID = note.UUID
+	sameID.Name = "renamed note"
+
+	// item exported before the UUID introduction
+	legacy := NewNote()
+	legacy.UUID = ""
+	legacy.Name = "legacy note"
+
+	data, err := json.Marshal(map[string][]Item{NoteItemType.String(): {sameID, legacy}})
+	require.NoError(t, err)
+
+	imported := &Imported{}
+	require.NoError(t, json.Unmarshal(data, imported))
+
+	entries := imported.Resolve(v, OnConflictSkip)
+	require.Len(t, entries, 2)
+	for _, entry := range entries {
+		assert.Equal(t, ImportCreate, entry.Action)
+		assert.NotEmpty(t, entry.Item.GetMetadata().UUID)
+		assert.NotEqual(t, note.ID(), entry.Item.ID())
+	}
+}
@@ -0,0 +1,62 @@
+// SPDX-FileCopyrightText: 2025 Luca Corbo, Paw contributors
+// SPDX-License-Identifier: AGPL-3.0-or-later
+
+package paw
+
+import "strings"
+
+func init() {
+	RegisterItemType(LicenseItemType, "license", "Software license", func() Item { return NewLicense() })
+}
+
+// Declare conformity to Item interface
+var _ Item = (*License)(nil)
+var _ CustomFielder = (*License)(nil)
+var _ FieldsItem = (*License)(nil)
+
+// License represents a software license
+type License struct {
+	*Metadata `json:"metadata,omitempty"`
+
+	Product    string `json:"product,omitempty"`
+	Version    string `json:"version,omitempty"`
+	LicenseKey string `json:"license_key,omitempty"`
+	LicensedTo string `json:"licensed_to,omitempty"`
+	Email      string `json:"email,omitempty"`
+	Purchased  string `json:"purchased,omitempty"`
+	Expires    string `json:"expires,omitempty"`
+	Notes      string `json:"notes,omitempty"`
+	// Fields holds the custom fields
+	Fields CustomFields `json:"fields,omitempty"`
+}
+
+// NewLicense returns a new software license
+func NewLicense() *License {
+	return &License{
+		Metadata: newMetadata(LicenseItemType),
+	}
+}
+
+// GetCustomFields implements CustomFielder.
+func (i *License) GetCustomFields() *CustomFields {
+	return &i.Fields
+}
+
+// ItemFields implements FieldsItem.
+func (i *License) ItemFields() []*ItemField {
+	return []*ItemField{
+		{Name: "product", Label: "Product", Type: TextCustomField, Value: &i.Product},
+		{Name: "version", Label: "Version", Type: TextCustomField, Value: &i.Version},
+		{Name: "license_key", Label: "License key", Type: HiddenCustomField, Multiline: true, Value: &i.LicenseKey},
+		{Name: "licensed_to", Label: "Licensed to", Type: TextCustomField, Value: &i.LicensedTo},
+		{Name: "email", Label: "Email", Type: EmailCustomField, Value: &i.Email},
+		{Name: "purchased", Label: "Purchased", Type: DateCustomField, Value: &i.Purchased},
+		{Name: "expires", Label: "Expires", Type: DateCustomField, Value: &i.Expires},
+		{Name: "notes", Label: "Notes", Type: TextCustomField, Multiline: true, Value: &i.Notes},
+	}
+}
+
+// Subtitle implements MetadataSubtitler.
+func (i *License) Subtitle() string {
+	return strings.TrimSpace(i.Product + " " + i.Version)
+}
@@ -6,13 +6,17 @@ package paw
 import (
 	"encoding/json"
 	"net/url"
-	"time"
 
 	"golang.org/x/net/publicsuffix"
 )
 
+func init() {
+	RegisterItemType(LoginItemType, "login", "Login", func() Item { return NewLogin() })
+}
+
 // Declare conformity to Item interface
 var _ Item = (*Login)(nil)
+var _ CustomFielder = (*Login)(nil)
 var _ MetadataSubtitler = (*Login)(nil)
 
 type Login struct {
@@ -23,6 +27,13 @@ type Login struct {
 
 	Username string    `json:"username,omitempty"`
 	URL      *LoginURL `json:"url,omitempty"`
+	// Fields holds the custom fields
+	Fields CustomFields `json:"fields,omitempty"`
+}
+
+// GetCustomFields implements CustomFielder.
+func (l *Login) GetCustomFields() *CustomFields {
+	return &l.Fields
 }
 
 // Subtitle implements MetadataSubtitler.
@@ -31,14 +42,10 @@ func (l *Login) Subtitle() string {
 }
 
 func NewLogin() *Login {
-	now := time.Now().UTC()
+	metadata := newMetadata(LoginItemType)
+	metadata.Autofill = &Autofill{}
 	return &Login{
-		Metadata: &Metadata{
-			Type:     LoginItemType,
-			Created:  now,
-			Modified: now,
-			Autofill: &Autofill{},
-		},
+		Metadata: metadata,
 		Note:     NewNote(),
 		Password: NewPassword(),
 		TOTP:     NewDefaultTOTP(),
@@ -4,7 +4,9 @@
 package paw
 
 import (
+	"crypto/rand"
 	"encoding/hex"
+	"fmt"
 	"time"
 
 	"golang.org/x/crypto/blake2b"
@@ -17,6 +19,9 @@ type MetadataSubtitler interface {
 
 // Item represents the basic paw identity
 type Metadata struct {
+	// UUID is the item unique identifier. It is assigned on creation and does
+	// not change when the item is renamed
+	UUID string `json:"uuid,omitempty"`
 	// Name reprents the item name
 	Name string `json:"name,omitempty"`
 	// Subtitle represents the item subtitle
@@ -31,9 +36,27 @@ type Metadata struct {
 	Favicon *Favicon `json:"favicon,omitempty"`
 	// Autofill
 	Autofill *Autofill `json:"autofill,omitempty"`
+	// Attributes holds the lookup attributes of the items stored using the Secret Service API
+	Attributes map[string]string `json:"attributes,omitempty"`
+	// Tags holds the user-defined tags, see NormalizeTags
+	Tags []string `json:"tags,omitempty"`
+	// Folder holds the folder path, the elements are separated by a slash, see NormalizeFolder
+	Folder string `json:"folder,omitempty"`
+	// Attachments holds the files attached to the item
+	Attachments []*Attachment `json:"attachments,omitempty"`
 }
 
+// ID returns the item ID used to identify the item into the vault and the storage.
+// Items created before the introduction of the UUID fallback to the legacy ID.
 func (m *Metadata) ID() string {
+	if m.UUID != "" {
+		return m.UUID
+	}
+	return m.legacyID()
+}
+
+// legacyID returns the ID computed hashing the item type and name
+func (m *Metadata) legacyID() string {
 	key := append([]byte(m.Type.String()), []byte(m.Name)...)
 	hash, err := blake2b.New256(key)
 	if err != nil {
@@ -42,6 +65,28 @@ func (m *Metadata) ID() string {
 	return hex.EncodeToString(hash.Sum(nil))
 }
 
+// newMetadata returns a new Metadata for the item type with an unique UUID
+func newMetadata(itemType ItemType) *Metadata {
+	now := time.Now().UTC()
+	return &Metadata{
+		UUID:     newUUID(),
+		Type:     itemType,
+		Created:  now,
+		Modified: now,
+	}
+}
+
+// newUUID returns a random (version 4) UUID as defined into the RFC4122
+func newUUID() string {
+	var b [16]byte
+	if _, err := rand.Read(b[:]); err != nil {
+		panic(err)
+	}
+	b[6] = (b[6] & 0x0f) | 0x40 // version 4
+	b[8] = (b[8] & 0x3f) | 0x80 // variant is 10
+	return fmt.Sprintf("%x-%x-%x-%x-%x", b[0:4], b[4:6], b[6:8], b[8:10], b[10:])
+}
+
 func (m *Metadata) GetMetadata() *Metadata {
 	return m
 }
@@ -3,25 +3,28 @@
 
 package paw
 
-import (
-	"time"
-)
+func init() {
+	RegisterItemType(NoteItemType, "note", "Note", func() Item { return NewNote() })
+}
 
 // Declare conformity to Item interface
 var _ Item = (*Note)(nil)
+var _ CustomFielder = (*Note)(nil)
 
 type Note struct {
 	Value     string `json:"value,omitempty"`
 	*Metadata `json:"metadata,omitempty"`
+	// Fields holds the custom fields
+	Fields CustomFields `json:"fields,omitempty"`
+}
+
+// GetCustomFields implements CustomFielder.
+func (n *Note) GetCustomFields() *CustomFields {
+	return &n.Fields
 }
 
 func NewNote() *Note {
-	now := time.Now().UTC()
 	return &Note{
-		Metadata: &Metadata{
-			Type:     NoteItemType,
-			Created:  now,
-			Modified: now,
-		},
+		Metadata: newMetadata(NoteItemType),
 	}
 }
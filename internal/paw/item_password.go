@@ -6,13 +6,17 @@ package paw
 import (
 	"fmt"
 	"strings"
-	"time"
 
 	"lucor.dev/paw/internal/age"
 )
 
+func init() {
+	RegisterItemType(PasswordItemType, "password", "Password", func() Item { return NewPassword() })
+}
+
 // Declare conformity to Item interface
 var _ Item = (*Password)(nil)
+var _ CustomFielder = (*Password)(nil)
 
 // Declare conformity to Seeder interface
 var _ Seeder = (*Password)(nil)
@@ -62,20 +66,25 @@ type Password struct {
 	Format Format       `json:"format,omitempty"`
 	Length int          `json:"length,omitempty"`
 	Mode   PasswordMode `json:"mode,omitempty"`
+	// History holds the previous values, the most recent first
+	History []*PasswordHistoryEntry `json:"history,omitempty"`
 
 	*Metadata `json:"metadata,omitempty"`
 	*Note     `json:"note,omitempty"`
+
+	// Fields holds the custom fields
+	Fields CustomFields `json:"fields,omitempty"`
+}
+
+// GetCustomFields implements CustomFielder.
+func (p *Password) GetCustomFields() *CustomFields {
+	return &p.Fields
 }
 
 func NewPassword() *Password {
-	now := time.Now().UTC()
 	return &Password{
-		Metadata: &Metadata{
-			Type:     PasswordItemType,
-			Created:  now,
-			Modified: now,
-		},
-		Note: NewNote(),
+		Metadata: newMetadata(PasswordItemType),
+		Note:     NewNote(),
 	}
 }
 
@@ -112,7 +121,9 @@ func NewCustomPassword() *Password {
 
 func (p *Password) Salt() []byte {
 	if p.Mode == StatelessPassword {
-		return []byte(p.ID())
+		// stateless passwords are derived from the item type and name,
+		// do not use the UUID to keep the generated values stable
+		return []byte(p.legacyID())
 	}
 	return nil
 }
@@ -0,0 +1,69 @@
+// SPDX-FileCopyrightText: 2025 Luca Corbo, Paw contributors
+// SPDX-License-Identifier: AGPL-3.0-or-later
+
+package paw
+
+import (
+	"fmt"
+	"time"
+)
+
+// PasswordHistoryMaxLength is the max number of previous values retained into the password history
+const PasswordHistoryMaxLength = 20
+
+// PasswordHistoryEntry represents a previous value of a password
+type PasswordHistoryEntry struct {
+	Value string `json:"value"`
+	// Replaced is the time the value has been replaced
+	Replaced time.Time `json:"replaced"`
+}
+
+// UpdateHistory records the previous value into the password history
+// when it differs from the current one. The most recent entry is the first
+// one and the history is bounded to PasswordHistoryMaxLength entries.
+func (p *Password) UpdateHistory(previous string, t time.Time) {
+	if previous == "" || previous == p.Value {
+		return
+	}
+	entry := &PasswordHistoryEntry{Value: previous, Replaced: t}
+	p.History = append([]*PasswordHistoryEntry{entry}, p.History...)
+	if len(p.History) > PasswordHistoryMaxLength {
+		p.History = p.History[:PasswordHistoryMaxLength]
+	}
+}
+
+// RestoreHistory restores the value of the history entry at index i.
+// The current value is recorded into the history.
+func (p *Password) RestoreHistory(i int, t time.Time) error {
+	if i < 0 || i >= len(p.History) {
+		return fmt.Errorf("password history entry %d not found", i)
+	}
+	value := p.History[i].Value
+	p.History = append(p.History[:i:i], p.History[i+1:]...)
+	previous := p.Value
+	p.Value = value
+	p.UpdateHistory(previous, t)
+	return nil
+}
+
+// TrackPasswordHistory records the password of the previous item version into
+// the history of the current one. Items without a password are ignored.
+func TrackPasswordHistory(previous Item, current Item, t time.Time) {
+	prev := itemPassword(previous)
+	cur := itemPassword(current)
+	if prev == nil || cur == nil {
+		return
+	}
+	cur.UpdateHistory(prev.Value, t)
+}
+
+// itemPassword returns the password of the item, if any
+func itemPassword(item Item) *Password {
+	switch v := item.(type) {
+	case *Password:
+		return v
+	case *Login:
+		return v.Password
+	}
+	return nil
+}
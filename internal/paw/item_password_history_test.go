@@ -0,0 +1,82 @@
+// SPDX-FileCopyrightText: 2025 Luca Corbo, Paw contributors
+// SPDX-License-Identifier: AGPL-3.0-or-later
+
+package paw
+
+import (
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func historyValues(p *Password) []string {
+	var values []string
+	for _, entry := range p.History {
+		values = append(values, entry.Value)
+	}
+	return values
+}
+
+func TestPassword_UpdateHistory(t *testing.T) {
+	now := time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC)
+
+	p := NewPassword()
+	p.Value = "first"
+	p.UpdateHistory("", now)
+	assert.Empty(t, p.History, "empty previous values are not recorded")
+
+	p.UpdateHistory("first", now)
+	assert.Empty(t, p.History, "unchanged values are not recorded")
+
+	p.Value = "second"
+	p.UpdateHistory("first", now)
+	p.Value = "third"
+	p.UpdateHistory("second", now.Add(time.Hour))
+	assert.Equal(t, []string{"second", "first"}, historyValues(p))
+	assert.Equal(t, now.Add(time.Hour), p.History[0].Replaced)
+
+	for i := 0; i < PasswordHistoryMaxLength; i++ {
+		previous := p.Value
+		p.Value = fmt.Sprintf("value-%d", i)
+		p.UpdateHistory(previous, now)
+	}
+	require.Len(t, p.History, PasswordHistoryMaxLength)
+	assert.Equal(t, fmt.Sprintf("value-%d", PasswordHistoryMaxLength-2), p.History[0].Value)
+}
+
+func TestPassword_RestoreHistory(t *testing.T) {
+	now := time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC)
+
+	p := NewPassword()
+	p.Value = "third"
+	p.History = []*PasswordHistoryEntry{
+		{Value: "second", Replaced: now},
+		{Value: "first", Replaced: now},
+	}
+
+	err := p.RestoreHistory(1, now)
+	require.NoError(t, err)
+	assert.Equal(t, "first", p.Value)
+	assert.Equal(t, []string{"third", "second"}, historyValues(p))
+
+	err = p.RestoreHistory(2, now)
+	assert.Error(t, err)
+}
+
+func TestTrackPasswordHistory(t *testing.T) {
+	now := time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC)
+
+	previous := NewLogin()
+	previous.Password.Value = "old"
+	current := NewLogin()
+	current.Password.Value = "new"
+
+	TrackPasswordHistory(previous, current, now)
+	assert.Equal(t, []string{"old"}, historyValues(current.Password))
+
+	// items without a password are ignored
+	TrackPasswordHistory(NewNote(), NewNote(), now)
+}
@@ -3,12 +3,13 @@
 
 package paw
 
-import (
-	"time"
-)
+func init() {
+	RegisterItemType(SSHKeyItemType, "ssh_key", "SSH key", func() Item { return NewSSHKey() })
+}
 
 // Declare conformity to Item interface
 var _ Item = (*SSHKey)(nil)
+var _ CustomFielder = (*SSHKey)(nil)
 
 type SSHKey struct {
 	*Metadata `json:"metadata,omitempty"`
@@ -20,6 +21,13 @@ type SSHKey struct {
 	Passphrase  *Password `json:"passphrase,omitempty"`
 	PrivateKey  string    `json:"private_key,omitempty"`
 	PublicKey   string    `json:"public_key,omitempty"`
+	// Fields holds the custom fields
+	Fields CustomFields `json:"fields,omitempty"`
+}
+
+// GetCustomFields implements CustomFielder.
+func (i *SSHKey) GetCustomFields() *CustomFields {
+	return &i.Fields
 }
 
 // Subtitle implements MetadataSubtitler.
@@ -28,13 +36,8 @@ func (i *SSHKey) Subtitle() string {
 }
 
 func NewSSHKey() *SSHKey {
-	now := time.Now().UTC()
 	return &SSHKey{
-		Metadata: &Metadata{
-			Type:     SSHKeyItemType,
-			Created:  now,
-			Modified: now,
-		},
+		Metadata:   newMetadata(SSHKeyItemType),
 		Passphrase: NewPassword(),
 		Note:       NewNote(),
 	}
@@ -0,0 +1,72 @@
+// SPDX-FileCopyrightText: 2025 Luca Corbo, Paw contributors
+// SPDX-License-Identifier: AGPL-3.0-or-later
+
+package paw
+
+import (
+	"encoding/json"
+	"os"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestItemTypes(t *testing.T) {
+	names := []string{}
+	for _, itemType := range ItemTypes() {
+		names = append(names, itemType.String())
+
+		v, err := ItemTypeFromString(itemType.String())
+		require.NoError(t, err)
+		assert.Equal(t, itemType, v)
+
+		item, err := NewItem("test", itemType)
+		require.NoError(t, err)
+		assert.Equal(t, itemType, item.GetMetadata().Type)
+		assert.Equal(t, "test", item.GetMetadata().Name)
+		assert.NotEmpty(t, item.GetMetadata().UUID)
+		assert.Implements(t, (*CustomFielder)(nil), item)
+	}
+	expected := []string{"api_credential", "card", "identity", "license", "login", "note", "password", "ssh_key", "wifi"}
+	assert.Equal(t, expected, names)
+
+	_, err := ItemTypeFromString("unknown")
+	assert.Error(t, err)
+	_, err = NewItem("test", MetadataItemType)
+	assert.Error(t, err)
+	assert.Panics(t, func() {
+		RegisterItemType(NoteItemType, "other", "Other", func() Item { return NewNote() })
+	})
+}
+
+func TestItemTypes_Storage(t *testing.T) {
+	root, err := os.MkdirTemp(os.TempDir(), "paw")
+	require.NoError(t, err)
+	defer os.RemoveAll(root)
+
+	s, err := NewOSStorageRooted(root)
+	require.NoError(t, err)
+	key, err := s.CreateVaultKey("test", "secret")
+	require.NoError(t, err)
+	vault, err := s.CreateVault("test", key)
+	require.NoError(t, err)
+
+	card := NewCard()
+	card.Name = "visa"
+	card.Number = "4111111111111111"
+	card.Expiry = "12/2030"
+	require.NoError(t, s.StoreItem(vault, card))
+
+	item, err := s.LoadItem(vault, card.Metadata)
+	require.NoError(t, err)
+	assert.Equal(t, card, item)
+
+	// exported items are imported using the registered item types
+	b, err := json.Marshal(map[string][]Item{CardItemType.String(): {card}})
+	require.NoError(t, err)
+	imported := &Imported{}
+	require.NoError(t, json.Unmarshal(b, imported))
+	require.Len(t, imported.Items, 1)
+	assert.Equal(t, card, imported.Items[0])
+}
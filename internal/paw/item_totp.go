@@ -7,7 +7,9 @@ import (
 	"crypto/sha1"
 	"crypto/sha256"
 	"crypto/sha512"
+	"errors"
 	"hash"
+	"time"
 
 	"lucor.dev/paw/internal/otp"
 )
@@ -26,11 +28,30 @@ const (
 	TOTPIntervalDefault = otp.DefaultInterval
 )
 
+// OTPType represents the one-time password algorithm
+type OTPType string
+
+const (
+	// TOTPType is the time-based one-time password as defined into the RFC6238
+	TOTPType OTPType = "totp"
+	// HOTPType is the counter-based one-time password as defined into the RFC4226
+	HOTPType OTPType = "hotp"
+)
+
 type TOTP struct {
 	Digits   int      `json:"digits,omitempty"`
 	Hash     TOTPHash `json:"hash,omitempty"`
 	Interval int      `json:"interval,omitempty"`
 	Secret   string   `json:"secret,omitempty"`
+	// OTPType is the one-time password algorithm, empty for TOTP
+	OTPType OTPType `json:"type,omitempty"`
+	// Counter is the HOTP counter of the next code to generate
+	Counter uint64 `json:"counter,omitempty"`
+}
+
+// IsHOTP reports whether the one-time password is counter-based
+func (t *TOTP) IsHOTP() bool {
+	return t.OTPType == HOTPType
 }
 
 // Hasher returns the hash function for the TOTP
@@ -45,6 +66,25 @@ func (t *TOTP) Hasher() func() hash.Hash {
 	}
 }
 
+// Code returns the TOTP code for the specified time.
+// For HOTP the code for the current counter is returned, see NextHOTPCode to
+// generate a code incrementing the counter.
+func (t *TOTP) Code(now time.Time) (string, error) {
+	if t.IsHOTP() {
+		return otp.HOTPFromBase32(t.Hasher(), t.Secret, t.Counter, t.Digits)
+	}
+	return otp.TOTPFromBase32(t.Hasher(), t.Secret, now, t.Interval, t.Digits)
+}
+
+// Remaining returns the remaining time before the code for the specified time expires
+func (t *TOTP) Remaining(now time.Time) time.Duration {
+	interval := int64(t.Interval)
+	if interval <= 0 {
+		interval = TOTPIntervalDefault
+	}
+	return time.Duration(interval-now.Unix()%interval) * time.Second
+}
+
 func NewDefaultTOTP() *TOTP {
 	return &TOTP{
 		Digits:   TOTPDigitsDefault,
@@ -52,3 +92,53 @@ func NewDefaultTOTP() *TOTP {
 		Interval: TOTPIntervalDefault,
 	}
 }
+
+// NextHOTPCode returns the HOTP code for the login counter and stores the
+// login with the incremented counter. The login is reloaded from the storage
+// before incrementing so that a counter is never used twice.
+func NextHOTPCode(s Storage, vault *Vault, login *Login) (string, error) {
+	unlock, err := LockVault(s, vault)
+	if err != nil {
+		return "", err
+	}
+	defer unlock()
+
+	item, err := s.LoadItem(vault, login.GetMetadata())
+	if err != nil {
+		return "", err
+	}
+	latest, ok := item.(*Login)
+	if !ok || latest.TOTP == nil || !latest.TOTP.IsHOTP() {
+		return "", errors.New("HOTP is not configured for the item")
+	}
+
+	code, err := latest.TOTP.Code(time.Now())
+	if err != nil {
+		return "", err
+	}
+
+	now := time.Now().UTC()
+	latest.TOTP.Counter++
+	latest.Modified = now
+	err = s.StoreItem(vault, latest)
+	if err != nil {
+		return "", err
+	}
+	err = vault.AddItem(latest)
+	if err != nil {
+		return "", err
+	}
+	vault.Modified = now
+	err = s.StoreVault(vault)
+	if err != nil {
+		return "", err
+	}
+
+	// update in place so that references to the login TOTP see the new counter
+	if login.TOTP == nil {
+		login.TOTP = &TOTP{}
+	}
+	*login.TOTP = *latest.TOTP
+	login.Modified = latest.Modified
+	return code, nil
+}
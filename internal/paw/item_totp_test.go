@@ -0,0 +1,92 @@
+// SPDX-FileCopyrightText: 2025 Luca Corbo, Paw contributors
+// SPDX-License-Identifier: AGPL-3.0-or-later
+
+package paw
+
+import (
+	"os"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestTOTP_Code(t *testing.T) {
+	// test vectors from RFC 6238
+	tests := map[string]struct {
+		totp *TOTP
+		want string
+	}{
+		"SHA1": {
+			totp: &TOTP{Hash: SHA1, Digits: 8, Interval: 30, Secret: "GEZDGNBVGY3TQOJQGEZDGNBVGY3TQOJQ"},
+			want: "94287082",
+		},
+		"SHA256": {
+			totp: &TOTP{Hash: SHA256, Digits: 8, Interval: 30, Secret: "GEZDGNBVGY3TQOJQGEZDGNBVGY3TQOJQGEZDGNBVGY3TQOJQGEZA"},
+			want: "46119246",
+		},
+	}
+	for name, tt := range tests {
+		t.Run(name, func(t *testing.T) {
+			code, err := tt.totp.Code(time.Unix(59, 0).UTC())
+			require.NoError(t, err)
+			assert.Equal(t, tt.want, code)
+		})
+	}
+}
+
+func TestTOTP_Remaining(t *testing.T) {
+	totp := &TOTP{Interval: 30}
+	assert.Equal(t, 1*time.Second, totp.Remaining(time.Unix(59, 0)))
+	assert.Equal(t, 30*time.Second, totp.Remaining(time.Unix(60, 0)))
+
+	totp = &TOTP{Interval: 45}
+	assert.Equal(t, 30*time.Second, totp.Remaining(time.Unix(60, 0)))
+}
+
+func TestNextHOTPCode(t *testing.T) {
+	root, err := os.MkdirTemp(os.TempDir(), "paw")
+	require.NoError(t, err)
+	defer os.RemoveAll(root)
+
+	storage, err := NewOSStorageRooted(root)
+	require.NoError(t, err)
+	key, err := storage.CreateVaultKey("test", "secret")
+	require.NoError(t, err)
+	vault, err := storage.CreateVault("test", key)
+	require.NoError(t, err)
+
+	login := NewLogin()
+	login.Name = "hotp"
+	// test vectors from RFC 4226
+	login.TOTP = &TOTP{Secret: "GEZDGNBVGY3TQOJQGEZDGNBVGY3TQOJQ", Hash: SHA1, Digits: 6, OTPType: HOTPType}
+	require.NoError(t, vault.AddItem(login))
+	require.NoError(t, storage.StoreItem(vault, login))
+
+	code, err := NextHOTPCode(storage, vault, login)
+	require.NoError(t, err)
+	assert.Equal(t, "755224", code)
+	assert.Equal(t, uint64(1), login.TOTP.Counter)
+
+	// a stale copy of the item must not reuse the counter
+	stale := NewLogin()
+	stale.Metadata = login.Metadata
+	stale.TOTP = &TOTP{Secret: login.TOTP.Secret, Hash: SHA1, Digits: 6, OTPType: HOTPType}
+	code, err = NextHOTPCode(storage, vault, stale)
+	require.NoError(t, err)
+	assert.Equal(t, "287082", code)
+
+	item, err := storage.LoadItem(vault, login.Metadata)
+	require.NoError(t, err)
+	assert.Equal(t, uint64(2), item.(*Login).TOTP.Counter)
+
+	// TOTP items are not supported
+	totp := NewLogin()
+	totp.Name = "totp"
+	totp.TOTP.Secret = "GEZDGNBVGY3TQOJQGEZDGNBVGY3TQOJQ"
+	require.NoError(t, vault.AddItem(totp))
+	require.NoError(t, storage.StoreItem(vault, totp))
+	_, err = NextHOTPCode(storage, vault, totp)
+	assert.Error(t, err)
+}
@@ -0,0 +1,71 @@
+// SPDX-FileCopyrightText: 2025 Luca Corbo, Paw contributors
+// SPDX-License-Identifier: AGPL-3.0-or-later
+
+package paw
+
+import (
+	"fmt"
+	"strings"
+)
+
+func init() {
+	RegisterItemType(WiFiItemType, "wifi", "Wi-Fi", func() Item { return NewWiFi() })
+}
+
+// Declare conformity to Item interface
+var _ Item = (*WiFi)(nil)
+var _ CustomFielder = (*WiFi)(nil)
+var _ FieldsItem = (*WiFi)(nil)
+
+// WiFiSecurityTypes are the supported Wi-Fi security types
+var WiFiSecurityTypes = []string{"WPA3", "WPA2", "WPA", "WEP", "None"}
+
+// WiFi represents the credential to access a Wi-Fi network
+type WiFi struct {
+	*Metadata `json:"metadata,omitempty"`
+
+	SSID     string `json:"ssid,omitempty"`
+	Password string `json:"password,omitempty"`
+	Security string `json:"security,omitempty"`
+	Notes    string `json:"notes,omitempty"`
+	// Fields holds the custom fields
+	Fields CustomFields `json:"fields,omitempty"`
+}
+
+// NewWiFi returns a new Wi-Fi network
+func NewWiFi() *WiFi {
+	return &WiFi{
+		Metadata: newMetadata(WiFiItemType),
+		Security: WiFiSecurityTypes[1],
+	}
+}
+
+// GetCustomFields implements CustomFielder.
+func (i *WiFi) GetCustomFields() *CustomFields {
+	return &i.Fields
+}
+
+// ItemFields implements FieldsItem.
+func (i *WiFi) ItemFields() []*ItemField {
+	return []*ItemField{
+		{Name: "ssid", Label: "SSID", Type: TextCustomField, Value: &i.SSID},
+		{Name: "password", Label: "Password", Type: HiddenCustomField, Value: &i.Password},
+		{Name: "security", Label: "Security", Type: TextCustomField, Options: WiFiSecurityTypes, Normalize: normalizeWiFiSecurity, Value: &i.Security},
+		{Name: "notes", Label: "Notes", Type: TextCustomField, Multiline: true, Value: &i.Notes},
+	}
+}
+
+// Subtitle implements MetadataSubtitler.
+func (i *WiFi) Subtitle() string {
+	return i.SSID
+}
+
+// normalizeWiFiSecurity returns the Wi-Fi security type matching case-insensitive the value
+func normalizeWiFiSecurity(v string) (string, error) {
+	for _, t := range WiFiSecurityTypes {
+		if strings.EqualFold(t, strings.TrimSpace(v)) {
+			return t, nil
+		}
+	}
+	return "", fmt.Errorf("expected one of: %s", strings.Join(WiFiSecurityTypes, ", "))
+}
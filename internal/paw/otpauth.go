@@ -0,0 +1,145 @@
+// SPDX-FileCopyrightText: 2025 Luca Corbo, Paw contributors
+// SPDX-License-Identifier: AGPL-3.0-or-later
+
+package paw
+
+import (
+	"encoding/base32"
+	"errors"
+	"fmt"
+	"net/url"
+	"strconv"
+	"strings"
+
+	"lucor.dev/paw/internal/otp"
+)
+
+// OTPAuthScheme is the scheme of the otpauth URIs
+const OTPAuthScheme = "otpauth"
+
+// OTPAuth represents an OTP key with its labels as exchanged using the otpauth URIs.
+// See https://github.com/google/google-authenticator/wiki/Key-Uri-Format
+type OTPAuth struct {
+	*TOTP
+	// Issuer is the provider or service the key is associated with
+	Issuer string
+	// Account is the account name the key is associated with
+	Account string
+}
+
+// ParseOTPAuth parses an otpauth URI
+func ParseOTPAuth(rawURI string) (*OTPAuth, error) {
+	u, err := url.Parse(strings.TrimSpace(rawURI))
+	if err != nil {
+		return nil, fmt.Errorf("invalid otpauth URI: %w", err)
+	}
+	if u.Scheme != OTPAuthScheme {
+		return nil, fmt.Errorf("invalid otpauth URI: unsupported scheme %q", u.Scheme)
+	}
+	o := &OTPAuth{TOTP: NewDefaultTOTP()}
+	switch OTPType(u.Host) {
+	case TOTPType:
+	case HOTPType:
+		o.OTPType = HOTPType
+	default:
+		return nil, fmt.Errorf("invalid otpauth URI: unsupported OTP type %q", u.Host)
+	}
+
+	label := strings.TrimPrefix(u.Path, "/")
+	if issuer, account, ok := strings.Cut(label, ":"); ok {
+		o.Issuer = strings.TrimSpace(issuer)
+		o.Account = strings.TrimSpace(account)
+	} else {
+		o.Account = strings.TrimSpace(label)
+	}
+
+	q := u.Query()
+	if issuer := q.Get("issuer"); issuer != "" {
+		o.Issuer = issuer
+	}
+
+	o.Secret, err = NormalizeTOTPSecret(q.Get("secret"))
+	if err != nil {
+		return nil, fmt.Errorf("invalid otpauth URI: %w", err)
+	}
+
+	if v := q.Get("algorithm"); v != "" {
+		switch hash := TOTPHash(strings.ToUpper(v)); hash {
+		case SHA1, SHA256, SHA512:
+			o.Hash = hash
+		default:
+			return nil, fmt.Errorf("invalid otpauth URI: unsupported algorithm %q", v)
+		}
+	}
+	if v := q.Get("digits"); v != "" {
+		o.Digits, err = strconv.Atoi(v)
+		if err != nil || o.Digits < otp.MinDigits || o.Digits > otp.MaxDigits {
+			return nil, fmt.Errorf("invalid otpauth URI: invalid digits %q", v)
+		}
+	}
+	if v := q.Get("period"); v != "" && !o.IsHOTP() {
+		o.Interval, err = strconv.Atoi(v)
+		if err != nil || o.Interval <= 0 {
+			return nil, fmt.Errorf("invalid otpauth URI: invalid period %q", v)
+		}
+	}
+	if o.IsHOTP() {
+		o.Counter, err = strconv.ParseUint(q.Get("counter"), 10, 64)
+		if err != nil {
+			return nil, fmt.Errorf("invalid otpauth URI: invalid counter %q", q.Get("counter"))
+		}
+	}
+	return o, nil
+}
+
+// String returns the otpauth URI
+func (o *OTPAuth) String() string {
+	label := url.PathEscape(o.Account)
+	if o.Issuer != "" {
+		label = url.PathEscape(o.Issuer) + ":" + label
+	}
+
+	q := url.Values{}
+	q.Set("secret", o.Secret)
+	if o.Issuer != "" {
+		q.Set("issuer", o.Issuer)
+	}
+	q.Set("algorithm", string(o.Hash))
+	q.Set("digits", strconv.Itoa(o.Digits))
+	otpType := TOTPType
+	if o.IsHOTP() {
+		otpType = HOTPType
+		q.Set("counter", strconv.FormatUint(o.Counter, 10))
+	} else {
+		q.Set("period", strconv.Itoa(o.Interval))
+	}
+	return fmt.Sprintf("%s://%s/%s?%s", OTPAuthScheme, otpType, label, q.Encode())
+}
+
+// NormalizeTOTPSecret returns the base32 encoded secret uppercased and without
+// spaces and padding. An error is returned if the secret is not valid.
+func NormalizeTOTPSecret(secret string) (string, error) {
+	secret = strings.ToUpper(strings.Join(strings.Fields(secret), ""))
+	secret = strings.TrimRight(secret, "=")
+	if secret == "" {
+		return "", errors.New("the secret is missing")
+	}
+	_, err := base32.StdEncoding.WithPadding(base32.NoPadding).DecodeString(secret)
+	if err != nil {
+		return "", errors.New("the secret is not a valid base32 string")
+	}
+	return secret, nil
+}
+
+// OTPAuth returns the otpauth representation of the login TOTP using the item
+// name as issuer and the username as account
+func (l *Login) OTPAuth() (*OTPAuth, error) {
+	if l.TOTP == nil || l.TOTP.Secret == "" {
+		return nil, errors.New("TOTP is not configured for the item")
+	}
+	return &OTPAuth{
+		TOTP:    l.TOTP,
+		Issuer:  l.Name,
+		Account: l.Username,
+	}, nil
+}
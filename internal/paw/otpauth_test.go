@@ -0,0 +1,124 @@
+// SPDX-FileCopyrightText: 2025 Luca Corbo, Paw contributors
+// SPDX-License-Identifier: AGPL-3.0-or-later
+
+package paw
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestParseOTPAuth(t *testing.T) {
+	tests := map[string]struct {
+		uri     string
+		want    *OTPAuth
+		wantErr bool
+	}{
+		"defaults": {
+			uri: "otpauth://totp/alice@example.com?secret=jbsw%20y3dp%20ehpk%203pxp",
+			want: &OTPAuth{
+				TOTP:    &TOTP{Secret: "JBSWY3DPEHPK3PXP", Hash: SHA1, Digits: 6, Interval: 30},
+				Account: "alice@example.com",
+			},
+		},
+		"full": {
+			uri: "otpauth://totp/ACME%20Co:john.doe@email.com?secret=HXDMVJECJJWSRB3HWIZR4IFUGFTMXBOZ&issuer=ACME%20Co&algorithm=SHA256&digits=8&period=60",
+			want: &OTPAuth{
+				TOTP:    &TOTP{Secret: "HXDMVJECJJWSRB3HWIZR4IFUGFTMXBOZ", Hash: SHA256, Digits: 8, Interval: 60},
+				Issuer:  "ACME Co",
+				Account: "john.doe@email.com",
+			},
+		},
+		"issuer parameter has precedence": {
+			uri: "otpauth://totp/Old:john?secret=JBSWY3DPEHPK3PXP&issuer=New",
+			want: &OTPAuth{
+				TOTP:    &TOTP{Secret: "JBSWY3DPEHPK3PXP", Hash: SHA1, Digits: 6, Interval: 30},
+				Issuer:  "New",
+				Account: "john",
+			},
+		},
+		"hotp": {
+			uri: "otpauth://hotp/ACME:john?secret=JBSWY3DPEHPK3PXP&counter=42",
+			want: &OTPAuth{
+				TOTP:    &TOTP{Secret: "JBSWY3DPEHPK3PXP", Hash: SHA1, Digits: 6, Interval: 30, OTPType: HOTPType, Counter: 42},
+				Issuer:  "ACME",
+				Account: "john",
+			},
+		},
+		"hotp missing counter": {
+			uri:     "otpauth://hotp/john?secret=JBSWY3DPEHPK3PXP",
+			wantErr: true,
+		},
+		"invalid scheme": {
+			uri:     "https://totp/john?secret=JBSWY3DPEHPK3PXP",
+			wantErr: true,
+		},
+		"missing secret": {
+			uri:     "otpauth://totp/john",
+			wantErr: true,
+		},
+		"invalid secret": {
+			uri:     "otpauth://totp/john?secret=not-base32!",
+			wantErr: true,
+		},
+		"invalid digits": {
+			uri:     "otpauth://totp/john?secret=JBSWY3DPEHPK3PXP&digits=20",
+			wantErr: true,
+		},
+		"too few digits": {
+			uri:     "otpauth://totp/john?secret=JBSWY3DPEHPK3PXP&digits=4",
+			wantErr: true,
+		},
+		"max digits": {
+			uri: "otpauth://totp/john?secret=JBSWY3DPEHPK3PXP&digits=10",
+			want: &OTPAuth{
+				TOTP:    &TOTP{Secret: "JBSWY3DPEHPK3PXP", Hash: SHA1, Digits: 10, Interval: 30},
+				Account: "john",
+			},
+		},
+		"invalid algorithm": {
+			uri:     "otpauth://totp/john?secret=JBSWY3DPEHPK3PXP&algorithm=MD5",
+			wantErr: true,
+		},
+	}
+	for name, tt := range tests {
+		t.Run(name, func(t *testing.T) {
+			got, err := ParseOTPAuth(tt.uri)
+			if tt.wantErr {
+				assert.Error(t, err)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, tt.want, got)
+		})
+	}
+}
+
+func TestOTPAuth_String(t *testing.T) {
+	o := &OTPAuth{
+		TOTP:    &TOTP{Secret: "JBSWY3DPEHPK3PXP", Hash: SHA512, Digits: 8, Interval: 60},
+		Issuer:  "ACME Co",
+		Account: "john.doe@email.com",
+	}
+	uri := o.String()
+	assert.Equal(t, "otpauth://totp/ACME%20Co:john.doe@email.com?algorithm=SHA512&digits=8&issuer=ACME+Co&period=60&secret=JBSWY3DPEHPK3PXP", uri)
+
+	got, err := ParseOTPAuth(uri)
+	require.NoError(t, err)
+	assert.Equal(t, o, got)
+}
+
+func TestOTPAuth_StringHOTP(t *testing.T) {
+	o := &OTPAuth{
+		TOTP:    &TOTP{Secret: "JBSWY3DPEHPK3PXP", Hash: SHA1, Digits: 6, Interval: 30, OTPType: HOTPType, Counter: 7},
+		Account: "john",
+	}
+	uri := o.String()
+	assert.Equal(t, "otpauth://hotp/john?algorithm=SHA1&counter=7&digits=6&secret=JBSWY3DPEHPK3PXP", uri)
+
+	got, err := ParseOTPAuth(uri)
+	require.NoError(t, err)
+	assert.Equal(t, o, got)
+}
@@ -88,6 +88,26 @@ func MakeKey(password string, w io.Writer) (key *Key, err error) {
 		return
 	}
 
+	key = &Key{
+		ageIdentity: ageIdentity,
+	}
+
+	ierr = StoreKey(key, password, w)
+	if ierr != nil {
+		key = nil
+		err = wrapErr(ierr)
+		return
+	}
+	return
+}
+
+// StoreKey encrypts the age secret key to w protecting it using the provided password
+func StoreKey(key *Key, password string, w io.Writer) (err error) {
+
+	wrapErr := func(err error) error {
+		return fmt.Errorf("paw: storekey error: %w", err)
+	}
+
 	ageScryptRecipient, ierr := age.NewScryptRecipient(password)
 	if ierr != nil {
 		err = wrapErr(ierr)
@@ -97,36 +117,32 @@ func MakeKey(password string, w io.Writer) (key *Key, err error) {
 	a := armor.NewWriter(w)
 	defer func() {
 		// make sure to handle the error, if any
-		if ierr := a.Close(); ierr != nil {
+		if ierr := a.Close(); ierr != nil && err == nil {
 			err = wrapErr(ierr)
 			return
 		}
 	}()
-	e, err := age.Encrypt(a, ageScryptRecipient)
-	if err != nil {
+	e, ierr := age.Encrypt(a, ageScryptRecipient)
+	if ierr != nil {
 		err = wrapErr(ierr)
 		return
 	}
 
 	data := &bytes.Buffer{}
 	fmt.Fprintf(data, "# created: %s\n", time.Now().UTC().Format(time.RFC3339))
-	fmt.Fprintf(data, "# public key: %s\n", ageIdentity.Recipient())
-	fmt.Fprintf(data, "%s\n", ageIdentity)
+	fmt.Fprintf(data, "# public key: %s\n", key.ageIdentity.Recipient())
+	fmt.Fprintf(data, "%s\n", key.ageIdentity)
 
-	_, err = e.Write(data.Bytes())
-	if err != nil {
+	_, ierr = e.Write(data.Bytes())
+	if ierr != nil {
 		err = wrapErr(ierr)
 		return
 	}
-	err = e.Close()
-	if err != nil {
+	ierr = e.Close()
+	if ierr != nil {
 		err = wrapErr(ierr)
 		return
 	}
-
-	key = &Key{
-		ageIdentity: ageIdentity,
-	}
 	return
 }
 
@@ -3,6 +3,8 @@
 
 package paw
 
+import "time"
+
 func newDefaultPreferences() *Preferences {
 	return &Preferences{
 		FaviconDownloader: FaviconDownloaderPreferences{
@@ -26,6 +28,9 @@ func newDefaultPreferences() *Preferences {
 				MinLength:     RandomPasswordMinLength,
 			},
 		},
+		Trash: TrashPreferences{
+			RetentionDays: TrashRetentionDaysDefault,
+		},
 		TOTP: TOTPPreferences{
 			Digits:   TOTPDigitsDefault,
 			Hash:     TOTPHashDefault,
@@ -37,7 +42,9 @@ func newDefaultPreferences() *Preferences {
 type Preferences struct {
 	FaviconDownloader FaviconDownloaderPreferences `json:"favicon_downloader,omitempty"`
 	Password          PasswordPreferences          `json:"password,omitempty"`
+	SecretService     SecretServicePreferences     `json:"secret_service,omitempty"`
 	TOTP              TOTPPreferences              `json:"totp,omitempty"`
+	Trash             TrashPreferences             `json:"trash,omitempty"`
 }
 
 // FaviconDownloaderPreferences represents the preferences for the favicon downloader.
@@ -46,6 +53,12 @@ type FaviconDownloaderPreferences struct {
 	Disabled bool `json:"disabled,omitempty"` // Disabled is true if the favicon downloader is disabled.
 }
 
+// SecretServicePreferences represents the preferences for the Freedesktop Secret Service provider.
+// The provider is opt-in, hence the default value is false.
+type SecretServicePreferences struct {
+	Enabled bool `json:"enabled,omitempty"` // Enabled is true if the unlocked vaults are exposed using the Secret Service API.
+}
+
 type PasswordPreferences struct {
 	Passphrase PassphrasePasswordPreferences `json:"passphrase,omitempty"`
 	Pin        PinPasswordPreferences        `json:"pin,omitempty"`
@@ -75,3 +88,18 @@ type TOTPPreferences struct {
 	Hash     TOTPHash `json:"hash,omitempty"`
 	Interval int      `json:"interval,omitempty"`
 }
+
+// TrashPreferences represents the preferences for the trash of the deleted items
+type TrashPreferences struct {
+	RetentionDays int `json:"retention_days,omitempty"` // RetentionDays is the number of days the deleted items are kept into the trash.
+}
+
+// Retention returns the retention period of the deleted items.
+// The default is returned when the preference is not set.
+func (p TrashPreferences) Retention() time.Duration {
+	days := p.RetentionDays
+	if days <= 0 {
+		days = TrashRetentionDaysDefault
+	}
+	return time.Duration(days) * 24 * time.Hour
+}
@@ -15,8 +15,13 @@ const (
 	storageRootName  = "storage"
 	keyFileName      = "key.age"
 	vaultFileName    = "vault.age"
+	syncBaseFileName = "sync.age"
+	trashDirName     = "trash"
+	conflictsDirName = "conflicts"
+	attachmentsDir   = "attachments"
 	appStateFileName = "paw.json"
 	lockFileName     = "paw.lock"
+	vaultLockExt     = ".lock"
 	logFileName      = "paw.log"
 	socketFileName   = "agent.sock"
 	namedPipe        = `\\.\pipe\paw`
@@ -27,6 +32,7 @@ type Storage interface {
 	AppStateStorage
 	VaultStorage
 	ItemStorage
+	AttachmentStorage
 	LogStorage
 	SocketAgentPath() string
 	LockFilePath() string
@@ -48,8 +54,17 @@ type VaultStorage interface {
 	LoadVault(name string, key *Key) (*Vault, error)
 	// LoadVaultKey returns the Key used to encrypt and decrypt the vault data
 	LoadVaultKey(name string, password string) (*Key, error)
+	// LockVault acquires the lock on the vault shared by all the goroutines
+	// and the processes using the storage. It serialises the read-modify-write
+	// sequences on the vault, the returned func releases the lock.
+	// The lock is not reentrant: a caller holding a vault instance acquires it
+	// using the LockVault func, so that the storage can store the vault.
+	LockVault(name string) (func(), error)
 	// StoreVault encrypts and stores the vault into the underlying storage
 	StoreVault(vault *Vault) error
+	// StoreVaultKey stores the Key used to encrypt and decrypt the vault data
+	// The file containing the key is encrypted using the provided password
+	StoreVaultKey(name string, password string, key *Key) error
 	// Vaults returns the list of vault names from the storage
 	Vaults() ([]string, error)
 }
@@ -61,6 +76,28 @@ type ItemStorage interface {
 	LoadItem(vault *Vault, itemMetadata *Metadata) (Item, error)
 	// StoreItem encrypts and encrypts and stores the item into the specified vault
 	StoreItem(vault *Vault, item Item) error
+	// TrashItem moves the item into the trash of the specified vault
+	TrashItem(vault *Vault, item Item) error
+	// RestoreItem moves the item from the trash of the specified vault
+	RestoreItem(vault *Vault, item Item) error
+	// PurgeItem deletes permanently the item from the trash of the specified vault
+	PurgeItem(vault *Vault, item Item) error
+	// LoadConflictCopy returns the item as left by the change discarded by a conflict, see Conflict
+	LoadConflictCopy(vault *Vault, itemMetadata *Metadata) (Item, error)
+	// StoreConflictCopy encrypts and stores the item as left by the change discarded by a conflict
+	StoreConflictCopy(vault *Vault, item Item) error
+	// DeleteConflictCopy deletes the conflict copy of the item, if any
+	DeleteConflictCopy(vault *Vault, itemMetadata *Metadata) error
+}
+
+type AttachmentStorage interface {
+	// DeleteAttachment deletes the attachment content from the specified vault
+	DeleteAttachment(vault *Vault, attachment *Attachment) error
+	// LoadAttachment returns a reader decrypting the attachment content from the underlying storage
+	LoadAttachment(vault *Vault, attachment *Attachment) (io.ReadCloser, error)
+	// StoreAttachment encrypts and stores the content read from r into the specified vault
+	// returning the number of bytes read
+	StoreAttachment(vault *Vault, attachment *Attachment, r io.Reader) (int64, error)
 }
 
 type LogStorage interface {
@@ -79,6 +116,12 @@ func vaultRootPath(s Storage, vaultName string) string {
 	return filepath.Join(storageRootPath(s), vaultName)
 }
 
+// vaultLockPath returns the path of the vault lock file. It is outside of the
+// vault dir to not be synced nor committed along with the vault files.
+func vaultLockPath(s Storage, vaultName string) string {
+	return filepath.Join(storageRootPath(s), vaultName+vaultLockExt)
+}
+
 func keyPath(s Storage, vaultName string) string {
 	return filepath.Join(vaultRootPath(s, vaultName), keyFileName)
 }
@@ -92,6 +135,34 @@ func itemPath(s Storage, vaultName string, itemID string) string {
 	return filepath.Join(vaultRootPath(s, vaultName), itemFileName)
 }
 
+func trashRootPath(s Storage, vaultName string) string {
+	return filepath.Join(vaultRootPath(s, vaultName), trashDirName)
+}
+
+func trashItemPath(s Storage, vaultName string, itemID string) string {
+	itemFileName := fmt.Sprintf("%s.age", itemID)
+	return filepath.Join(trashRootPath(s, vaultName), itemFileName)
+}
+
+func conflictsRootPath(s Storage, vaultName string) string {
+	return filepath.Join(vaultRootPath(s, vaultName), conflictsDirName)
+}
+
+// conflictCopyPath returns the path of the copy of the item change discarded by a conflict
+func conflictCopyPath(s Storage, vaultName string, itemID string) string {
+	itemFileName := fmt.Sprintf("%s.age", itemID)
+	return filepath.Join(conflictsRootPath(s, vaultName), itemFileName)
+}
+
+func attachmentsRootPath(s Storage, vaultName string) string {
+	return filepath.Join(vaultRootPath(s, vaultName), attachmentsDir)
+}
+
+func attachmentPath(s Storage, vaultName string, attachmentID string) string {
+	attachmentFileName := fmt.Sprintf("%s.age", attachmentID)
+	return filepath.Join(attachmentsRootPath(s, vaultName), attachmentFileName)
+}
+
 func socketAgentPath(s Storage) string {
 	if runtime.GOOS == "windows" {
 		return namedPipe
@@ -122,6 +193,23 @@ func encrypt(key *Key, w io.Writer, v interface{}) error {
 	return nil
 }
 
+// encryptStream encrypts the content read from r writing it to w. It returns the number of bytes read.
+func encryptStream(key *Key, w io.Writer, r io.Reader) (int64, error) {
+	encWriter, err := key.Encrypt(w)
+	if err != nil {
+		return 0, fmt.Errorf("could not create encrypted writer: %w", err)
+	}
+	n, err := io.Copy(encWriter, r)
+	if err != nil {
+		return n, fmt.Errorf("could not encrypt content: %w", err)
+	}
+	err = encWriter.Close()
+	if err != nil {
+		return n, fmt.Errorf("could not encrypt content: %w", err)
+	}
+	return n, nil
+}
+
 func decrypt(key *Key, r io.Reader, v interface{}) error {
 	encReader, err := key.Decrypt(r)
 	if err != nil {
@@ -134,3 +222,92 @@ func decrypt(key *Key, r io.Reader, v interface{}) error {
 	}
 	return nil
 }
+
+// migrateLegacyItemIDs assigns an UUID to the vault's items still identified by
+// the legacy type and name hash moving their files to the new location.
+// Items that cannot be loaded keep the legacy ID.
+// The migration holds the vault lock and is skipped if the lock is already
+// held by the process, e.g. loading the stored vault to merge it: the next load
+// migrates the items.
+func migrateLegacyItemIDs(s Storage, vault *Vault) error {
+	if !hasLegacyItemIDs(s, vault) {
+		return nil
+	}
+	mu := vaultMutex(vaultLockPath(s, vault.Name))
+	if !mu.TryLock() {
+		return nil
+	}
+	mu.Unlock()
+	unlock, err := s.LockVault(vault.Name)
+	if err != nil {
+		return err
+	}
+	defer unlock()
+
+	// another writer could have migrated the items in the meantime
+	stored, err := s.LoadVault(vault.Name, vault.key)
+	if err != nil {
+		return err
+	}
+	*vault = *stored
+	defer holdVaultLock(vault)()
+
+	legacy := []*Metadata{}
+	vault.Range(func(id string, meta *Metadata) bool {
+		if meta.UUID == "" {
+			legacy = append(legacy, meta)
+		}
+		return true
+	})
+
+	migrated := []*Metadata{}
+	for _, meta := range legacy {
+		item, err := s.LoadItem(vault, meta)
+		if err != nil {
+			continue
+		}
+		legacyID := meta.ID()
+		uuid := newUUID()
+		item.GetMetadata().UUID = uuid
+		err = s.StoreItem(vault, item)
+		if err != nil {
+			return fmt.Errorf("could not migrate item %q: %w", meta.Name, err)
+		}
+		meta.UUID = uuid
+		delete(vault.ItemMetadata[meta.Type], legacyID)
+		vault.ItemMetadata[meta.Type][uuid] = meta
+		migrated = append(migrated, &Metadata{Name: meta.Name, Type: meta.Type})
+	}
+
+	if len(migrated) == 0 {
+		return nil
+	}
+
+	err = s.StoreVault(vault)
+	if err != nil {
+		return fmt.Errorf("could not store the migrated vault: %w", err)
+	}
+
+	// remove the legacy item files only once the vault references the new ones
+	for _, legacyMeta := range migrated {
+		err := s.DeleteItem(vault, legacyMeta)
+		if err != nil {
+			return fmt.Errorf("could not remove the legacy item file for %q: %w", legacyMeta.Name, err)
+		}
+	}
+	return nil
+}
+
+// hasLegacyItemIDs reports whether the vault has items to migrate, see
+// migrateLegacyItemIDs. The items that cannot be loaded are not migrated.
+func hasLegacyItemIDs(s Storage, vault *Vault) bool {
+	found := false
+	vault.Range(func(id string, meta *Metadata) bool {
+		if meta.UUID == "" {
+			_, err := s.LoadItem(vault, meta)
+			found = err == nil
+		}
+		return !found
+	})
+	return found
+}
@@ -7,6 +7,7 @@ import (
 	"encoding/json"
 	"errors"
 	"fmt"
+	"io"
 	"time"
 
 	"fyne.io/fyne/v2"
@@ -57,6 +58,10 @@ func (s *FyneStorage) CreateVaultKey(name string, password string) (*Key, error)
 		return nil, fmt.Errorf("could not create the vault key file: %w", err)
 	}
 
+	err = w.Commit()
+	if err != nil {
+		return nil, fmt.Errorf("could not create the vault key file: %w", err)
+	}
 	return key, nil
 }
 
@@ -98,6 +103,7 @@ func (s *FyneStorage) LoadVaultKey(name string, password string) (*Key, error) {
 
 // LoadVault returns a vault decrypting from the underlying storage
 func (s *FyneStorage) LoadVault(name string, key *Key) (*Vault, error) {
+	recoverVault(s, name)
 	vault := NewVault(key, name)
 	vaultFile := vaultPath(s, name)
 
@@ -111,11 +117,35 @@ func (s *FyneStorage) LoadVault(name string, key *Key) (*Vault, error) {
 	if err != nil {
 		return nil, fmt.Errorf("could not read and decrypt the vault: %w", err)
 	}
+
+	err = migrateLegacyItemIDs(s, vault)
+	if err != nil {
+		return nil, err
+	}
+	vault.base = newVaultBase(vault)
 	return vault, nil
 }
 
-// StoreVault encrypts and stores the vault into the underlying storage
+// LockVault acquires the lock on the vault shared by all the goroutines and the processes using the storage
+func (s *FyneStorage) LockVault(name string) (func(), error) {
+	return lockVaultFile(vaultLockPath(s, name))
+}
+
+// StoreVault encrypts and stores the vault into the underlying storage.
+// The changes stored by the other writers since the vault has been loaded are
+// merged first, see MergeVault.
 func (s *FyneStorage) StoreVault(vault *Vault) error {
+	unlock, err := LockVault(s, vault)
+	if err != nil {
+		return err
+	}
+	defer unlock()
+
+	_, err = MergeVault(s, vault)
+	if err != nil {
+		return err
+	}
+
 	vaultFile := vaultPath(s, vault.Name)
 	w, err := s.createFile(vaultFile)
 	if err != nil {
@@ -127,6 +157,37 @@ func (s *FyneStorage) StoreVault(vault *Vault) error {
 	if err != nil {
 		return fmt.Errorf("could not encrypt and store the vault: %w", err)
 	}
+	err = w.Commit()
+	if err != nil {
+		return fmt.Errorf("could not store the vault: %w", err)
+	}
+	vault.base = newVaultBase(vault)
+	return nil
+}
+
+// StoreVaultKey stores the key used to encrypt and decrypt the vault data
+// The file containing the key is encrypted using the provided password
+func (s *FyneStorage) StoreVaultKey(name string, password string, key *Key) error {
+	keyFile := keyPath(s, name)
+	if !s.isExist(keyFile) {
+		return fmt.Errorf("key for vault %q does not exist", name)
+	}
+
+	w, err := s.createFile(keyFile)
+	if err != nil {
+		return fmt.Errorf("could not create writer for the key file: %w", err)
+	}
+	defer w.Close()
+
+	err = StoreKey(key, password, w)
+	if err != nil {
+		return fmt.Errorf("could not store the vault key file: %w", err)
+	}
+
+	err = w.Commit()
+	if err != nil {
+		return fmt.Errorf("could not replace the vault key file: %w", err)
+	}
 	return nil
 }
 
@@ -140,18 +201,95 @@ func (s *FyneStorage) DeleteItem(vault *Vault, item Item) error {
 	return nil
 }
 
+// TrashItem moves the item into the trash of the specified vault
+func (s *FyneStorage) TrashItem(vault *Vault, item Item) error {
+	err := s.mkdirIfNotExists(trashRootPath(s, vault.Name))
+	if err != nil {
+		return fmt.Errorf("could not create the trash dir: %w", err)
+	}
+	src := storage.NewFileURI(itemPath(s, vault.Name, item.ID()))
+	dst := storage.NewFileURI(trashItemPath(s, vault.Name, item.ID()))
+	err = storage.Move(src, dst)
+	if err != nil {
+		return fmt.Errorf("could not move the item to the trash: %w", err)
+	}
+	return nil
+}
+
+// RestoreItem moves the item from the trash of the specified vault
+func (s *FyneStorage) RestoreItem(vault *Vault, item Item) error {
+	src := storage.NewFileURI(trashItemPath(s, vault.Name, item.ID()))
+	dst := storage.NewFileURI(itemPath(s, vault.Name, item.ID()))
+	err := storage.Move(src, dst)
+	if err != nil {
+		return fmt.Errorf("could not restore the item from the trash: %w", err)
+	}
+	return nil
+}
+
+// PurgeItem deletes permanently the item from the trash of the specified vault
+func (s *FyneStorage) PurgeItem(vault *Vault, item Item) error {
+	trashFile := trashItemPath(s, vault.Name, item.ID())
+	if !s.isExist(trashFile) {
+		return nil
+	}
+	err := storage.Delete(storage.NewFileURI(trashFile))
+	if err != nil {
+		return fmt.Errorf("could not purge the item: %w", err)
+	}
+	return nil
+}
+
+// DeleteAttachment deletes the attachment content from the specified vault
+func (s *FyneStorage) DeleteAttachment(vault *Vault, attachment *Attachment) error {
+	attachmentFile := attachmentPath(s, vault.Name, attachment.ID)
+	if !s.isExist(attachmentFile) {
+		return nil
+	}
+	err := storage.Delete(storage.NewFileURI(attachmentFile))
+	if err != nil {
+		return fmt.Errorf("could not delete the attachment: %w", err)
+	}
+	return nil
+}
+
+// LoadAttachment returns a reader decrypting the attachment content from the underlying storage
+func (s *FyneStorage) LoadAttachment(vault *Vault, attachment *Attachment) (io.ReadCloser, error) {
+	f, err := storage.Reader(storage.NewFileURI(attachmentPath(s, vault.Name, attachment.ID)))
+	if err != nil {
+		return nil, fmt.Errorf("could not create reader: %w", err)
+	}
+	r, err := vault.key.Decrypt(f)
+	if err != nil {
+		f.Close()
+		return nil, fmt.Errorf("could not decrypt the attachment: %w", err)
+	}
+	return &readCloser{Reader: r, Closer: f}, nil
+}
+
+// StoreAttachment encrypts and stores the content read from r into the specified vault
+func (s *FyneStorage) StoreAttachment(vault *Vault, attachment *Attachment, r io.Reader) (int64, error) {
+	err := s.mkdirIfNotExists(attachmentsRootPath(s, vault.Name))
+	if err != nil {
+		return 0, fmt.Errorf("could not create the attachments dir: %w", err)
+	}
+	w, err := s.createFile(attachmentPath(s, vault.Name, attachment.ID))
+	if err != nil {
+		return 0, fmt.Errorf("could not create writer: %w", err)
+	}
+	defer w.Close()
+	n, err := encryptStream(vault.key, w, r)
+	if err != nil {
+		return n, err
+	}
+	return n, w.Commit()
+}
+
 // LoadItem returns a item from the vault decrypting from the underlying storage
 func (s *FyneStorage) LoadItem(vault *Vault, itemMetadata *Metadata) (Item, error) {
-	var item Item
-	switch itemMetadata.Type {
-	case NoteItemType:
-		item = &Note{}
-	case PasswordItemType:
-		item = &Password{}
-	case LoginItemType:
-		item = &Login{}
-	case SSHKeyItemType:
-		item = &SSHKey{}
+	item, err := emptyItem(itemMetadata.Type)
+	if err != nil {
+		return nil, err
 	}
 
 	itemFile := itemPath(s, vault.Name, itemMetadata.ID())
@@ -170,6 +308,10 @@ func (s *FyneStorage) LoadItem(vault *Vault, itemMetadata *Metadata) (Item, erro
 
 // StoreItem encrypts and encrypts and stores the item into the specified vault
 func (s *FyneStorage) StoreItem(vault *Vault, item Item) error {
+	err := keepConflictCopy(s, vault, item.ID())
+	if err != nil {
+		return err
+	}
 	itemFile := itemPath(s, vault.Name, item.ID())
 	w, err := s.createFile(itemFile)
 	if err != nil {
@@ -181,6 +323,58 @@ func (s *FyneStorage) StoreItem(vault *Vault, item Item) error {
 	if err != nil {
 		return fmt.Errorf("could not encrypt and store the item: %w", err)
 	}
+	return w.Commit()
+}
+
+// LoadConflictCopy returns the item as left by the change discarded by a conflict
+func (s *FyneStorage) LoadConflictCopy(vault *Vault, itemMetadata *Metadata) (Item, error) {
+	item, err := emptyItem(itemMetadata.Type)
+	if err != nil {
+		return nil, err
+	}
+
+	r, err := storage.Reader(storage.NewFileURI(conflictCopyPath(s, vault.Name, itemMetadata.ID())))
+	if err != nil {
+		return nil, fmt.Errorf("could not create reader: %w", err)
+	}
+	defer r.Close()
+
+	err = decrypt(vault.key, r, item)
+	if err != nil {
+		return nil, fmt.Errorf("could not read and decrypt the conflict copy: %w", err)
+	}
+	return item, nil
+}
+
+// StoreConflictCopy encrypts and stores the item as left by the change discarded by a conflict
+func (s *FyneStorage) StoreConflictCopy(vault *Vault, item Item) error {
+	err := s.mkdirIfNotExists(conflictsRootPath(s, vault.Name))
+	if err != nil {
+		return fmt.Errorf("could not create the conflicts dir: %w", err)
+	}
+	w, err := s.createFile(conflictCopyPath(s, vault.Name, item.ID()))
+	if err != nil {
+		return fmt.Errorf("could not create writer: %w", err)
+	}
+	defer w.Close()
+
+	err = encrypt(vault.key, w, item)
+	if err != nil {
+		return fmt.Errorf("could not encrypt and store the conflict copy: %w", err)
+	}
+	return w.Commit()
+}
+
+// DeleteConflictCopy deletes the conflict copy of the item, if any
+func (s *FyneStorage) DeleteConflictCopy(vault *Vault, itemMetadata *Metadata) error {
+	copyFile := conflictCopyPath(s, vault.Name, itemMetadata.ID())
+	if !s.isExist(copyFile) {
+		return nil
+	}
+	err := storage.Delete(storage.NewFileURI(copyFile))
+	if err != nil {
+		return fmt.Errorf("could not delete the conflict copy: %w", err)
+	}
 	return nil
 }
 
@@ -235,7 +429,11 @@ func (s *FyneStorage) StoreAppState(appState *AppState) error {
 		return err
 	}
 	defer w.Close()
-	return json.NewEncoder(w).Encode(appState)
+	err = json.NewEncoder(w).Encode(appState)
+	if err != nil {
+		return err
+	}
+	return w.Commit()
 }
 
 // SocketAgentPath return the socket agent path
@@ -265,6 +463,9 @@ func (s *FyneStorage) mkdirIfNotExists(path string) error {
 	return storage.CreateListable(storage.NewFileURI(path))
 }
 
-func (s *FyneStorage) createFile(name string) (fyne.URIWriteCloser, error) {
-	return storage.Writer(storage.NewFileURI(name))
+// createFile returns a writer that replaces atomically the named file once
+// committed, so that a concurrent reader or an interrupted write never leave
+// the file half-written
+func (s *FyneStorage) createFile(name string) (*atomicFile, error) {
+	return createAtomicFile(name)
 }
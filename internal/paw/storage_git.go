@@ -0,0 +1,213 @@
+// SPDX-FileCopyrightText: 2025 Luca Corbo, Paw contributors
+// SPDX-License-Identifier: AGPL-3.0-or-later
+
+package paw
+
+import (
+	"fmt"
+	"io"
+	"log"
+)
+
+// Declare conformity to Storage interface
+var _ Storage = (*GitStorage)(nil)
+
+// GitStorage wraps a Storage committing the changes of the vaults that are
+// git repositories, see InitVaultGit. The other vaults are not affected.
+// The commit messages never contain the item names, only the item type and ID.
+type GitStorage struct {
+	Storage
+}
+
+// NewGitStorage returns a Storage committing the changes of the git backed vaults
+func NewGitStorage(s Storage) Storage {
+	return &GitStorage{Storage: s}
+}
+
+// StoreVault encrypts and stores the vault into the underlying storage
+func (s *GitStorage) StoreVault(vault *Vault) error {
+	unlock, err := LockVault(s, vault)
+	if err != nil {
+		return err
+	}
+	defer unlock()
+
+	err = s.Storage.StoreVault(vault)
+	if err != nil {
+		return err
+	}
+	s.commit(vault.Name, "Update the vault index")
+	return nil
+}
+
+// StoreVaultKey stores the key used to encrypt and decrypt the vault data.
+// The caller holds the vault lock, see ChangeVaultPassword.
+// The previous key file is kept into the git history.
+func (s *GitStorage) StoreVaultKey(name string, password string, key *Key) error {
+	err := s.Storage.StoreVaultKey(name, password, key)
+	if err != nil {
+		return err
+	}
+	s.commit(name, "Update the vault key")
+	return nil
+}
+
+// DeleteItem delete the item from the specified vaultName
+func (s *GitStorage) DeleteItem(vault *Vault, item Item) error {
+	unlock, err := LockVault(s, vault)
+	if err != nil {
+		return err
+	}
+	defer unlock()
+
+	err = s.Storage.DeleteItem(vault, item)
+	if err != nil {
+		return err
+	}
+	s.commit(vault.Name, "Delete %s item %s", item.GetMetadata().Type, item.ID())
+	return nil
+}
+
+// StoreItem encrypts and stores the item into the specified vault
+func (s *GitStorage) StoreItem(vault *Vault, item Item) error {
+	unlock, err := LockVault(s, vault)
+	if err != nil {
+		return err
+	}
+	defer unlock()
+
+	err = s.Storage.StoreItem(vault, item)
+	if err != nil {
+		return err
+	}
+	s.commit(vault.Name, "Store %s item %s", item.GetMetadata().Type, item.ID())
+	return nil
+}
+
+// TrashItem moves the item into the trash of the specified vault
+func (s *GitStorage) TrashItem(vault *Vault, item Item) error {
+	unlock, err := LockVault(s, vault)
+	if err != nil {
+		return err
+	}
+	defer unlock()
+
+	err = s.Storage.TrashItem(vault, item)
+	if err != nil {
+		return err
+	}
+	s.commit(vault.Name, "Move %s item %s to the trash", item.GetMetadata().Type, item.ID())
+	return nil
+}
+
+// RestoreItem moves the item from the trash of the specified vault
+func (s *GitStorage) RestoreItem(vault *Vault, item Item) error {
+	unlock, err := LockVault(s, vault)
+	if err != nil {
+		return err
+	}
+	defer unlock()
+
+	err = s.Storage.RestoreItem(vault, item)
+	if err != nil {
+		return err
+	}
+	s.commit(vault.Name, "Restore %s item %s from the trash", item.GetMetadata().Type, item.ID())
+	return nil
+}
+
+// PurgeItem deletes permanently the item from the trash of the specified vault
+func (s *GitStorage) PurgeItem(vault *Vault, item Item) error {
+	unlock, err := LockVault(s, vault)
+	if err != nil {
+		return err
+	}
+	defer unlock()
+
+	err = s.Storage.PurgeItem(vault, item)
+	if err != nil {
+		return err
+	}
+	s.commit(vault.Name, "Purge %s item %s from the trash", item.GetMetadata().Type, item.ID())
+	return nil
+}
+
+// StoreConflictCopy encrypts and stores the item as left by the change discarded by a conflict
+func (s *GitStorage) StoreConflictCopy(vault *Vault, item Item) error {
+	unlock, err := LockVault(s, vault)
+	if err != nil {
+		return err
+	}
+	defer unlock()
+
+	err = s.Storage.StoreConflictCopy(vault, item)
+	if err != nil {
+		return err
+	}
+	s.commit(vault.Name, "Keep conflict copy of %s item %s", item.GetMetadata().Type, item.ID())
+	return nil
+}
+
+// DeleteConflictCopy deletes the conflict copy of the item, if any
+func (s *GitStorage) DeleteConflictCopy(vault *Vault, itemMetadata *Metadata) error {
+	unlock, err := LockVault(s, vault)
+	if err != nil {
+		return err
+	}
+	defer unlock()
+
+	err = s.Storage.DeleteConflictCopy(vault, itemMetadata)
+	if err != nil {
+		return err
+	}
+	s.commit(vault.Name, "Delete conflict copy of %s item %s", itemMetadata.Type, itemMetadata.ID())
+	return nil
+}
+
+// DeleteAttachment deletes the attachment content from the specified vault
+func (s *GitStorage) DeleteAttachment(vault *Vault, attachment *Attachment) error {
+	unlock, err := LockVault(s, vault)
+	if err != nil {
+		return err
+	}
+	defer unlock()
+
+	err = s.Storage.DeleteAttachment(vault, attachment)
+	if err != nil {
+		return err
+	}
+	s.commit(vault.Name, "Delete attachment %s", attachment.ID)
+	return nil
+}
+
+// StoreAttachment encrypts and stores the content read from r into the specified vault
+func (s *GitStorage) StoreAttachment(vault *Vault, attachment *Attachment, r io.Reader) (int64, error) {
+	unlock, err := LockVault(s, vault)
+	if err != nil {
+		return 0, err
+	}
+	defer unlock()
+
+	n, err := s.Storage.StoreAttachment(vault, attachment, r)
+	if err != nil {
+		return n, err
+	}
+	s.commit(vault.Name, "Store attachment %s", attachment.ID)
+	return n, nil
+}
+
+// commit commits all the changes of the vault if it is a git repository.
+// The caller holds the vault lock so that the changes of the other writers are
+// never committed half-written.
+// The changes are already stored: a failing commit, e.g. a missing git identity
+// or a failing hook, is logged and the changes are committed by the next one.
+func (s *GitStorage) commit(vaultName string, format string, a ...interface{}) {
+	dir := vaultRootPath(s, vaultName)
+	if !isGitRepository(dir) {
+		return
+	}
+	err := gitCommitAll(dir, fmt.Sprintf(format, a...))
+	if err != nil {
+		log.Printf("vault %q: could not commit the changes: %s", vaultName, err)
+	}
+}
@@ -5,6 +5,7 @@ package paw
 
 import (
 	"errors"
+	"io"
 	"os"
 	"path/filepath"
 )
@@ -12,6 +13,7 @@ import (
 var _ Storage = (*StorageMock)(nil)
 var _ AppStateStorage = (*AppStateStorageMock)(nil)
 var _ ItemStorage = (*ItemStorageMock)(nil)
+var _ AttachmentStorage = (*AttachmentStorageMock)(nil)
 var _ VaultStorage = (*VaultStorageMock)(nil)
 
 var (
@@ -22,6 +24,7 @@ type StorageMock struct {
 	AppStateStorageMock
 	VaultStorageMock
 	ItemStorageMock
+	AttachmentStorageMock
 	OnSocketAgentPath func() string
 }
 
@@ -78,8 +81,12 @@ type VaultStorageMock struct {
 	OnLoadVault func(name string, key *Key) (*Vault, error)
 	// LoadVaultKey returns the Key used to encrypt and decrypt the vault data
 	OnLoadVaultKey func(name string, password string) (*Key, error)
+	// LockVault acquires the lock on the vault shared by all the processes
+	OnLockVault func(name string) (func(), error)
 	// StoreVault encrypts and stores the vault into the underlying storage
 	OnStoreVault func(vault *Vault) error
+	// StoreVaultKey stores the Key used to encrypt and decrypt the vault data
+	OnStoreVaultKey func(name string, password string, key *Key) error
 	// Vaults returns the list of vault names from the storage
 	OnVaults func() ([]string, error)
 }
@@ -124,6 +131,14 @@ func (c *VaultStorageMock) LoadVaultKey(name string, password string) (*Key, err
 	return c.OnLoadVaultKey(name, password)
 }
 
+// LockVault implements VaultStorage.
+func (c *VaultStorageMock) LockVault(name string) (func(), error) {
+	if c.OnLockVault == nil {
+		return func() {}, nil
+	}
+	return c.OnLockVault(name)
+}
+
 // StoreVault implements VaultStorage.
 func (c *VaultStorageMock) StoreVault(vault *Vault) error {
 	if c.OnStoreVault == nil {
@@ -132,6 +147,14 @@ func (c *VaultStorageMock) StoreVault(vault *Vault) error {
 	return c.OnStoreVault(vault)
 }
 
+// StoreVaultKey implements VaultStorage.
+func (c *VaultStorageMock) StoreVaultKey(name string, password string, key *Key) error {
+	if c.OnStoreVaultKey == nil {
+		return ErrCallbackRequired
+	}
+	return c.OnStoreVaultKey(name, password, key)
+}
+
 // Vaults implements VaultStorage.
 func (c *VaultStorageMock) Vaults() ([]string, error) {
 	if c.OnVaults == nil {
@@ -147,6 +170,18 @@ type ItemStorageMock struct {
 	OnLoadItem func(vault *Vault, itemMetadata *Metadata) (Item, error)
 	// StoreItem encrypts and encrypts and stores the item into the specified vault
 	OnStoreItem func(vault *Vault, item Item) error
+	// TrashItem moves the item into the trash of the specified vault
+	OnTrashItem func(vault *Vault, item Item) error
+	// RestoreItem moves the item from the trash of the specified vault
+	OnRestoreItem func(vault *Vault, item Item) error
+	// PurgeItem deletes permanently the item from the trash of the specified vault
+	OnPurgeItem func(vault *Vault, item Item) error
+	// LoadConflictCopy returns the item as left by the change discarded by a conflict, see Conflict
+	OnLoadConflictCopy func(vault *Vault, itemMetadata *Metadata) (Item, error)
+	// StoreConflictCopy encrypts and stores the item as left by the change discarded by a conflict
+	OnStoreConflictCopy func(vault *Vault, item Item) error
+	// DeleteConflictCopy deletes the conflict copy of the item, if any
+	OnDeleteConflictCopy func(vault *Vault, itemMetadata *Metadata) error
 }
 
 // DeleteItem implements ItemStorage.
@@ -172,3 +207,84 @@ func (c *ItemStorageMock) StoreItem(vault *Vault, item Item) error {
 	}
 	return c.OnStoreItem(vault, item)
 }
+
+// TrashItem implements ItemStorage.
+func (c *ItemStorageMock) TrashItem(vault *Vault, item Item) error {
+	if c.OnTrashItem == nil {
+		return ErrCallbackRequired
+	}
+	return c.OnTrashItem(vault, item)
+}
+
+// RestoreItem implements ItemStorage.
+func (c *ItemStorageMock) RestoreItem(vault *Vault, item Item) error {
+	if c.OnRestoreItem == nil {
+		return ErrCallbackRequired
+	}
+	return c.OnRestoreItem(vault, item)
+}
+
+// PurgeItem implements ItemStorage.
+func (c *ItemStorageMock) PurgeItem(vault *Vault, item Item) error {
+	if c.OnPurgeItem == nil {
+		return ErrCallbackRequired
+	}
+	return c.OnPurgeItem(vault, item)
+}
+
+// LoadConflictCopy implements ItemStorage.
+func (c *ItemStorageMock) LoadConflictCopy(vault *Vault, itemMetadata *Metadata) (Item, error) {
+	if c.OnLoadConflictCopy == nil {
+		return nil, ErrCallbackRequired
+	}
+	return c.OnLoadConflictCopy(vault, itemMetadata)
+}
+
+// StoreConflictCopy implements ItemStorage.
+func (c *ItemStorageMock) StoreConflictCopy(vault *Vault, item Item) error {
+	if c.OnStoreConflictCopy == nil {
+		return ErrCallbackRequired
+	}
+	return c.OnStoreConflictCopy(vault, item)
+}
+
+// DeleteConflictCopy implements ItemStorage.
+func (c *ItemStorageMock) DeleteConflictCopy(vault *Vault, itemMetadata *Metadata) error {
+	if c.OnDeleteConflictCopy == nil {
+		return ErrCallbackRequired
+	}
+	return c.OnDeleteConflictCopy(vault, itemMetadata)
+}
+
+type AttachmentStorageMock struct {
+	// DeleteAttachment deletes the attachment content from the specified vault
+	OnDeleteAttachment func(vault *Vault, attachment *Attachment) error
+	// LoadAttachment returns a reader decrypting the attachment content from the underlying storage
+	OnLoadAttachment func(vault *Vault, attachment *Attachment) (io.ReadCloser, error)
+	// StoreAttachment encrypts and stores the content read from r into the specified vault
+	OnStoreAttachment func(vault *Vault, attachment *Attachment, r io.Reader) (int64, error)
+}
+
+// DeleteAttachment implements AttachmentStorage.
+func (c *AttachmentStorageMock) DeleteAttachment(vault *Vault, attachment *Attachment) error {
+	if c.OnDeleteAttachment == nil {
+		return ErrCallbackRequired
+	}
+	return c.OnDeleteAttachment(vault, attachment)
+}
+
+// LoadAttachment implements AttachmentStorage.
+func (c *AttachmentStorageMock) LoadAttachment(vault *Vault, attachment *Attachment) (io.ReadCloser, error) {
+	if c.OnLoadAttachment == nil {
+		return nil, ErrCallbackRequired
+	}
+	return c.OnLoadAttachment(vault, attachment)
+}
+
+// StoreAttachment implements AttachmentStorage.
+func (c *AttachmentStorageMock) StoreAttachment(vault *Vault, attachment *Attachment, r io.Reader) (int64, error) {
+	if c.OnStoreAttachment == nil {
+		return 0, ErrCallbackRequired
+	}
+	return c.OnStoreAttachment(vault, attachment, r)
+}
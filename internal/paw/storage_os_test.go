@@ -5,7 +5,10 @@ package paw
 
 import (
 	"os"
+	"path/filepath"
+	"runtime"
 	"testing"
+	"time"
 
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
@@ -96,3 +99,167 @@ func TestStorageOSRoundTrip(t *testing.T) {
 	assert.Equal(t, login.Name, itemWebsite.GetMetadata().Name)
 	assert.Equal(t, login.Password.Value, itemWebsite.(*Login).Password.Value)
 }
+
+func TestStorageOSMigrateLegacyItemIDs(t *testing.T) {
+	name := "test"
+	password := "secret"
+
+	root, err := os.MkdirTemp(os.TempDir(), "paw")
+	require.NoError(t, err)
+	defer os.RemoveAll(root)
+
+	storage, err := NewOSStorageRooted(root)
+	require.NoError(t, err)
+
+	key, err := storage.CreateVaultKey(name, password)
+	require.NoError(t, err)
+
+	vault, err := storage.CreateVault(name, key)
+	require.NoError(t, err)
+
+	// simulate an item created before the UUID introduction
+	note := NewNote()
+	note.UUID = ""
+	note.Name = "legacy note"
+	note.Value = "a secret note"
+	legacyID := note.ID()
+
+	err = storage.StoreItem(vault, note)
+	require.NoError(t, err)
+	err = vault.AddItem(note)
+	require.NoError(t, err)
+	err = storage.StoreVault(vault)
+	require.NoError(t, err)
+	require.FileExists(t, itemPath(storage, name, legacyID))
+
+	// the migration is skipped while the process holds the vault lock
+	unlock, err := storage.LockVault(name)
+	require.NoError(t, err)
+	lockedVault, err := storage.LoadVault(name, key)
+	unlock()
+	require.NoError(t, err)
+	assert.Contains(t, lockedVault.ItemMetadata[NoteItemType], legacyID)
+	require.FileExists(t, itemPath(storage, name, legacyID))
+
+	migratedVault, err := storage.LoadVault(name, key)
+	require.NoError(t, err)
+
+	meta, ok := migratedVault.ItemMetadataByName(NoteItemType, note.Name)
+	require.True(t, ok)
+	require.NotEmpty(t, meta.UUID)
+	assert.Equal(t, meta.UUID, meta.ID())
+	assert.NoFileExists(t, itemPath(storage, name, legacyID))
+	require.FileExists(t, itemPath(storage, name, meta.ID()))
+
+	item, err := storage.LoadItem(migratedVault, meta)
+	require.NoError(t, err)
+	assert.Equal(t, meta.UUID, item.GetMetadata().UUID)
+	assert.Equal(t, note.Value, item.(*Note).Value)
+
+	// migration is persisted
+	reloadedVault, err := storage.LoadVault(name, key)
+	require.NoError(t, err)
+	_, ok = reloadedVault.ItemMetadata[NoteItemType][meta.UUID]
+	assert.True(t, ok)
+}
+
+func TestStorageOSAtomicWrite(t *testing.T) {
+	root, err := os.MkdirTemp(os.TempDir(), "paw")
+	require.NoError(t, err)
+	defer os.RemoveAll(root)
+
+	name := filepath.Join(root, "file")
+	require.NoError(t, os.WriteFile(name, []byte("previous"), 0600))
+
+	// the file is not replaced until committed
+	w, err := createAtomicFile(name)
+	require.NoError(t, err)
+	_, err = w.WriteString("discarded")
+	require.NoError(t, err)
+	require.NoError(t, w.Close())
+	b, err := os.ReadFile(name)
+	require.NoError(t, err)
+	assert.Equal(t, "previous", string(b))
+
+	w, err = createAtomicFile(name)
+	require.NoError(t, err)
+	defer w.Close()
+	_, err = w.WriteString("committed")
+	require.NoError(t, err)
+	require.NoError(t, w.Commit())
+	b, err = os.ReadFile(name)
+	require.NoError(t, err)
+	assert.Equal(t, "committed", string(b))
+
+	// no temporary file is left
+	entries, err := os.ReadDir(root)
+	require.NoError(t, err)
+	assert.Len(t, entries, 1)
+}
+
+func TestWriteFileAtomic(t *testing.T) {
+	root := t.TempDir()
+	name := filepath.Join(root, "file")
+	require.NoError(t, os.WriteFile(name, []byte("previous"), 0644))
+
+	require.NoError(t, WriteFileAtomic(name, []byte("written")))
+	b, err := os.ReadFile(name)
+	require.NoError(t, err)
+	assert.Equal(t, "written", string(b))
+	if runtime.GOOS != "windows" {
+		fi, err := os.Stat(name)
+		require.NoError(t, err)
+		assert.Equal(t, os.FileMode(0600), fi.Mode().Perm())
+	}
+
+	entries, err := os.ReadDir(root)
+	require.NoError(t, err)
+	assert.Len(t, entries, 1)
+
+	err = WriteFileAtomic(filepath.Join(root, "missing", "file"), []byte("written"))
+	assert.Error(t, err)
+}
+
+func TestStorageOSRecoverVault(t *testing.T) {
+	root, err := os.MkdirTemp(os.TempDir(), "paw")
+	require.NoError(t, err)
+	defer os.RemoveAll(root)
+
+	s, err := NewOSStorageRooted(root)
+	require.NoError(t, err)
+	key, err := s.CreateVaultKey("test", "secret")
+	require.NoError(t, err)
+	vault, err := s.CreateVault("test", key)
+	require.NoError(t, err)
+	note := NewNote()
+	note.Name = "note"
+	require.NoError(t, s.StoreItem(vault, note))
+
+	now := time.Now()
+	stale := vaultPath(s, "test") + ".1234" + tmpFileSuffix
+	require.NoError(t, os.WriteFile(stale, []byte("age"), 0600))
+	require.NoError(t, os.Chtimes(stale, now.Add(-time.Hour), now.Add(-time.Hour)))
+	fresh := itemPath(s, "test", note.ID()) + ".5678" + tmpFileSuffix
+	require.NoError(t, os.WriteFile(fresh, []byte("age"), 0600))
+
+	// the vault is recovered on load
+	_, err = s.LoadVault("test", key)
+	require.NoError(t, err)
+	assert.NoFileExists(t, stale)
+	assert.FileExists(t, fresh)
+
+	// the files left half-written are reported
+	b, err := os.ReadFile(vaultPath(s, "test"))
+	require.NoError(t, err)
+	require.NoError(t, os.WriteFile(vaultPath(s, "test"), b[:len(ageHeader)+10], 0600))
+	require.NoError(t, os.WriteFile(itemPath(s, "test", note.ID()), nil, 0600))
+	b, err = os.ReadFile(keyPath(s, "test"))
+	require.NoError(t, err)
+	require.NoError(t, os.WriteFile(keyPath(s, "test"), b[:len(b)/2], 0600))
+
+	errs := recoverVaultFiles(vaultRootPath(s, "test"), now)
+	require.Len(t, errs, 3)
+	for _, err := range errs {
+		assert.ErrorContains(t, err, "may be corrupted")
+	}
+}
@@ -0,0 +1,685 @@
+// SPDX-FileCopyrightText: 2025 Luca Corbo, Paw contributors
+// SPDX-License-Identifier: AGPL-3.0-or-later
+
+package paw
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"path"
+	"sort"
+	"time"
+)
+
+// SyncState represents the synchronisation state of a vault
+type SyncState struct {
+	// Remote is the remote location, see NewRemote
+	Remote string `json:"remote,omitempty"`
+	// Synced is the time of the last successful synchronisation
+	Synced time.Time `json:"synced,omitempty"`
+	// Error holds the error of the last synchronisation, if failed
+	Error string `json:"error,omitempty"`
+}
+
+// SyncResult reports the changes applied by a synchronisation
+type SyncResult struct {
+	// Pulled lists the items updated from the remote
+	Pulled []*Metadata
+	// Pushed lists the items updated into the remote
+	Pushed []*Metadata
+	// Conflicts lists the items changed both locally and remotely, see Conflict
+	Conflicts []*Conflict
+	// Synced is the synchronisation time
+	Synced time.Time
+}
+
+// syncEntry represents the state of an item into a vault index
+type syncEntry struct {
+	meta *Metadata
+	// deleted is the deletion time for the items into the trash
+	deleted time.Time
+}
+
+func (e *syncEntry) trashed() bool {
+	return !e.deleted.IsZero()
+}
+
+// modified returns the time of the last change, the deletion time for the items into the trash
+func (e *syncEntry) modified() time.Time {
+	if e.trashed() {
+		return e.deleted
+	}
+	return e.meta.Modified
+}
+
+// fileName returns the remote file name of the item
+func (e *syncEntry) fileName(vaultName string) string {
+	name := e.meta.ID() + ".age"
+	if e.trashed() {
+		return path.Join(vaultName, trashDirName, name)
+	}
+	return path.Join(vaultName, name)
+}
+
+// syncEntries returns the items and the tombstones of the vault by ID
+func syncEntries(v *Vault) map[string]*syncEntry {
+	entries := map[string]*syncEntry{}
+	v.Range(func(id string, meta *Metadata) bool {
+		entries[id] = &syncEntry{meta: meta}
+		return true
+	})
+	for id, tombstone := range v.Trash {
+		entries[id] = &syncEntry{meta: tombstone.Metadata, deleted: tombstone.Deleted}
+	}
+	return entries
+}
+
+// syncMerge represents the merge of the local and remote states of an item
+type syncMerge struct {
+	id string
+	// win is the merged state, nil if the item has been purged
+	win  *syncEntry
+	lose *syncEntry
+	// pull reports whether the remote state wins
+	pull bool
+	// changed reports whether the local and remote states differ
+	changed bool
+	// conflict reports whether both the states changed since the base
+	conflict bool
+}
+
+// sameSyncEntry reports whether the entries represent the same item state
+func sameSyncEntry(a *syncEntry, b *syncEntry) bool {
+	if a == nil || b == nil {
+		return a == b
+	}
+	return a.trashed() == b.trashed() && a.modified().Equal(b.modified())
+}
+
+// mergeSyncEntries merges the local and remote entries by ID using the base
+// entries as common ancestor: an item changed only on a side takes that state,
+// purges included. An item changed on both sides is a conflict: the most recent
+// change wins, an item present wins over a purged one and on equal times the
+// local state is kept.
+// A nil base merges comparing only the modification times, the purges cannot
+// be detected and no conflict is reported.
+func mergeSyncEntries(base map[string]*syncEntry, local map[string]*syncEntry, remote map[string]*syncEntry) []*syncMerge {
+	ids := make([]string, 0, len(local)+len(remote))
+	for id := range local {
+		ids = append(ids, id)
+	}
+	for id := range remote {
+		if _, ok := local[id]; !ok {
+			ids = append(ids, id)
+		}
+	}
+	sort.Strings(ids)
+
+	merges := make([]*syncMerge, 0, len(ids))
+	for _, id := range ids {
+		l, r := local[id], remote[id]
+		if sameSyncEntry(l, r) {
+			merges = append(merges, &syncMerge{id: id, win: l})
+			continue
+		}
+
+		if base != nil && sameSyncEntry(base[id], r) {
+			// changed only locally
+			merges = append(merges, &syncMerge{id: id, win: l, lose: r, changed: true})
+			continue
+		}
+		if base != nil && sameSyncEntry(base[id], l) {
+			// changed only remotely
+			merges = append(merges, &syncMerge{id: id, win: r, lose: l, pull: true, changed: true})
+			continue
+		}
+
+		m := &syncMerge{id: id, changed: true, conflict: base != nil}
+		if r == nil || (l != nil && !r.modified().After(l.modified())) {
+			m.win, m.lose = l, r
+		} else {
+			m.win, m.lose, m.pull = r, l, true
+		}
+		merges = append(merges, m)
+	}
+	return merges
+}
+
+// setSyncEntries replaces the items and the tombstones of the vault with the merged ones
+func setSyncEntries(v *Vault, merges []*syncMerge) {
+	v.ItemMetadata = make(map[ItemType]map[string]*Metadata)
+	v.Trash = nil
+	for _, m := range merges {
+		e := m.win
+		if e == nil {
+			continue
+		}
+		if e.trashed() {
+			if v.Trash == nil {
+				v.Trash = make(map[string]*Tombstone)
+			}
+			v.Trash[m.id] = &Tombstone{Metadata: e.meta, Deleted: e.deleted}
+			continue
+		}
+		if v.ItemMetadata[e.meta.Type] == nil {
+			v.ItemMetadata[e.meta.Type] = make(map[string]*Metadata)
+		}
+		v.ItemMetadata[e.meta.Type][m.id] = e.meta
+	}
+}
+
+// syncDeletion deletes an outdated file once the vault indexes are stored
+type syncDeletion func(ctx context.Context) error
+
+// remoteDeletion returns the deletion of the named file from the remote
+func remoteDeletion(remote Remote, name string) syncDeletion {
+	return func(ctx context.Context) error {
+		return remote.Delete(ctx, name)
+	}
+}
+
+// syncBase represents the vault index at the last synchronisation with a
+// remote, it is the common ancestor to merge the local and remote changes
+type syncBase struct {
+	Remote string `json:"remote"`
+	Vault  *Vault `json:"vault"`
+}
+
+// SyncVault synchronises the vault with the remote storing the merged vault
+// both into the storage and into the remote.
+// Items are merged by ID using the vault as of the last synchronisation as
+// common ancestor: the changes made only on a side are applied to the other,
+// the items changed on both sides are merged comparing the modification time,
+// or the deletion time for the items into the trash. The most recent change
+// wins and the discarded one is recorded as a conflict, see MergeVault.
+// Files are transferred encrypted hence the remote vault must use the same key.
+// The local changes are written through the storage, e.g. committed by GitStorage.
+func SyncVault(ctx context.Context, s Storage, vault *Vault, remote Remote) (*SyncResult, error) {
+	unlock, err := LockVault(s, vault)
+	if err != nil {
+		return nil, err
+	}
+	defer unlock()
+
+	// the local files are read from the storage dir and written through the storage
+	local := NewDirRemote(storageRootPath(s))
+	result := &SyncResult{Synced: time.Now().UTC()}
+
+	remoteFound := true
+	remoteVault, err := loadRemoteVault(ctx, remote, vault)
+	if errors.Is(err, ErrRemoteNotFound) {
+		// first synchronisation, push the key to allow to synchronise the vault from other devices
+		err = copyRemoteFile(ctx, local, remote, path.Join(vault.Name, keyFileName))
+		if err != nil {
+			return nil, err
+		}
+		remoteFound = false
+		remoteVault = NewVault(vault.key, vault.Name)
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	var base *vaultBase
+	if remoteFound {
+		base, err = loadSyncBase(ctx, local, vault, remote)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	// the vault is restored on failure, the merged one references files not yet transferred
+	prev := *vault
+	merges, conflicts := vault.merge(base, remoteVault, result.Synced)
+	result.Conflicts = conflicts
+	deletions, err := syncFiles(ctx, s, local, remote, vault, merges, result)
+	if err != nil {
+		*vault = prev
+		return nil, err
+	}
+	d, err := syncConflictCopies(ctx, s, remote, vault, &prev, remoteVault, conflicts)
+	if err != nil {
+		*vault = prev
+		return nil, err
+	}
+	deletions = append(deletions, d...)
+
+	// the indexes are stored once all the files are transferred and before
+	// deleting the outdated ones, an interrupted sync never leaves an index
+	// referencing missing files
+	if len(result.Pulled) > 0 || !sameConflicts(&prev, vault) {
+		vault.Modified = result.Synced
+		err = s.StoreVault(vault)
+		if err != nil {
+			*vault = prev
+			return nil, err
+		}
+	}
+	if len(result.Pushed) > 0 || !remoteFound || !sameConflicts(remoteVault, vault) {
+		err = storeRemoteVault(ctx, remote, vault)
+		if err != nil {
+			return nil, err
+		}
+	}
+	err = storeSyncBase(ctx, local, vault, remote)
+	if err != nil {
+		return nil, err
+	}
+	for _, d := range deletions {
+		err = d(ctx)
+		if err != nil {
+			return nil, err
+		}
+	}
+	return result, nil
+}
+
+// syncFiles transfers the files of the changed items to the side losing the
+// merge, reporting the items into the result. It returns the outdated files
+// to delete.
+func syncFiles(ctx context.Context, s Storage, local Remote, remote Remote, vault *Vault, merges []*syncMerge, result *SyncResult) ([]syncDeletion, error) {
+	deletions := []syncDeletion{}
+	for _, m := range merges {
+		if !m.changed {
+			continue
+		}
+		if m.conflict && m.win != nil && m.lose != nil && !m.lose.trashed() {
+			err := syncKeepConflictCopy(ctx, s, remote, vault, m)
+			if err != nil {
+				return nil, err
+			}
+		}
+		// a nil win represents an item purged, reported by the metadata on the other side
+		var meta *Metadata
+		if m.win != nil {
+			meta = m.win.meta
+		} else {
+			meta = m.lose.meta
+		}
+		if m.pull {
+			d, err := syncPull(ctx, s, remote, vault, m.win, m.lose)
+			if err != nil {
+				return nil, err
+			}
+			deletions = append(deletions, d...)
+			result.Pulled = append(result.Pulled, meta)
+			continue
+		}
+		d, err := syncPush(ctx, local, remote, vault.Name, m.win, m.lose)
+		if err != nil {
+			return nil, err
+		}
+		deletions = append(deletions, d...)
+		result.Pushed = append(result.Pushed, meta)
+	}
+	return deletions, nil
+}
+
+// syncPull stores the item and its attachments pulled from the remote through
+// the storage returning the outdated local files to delete.
+// A nil win represents a purged item: all its local files are deleted.
+func syncPull(ctx context.Context, s Storage, remote Remote, vault *Vault, win *syncEntry, lose *syncEntry) ([]syncDeletion, error) {
+	deletions := []syncDeletion{}
+
+	stored := false
+	winAttachments := map[string]*Attachment{}
+	if win != nil {
+		item, err := loadRemoteItem(ctx, remote, vault, win.fileName(vault.Name), win.meta.Type)
+		// the file of a trashed item could be already purged
+		if err != nil && !(win.trashed() && errors.Is(err, ErrRemoteNotFound)) {
+			return nil, err
+		}
+		if err == nil {
+			err = s.StoreItem(vault, item)
+			if err != nil {
+				return nil, err
+			}
+			stored = true
+			if win.trashed() {
+				deletions = append(deletions, func(ctx context.Context) error {
+					return s.TrashItem(vault, item)
+				})
+			}
+		}
+		for _, a := range win.meta.Attachments {
+			winAttachments[a.ID] = a
+		}
+	}
+
+	loseAttachments := map[string]*Attachment{}
+	if lose != nil {
+		tombstone := &Tombstone{Metadata: lose.meta}
+		switch {
+		case win != nil && win.trashed() && stored:
+			// the stored file is moved into the trash replacing the outdated one
+		case win != nil && win.trashed() == lose.trashed():
+			// the file is replaced or, if already purged, kept
+		case lose.trashed():
+			deletions = append(deletions, func(ctx context.Context) error {
+				return s.PurgeItem(vault, tombstone)
+			})
+		default:
+			deletions = append(deletions, func(ctx context.Context) error {
+				return s.DeleteItem(vault, tombstone)
+			})
+		}
+		for _, a := range lose.meta.Attachments {
+			loseAttachments[a.ID] = a
+		}
+	}
+
+	for id, a := range winAttachments {
+		if _, ok := loseAttachments[id]; ok {
+			// attachments are immutable, the content is already there
+			continue
+		}
+		err := pullAttachment(ctx, s, remote, vault, a)
+		if err != nil {
+			return nil, err
+		}
+	}
+	for id, a := range loseAttachments {
+		if _, ok := winAttachments[id]; ok {
+			continue
+		}
+		a := a
+		deletions = append(deletions, func(ctx context.Context) error {
+			return s.DeleteAttachment(vault, a)
+		})
+	}
+	return deletions, nil
+}
+
+// pullAttachment stores the attachment content pulled from the remote
+func pullAttachment(ctx context.Context, s Storage, remote Remote, vault *Vault, attachment *Attachment) error {
+	rc, err := remote.Get(ctx, path.Join(vault.Name, attachmentsDir, attachment.ID+".age"))
+	if err != nil {
+		return err
+	}
+	defer rc.Close()
+	r, err := vault.key.Decrypt(rc)
+	if err != nil {
+		return fmt.Errorf("could not decrypt the remote attachment: %w", err)
+	}
+	_, err = s.StoreAttachment(vault, attachment, r)
+	return err
+}
+
+// syncPush copies the item file and its attachments from the local dir to the
+// remote returning the outdated remote files to delete.
+// A nil win represents a purged item: all its files are deleted from the remote.
+func syncPush(ctx context.Context, local Remote, remote Remote, vaultName string, win *syncEntry, lose *syncEntry) ([]syncDeletion, error) {
+	deletions := []syncDeletion{}
+
+	winAttachments := map[string]bool{}
+	if win != nil {
+		err := copyRemoteFile(ctx, local, remote, win.fileName(vaultName))
+		// the file of a trashed item could be already purged
+		if err != nil && !(win.trashed() && errors.Is(err, ErrRemoteNotFound)) {
+			return nil, err
+		}
+		for _, a := range win.meta.Attachments {
+			winAttachments[a.ID] = true
+		}
+	}
+
+	loseAttachments := map[string]bool{}
+	if lose != nil {
+		if win == nil || lose.trashed() != win.trashed() {
+			deletions = append(deletions, remoteDeletion(remote, lose.fileName(vaultName)))
+		}
+		for _, a := range lose.meta.Attachments {
+			loseAttachments[a.ID] = true
+		}
+	}
+
+	for id := range winAttachments {
+		if loseAttachments[id] {
+			// attachments are immutable, the content is already there
+			continue
+		}
+		err := copyRemoteFile(ctx, local, remote, path.Join(vaultName, attachmentsDir, id+".age"))
+		if err != nil {
+			return nil, err
+		}
+	}
+	for id := range loseAttachments {
+		if !winAttachments[id] {
+			deletions = append(deletions, remoteDeletion(remote, path.Join(vaultName, attachmentsDir, id+".age")))
+		}
+	}
+	return deletions, nil
+}
+
+// syncKeepConflictCopy stores the item of the losing side as conflict copy on
+// both the sides, before it is overwritten by the winning one
+func syncKeepConflictCopy(ctx context.Context, s Storage, remote Remote, vault *Vault, m *syncMerge) error {
+	var item Item
+	var err error
+	if m.pull {
+		item, err = s.LoadItem(vault, m.lose.meta)
+	} else {
+		item, err = loadRemoteItem(ctx, remote, vault, m.lose.fileName(vault.Name), m.lose.meta.Type)
+	}
+	if err == nil {
+		err = s.StoreConflictCopy(vault, item)
+	}
+	if err == nil {
+		err = storeRemoteItem(ctx, remote, vault, conflictCopyName(vault.Name, m.id), item)
+	}
+	if err != nil {
+		return fmt.Errorf("could not keep the conflict copy: %w", err)
+	}
+	vault.Conflicts[m.id].Copy = true
+	return nil
+}
+
+// syncConflictCopies transfers the conflict copies of the conflicts recorded
+// on a side only, e.g. detected merging the changes of a local writer, and
+// returns the copies of the dismissed conflicts to delete.
+// The copies of the conflicts detected by the sync are already on both sides.
+func syncConflictCopies(ctx context.Context, s Storage, remote Remote, vault *Vault, localVault *Vault, remoteVault *Vault, detected []*Conflict) ([]syncDeletion, error) {
+	isDetected := map[*Conflict]bool{}
+	for _, c := range detected {
+		isDetected[c] = true
+	}
+	hasCopy := func(v *Vault, id string) bool {
+		c, ok := v.Conflicts[id]
+		return ok && c.Copy
+	}
+
+	for id, c := range vault.Conflicts {
+		if !c.Copy || isDetected[c] {
+			continue
+		}
+		name := conflictCopyName(vault.Name, id)
+		switch {
+		case !hasCopy(localVault, id):
+			item, err := loadRemoteItem(ctx, remote, vault, name, c.Type)
+			if errors.Is(err, ErrRemoteNotFound) {
+				// a missing copy is reported loading it, it should not prevent the sync
+				continue
+			}
+			if err == nil {
+				err = s.StoreConflictCopy(vault, item)
+			}
+			if err != nil {
+				return nil, err
+			}
+		case !hasCopy(remoteVault, id):
+			item, err := s.LoadConflictCopy(vault, c.Metadata)
+			if err != nil {
+				// a missing copy is reported loading it, it should not prevent the sync
+				continue
+			}
+			err = storeRemoteItem(ctx, remote, vault, name, item)
+			if err != nil {
+				return nil, err
+			}
+		}
+	}
+
+	deletions := []syncDeletion{}
+	for id, c := range localVault.Conflicts {
+		if _, ok := vault.Conflicts[id]; ok || !c.Copy {
+			continue
+		}
+		meta := c.Metadata
+		deletions = append(deletions, func(ctx context.Context) error {
+			return s.DeleteConflictCopy(vault, meta)
+		})
+	}
+	for id, c := range remoteVault.Conflicts {
+		if _, ok := vault.Conflicts[id]; ok || !c.Copy {
+			continue
+		}
+		deletions = append(deletions, remoteDeletion(remote, conflictCopyName(vault.Name, id)))
+	}
+	return deletions, nil
+}
+
+// conflictCopyName returns the remote file name of the conflict copy of the item
+func conflictCopyName(vaultName string, itemID string) string {
+	return path.Join(vaultName, conflictsDirName, itemID+".age")
+}
+
+// loadRemoteItem returns the item decrypting the named file from the remote
+func loadRemoteItem(ctx context.Context, remote Remote, vault *Vault, name string, itemType ItemType) (Item, error) {
+	rc, err := remote.Get(ctx, name)
+	if err != nil {
+		return nil, err
+	}
+	defer rc.Close()
+
+	item, err := emptyItem(itemType)
+	if err != nil {
+		return nil, err
+	}
+	err = decrypt(vault.key, rc, item)
+	if err != nil {
+		return nil, fmt.Errorf("could not decrypt the remote item %s: %w", name, err)
+	}
+	return item, nil
+}
+
+// storeRemoteItem encrypts and stores the item into the remote as name
+func storeRemoteItem(ctx context.Context, remote Remote, vault *Vault, name string, item Item) error {
+	buf := &bytes.Buffer{}
+	err := encrypt(vault.key, buf, item)
+	if err != nil {
+		return err
+	}
+	return remote.Put(ctx, name, buf)
+}
+
+// loadSyncBase returns the vault index as of the last synchronisation with the
+// remote, nil if the vault has never been synchronised with it
+func loadSyncBase(ctx context.Context, local Remote, vault *Vault, remote Remote) (*vaultBase, error) {
+	rc, err := local.Get(ctx, path.Join(vault.Name, syncBaseFileName))
+	if errors.Is(err, ErrRemoteNotFound) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	defer rc.Close()
+
+	sb := &syncBase{Vault: NewVault(vault.key, vault.Name)}
+	err = decrypt(vault.key, rc, sb)
+	if err != nil {
+		// e.g. encrypted with a previous key, the vault is merged without base
+		return nil, nil
+	}
+	if sb.Remote != remote.String() {
+		// the remote changed, the base does not represent its state
+		return nil, nil
+	}
+	return newVaultBase(sb.Vault), nil
+}
+
+// storeSyncBase stores the vault index as of the synchronisation with the remote
+func storeSyncBase(ctx context.Context, local Remote, vault *Vault, remote Remote) error {
+	buf := &bytes.Buffer{}
+	err := encrypt(vault.key, buf, &syncBase{Remote: remote.String(), Vault: vault})
+	if err != nil {
+		return err
+	}
+	return local.Put(ctx, path.Join(vault.Name, syncBaseFileName), buf)
+}
+
+// PullVaultKey copies the key of the vault from the remote into the storage
+// returning it once decrypted using the password.
+// It allows to synchronise a vault not yet present into the storage.
+func PullVaultKey(ctx context.Context, s Storage, remote Remote, name string, password string) (*Key, error) {
+	local := NewDirRemote(storageRootPath(s))
+	keyFile := path.Join(path.Base(remoteFileName(name)), keyFileName)
+
+	rc, err := local.Get(ctx, keyFile)
+	if err == nil {
+		rc.Close()
+		return nil, fmt.Errorf("key for vault %q already exists", name)
+	}
+
+	rc, err = remote.Get(ctx, keyFile)
+	if errors.Is(err, ErrRemoteNotFound) {
+		return nil, fmt.Errorf("%w: %q is not into the remote %s", ErrVaultNotFound, name, remote)
+	}
+	if err != nil {
+		return nil, err
+	}
+	defer rc.Close()
+	b, err := io.ReadAll(rc)
+	if err != nil {
+		return nil, fmt.Errorf("could not read the remote key: %w", err)
+	}
+
+	// ensure the password is valid before storing the key
+	key, err := LoadKey(password, bytes.NewReader(b))
+	if err != nil {
+		return nil, err
+	}
+	err = local.Put(ctx, keyFile, bytes.NewReader(b))
+	if err != nil {
+		return nil, err
+	}
+	return key, nil
+}
+
+// loadRemoteVault returns the remote vault decrypting it with the local vault key
+func loadRemoteVault(ctx context.Context, remote Remote, vault *Vault) (*Vault, error) {
+	rc, err := remote.Get(ctx, path.Join(vault.Name, vaultFileName))
+	if err != nil {
+		return nil, err
+	}
+	defer rc.Close()
+
+	remoteVault := NewVault(vault.key, vault.Name)
+	err = decrypt(vault.key, rc, remoteVault)
+	if err != nil {
+		return nil, fmt.Errorf("could not decrypt the remote vault, it could be encrypted with a different key: %w", err)
+	}
+	return remoteVault, nil
+}
+
+// storeRemoteVault encrypts and stores the vault into the remote
+func storeRemoteVault(ctx context.Context, remote Remote, vault *Vault) error {
+	buf := &bytes.Buffer{}
+	err := encrypt(vault.key, buf, vault)
+	if err != nil {
+		return err
+	}
+	return remote.Put(ctx, path.Join(vault.Name, vaultFileName), buf)
+}
+
+// copyRemoteFile copies the named file from src to dst
+func copyRemoteFile(ctx context.Context, src Remote, dst Remote, name string) error {
+	rc, err := src.Get(ctx, name)
+	if err != nil {
+		return err
+	}
+	defer rc.Close()
+	return dst.Put(ctx, name, rc)
+}
@@ -0,0 +1,333 @@
+// SPDX-FileCopyrightText: 2025 Luca Corbo, Paw contributors
+// SPDX-License-Identifier: AGPL-3.0-or-later
+
+package paw
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"os"
+	"path"
+	"path/filepath"
+	"strings"
+)
+
+// ErrRemoteNotFound is returned when a file does not exist into the remote
+var ErrRemoteNotFound = errors.New("remote file not found")
+
+// Remote represents the location where the encrypted vault files are synchronised.
+// The file names are slash separated paths relative to the storage root,
+// e.g. "my-vault/vault.age".
+type Remote interface {
+	// Get returns a reader for the named file. ErrRemoteNotFound is returned if the file does not exist
+	Get(ctx context.Context, name string) (io.ReadCloser, error)
+	// Put writes the content read from r to the named file creating the parent directories
+	Put(ctx context.Context, name string, r io.Reader) error
+	// Delete deletes the named file. Deleting a file that does not exist is not an error
+	Delete(ctx context.Context, name string) error
+	// String returns the remote location
+	String() string
+}
+
+// NewRemote returns the Remote for the specified location.
+// The location can be an absolute directory path, a file:// URL or an
+// http(s):// URL to a WebDAV-style server. The password for the HTTP remote,
+// if not specified into the URL, is read from the PAW_SYNC_PASSWORD env var.
+func NewRemote(location string) (Remote, error) {
+	if filepath.IsAbs(location) {
+		return NewDirRemote(location), nil
+	}
+	u, err := url.Parse(location)
+	if err != nil {
+		return nil, fmt.Errorf("invalid remote %q: %w", location, err)
+	}
+	switch u.Scheme {
+	case "file":
+		return NewDirRemote(filepath.FromSlash(u.Path)), nil
+	case "http", "https":
+		return NewHTTPRemote(u, nil), nil
+	}
+	return nil, fmt.Errorf("invalid remote %q: expected an absolute path, a file or an http(s) URL", location)
+}
+
+// remoteFileName cleans the name ensuring it cannot point outside the remote root
+func remoteFileName(name string) string {
+	return strings.TrimPrefix(path.Clean("/"+name), "/")
+}
+
+// Declare conformity to Remote interface
+var _ Remote = (*DirRemote)(nil)
+
+// DirRemote is a Remote storing the files into a local directory,
+// e.g. a directory shared using a file synchronisation tool or a mounted network share
+type DirRemote struct {
+	root string
+}
+
+// NewDirRemote returns a Remote storing the files into the root directory
+func NewDirRemote(root string) *DirRemote {
+	return &DirRemote{root: root}
+}
+
+func (r *DirRemote) path(name string) string {
+	return filepath.Join(r.root, filepath.FromSlash(remoteFileName(name)))
+}
+
+// Get implements Remote
+func (r *DirRemote) Get(ctx context.Context, name string) (io.ReadCloser, error) {
+	f, err := os.Open(r.path(name))
+	if errors.Is(err, os.ErrNotExist) {
+		return nil, fmt.Errorf("%w: %s", ErrRemoteNotFound, name)
+	}
+	if err != nil {
+		return nil, fmt.Errorf("could not read the remote file: %w", err)
+	}
+	return f, nil
+}
+
+// Put implements Remote
+func (r *DirRemote) Put(ctx context.Context, name string, rd io.Reader) error {
+	p := r.path(name)
+	err := os.MkdirAll(filepath.Dir(p), 0700)
+	if err != nil {
+		return fmt.Errorf("could not create the remote dir: %w", err)
+	}
+	// write to a temporary file first to not leave a partial file on failure
+	tmp := p + ".tmp"
+	f, err := os.OpenFile(tmp, os.O_RDWR|os.O_CREATE|os.O_TRUNC, 0600)
+	if err != nil {
+		return fmt.Errorf("could not create the remote file: %w", err)
+	}
+	_, err = io.Copy(f, rd)
+	if err != nil {
+		f.Close()
+		os.Remove(tmp)
+		return fmt.Errorf("could not write the remote file: %w", err)
+	}
+	err = f.Close()
+	if err != nil {
+		os.Remove(tmp)
+		return fmt.Errorf("could not write the remote file: %w", err)
+	}
+	err = os.Rename(tmp, p)
+	if err != nil {
+		os.Remove(tmp)
+		return fmt.Errorf("could not write the remote file: %w", err)
+	}
+	return nil
+}
+
+// Delete implements Remote
+func (r *DirRemote) Delete(ctx context.Context, name string) error {
+	err := os.Remove(r.path(name))
+	if err != nil && !errors.Is(err, os.ErrNotExist) {
+		return fmt.Errorf("could not delete the remote file: %w", err)
+	}
+	return nil
+}
+
+// String implements Remote
+func (r *DirRemote) String() string {
+	return r.root
+}
+
+// Declare conformity to Remote interface
+var _ Remote = (*HTTPRemote)(nil)
+
+// HTTPRemote is a Remote storing the files on a WebDAV-style HTTP server
+// using the GET, PUT, DELETE and MKCOL methods
+type HTTPRemote struct {
+	client   *http.Client
+	base     *url.URL
+	user     string
+	password string
+}
+
+// NewHTTPRemote returns a Remote storing the files under the base URL.
+// The credentials, if any, are used for the HTTP basic authentication.
+// If client is nil the http.DefaultClient is used.
+func NewHTTPRemote(base *url.URL, client *http.Client) *HTTPRemote {
+	if client == nil {
+		client = http.DefaultClient
+	}
+	u := *base
+	u.Path = strings.TrimSuffix(u.Path, "/")
+	r := &HTTPRemote{client: client, base: &u}
+	if u.User != nil {
+		r.user = u.User.Username()
+		r.password, _ = u.User.Password()
+		if r.password == "" {
+			r.password = os.Getenv(ENV_SYNC_PASSWORD)
+		}
+		// the password is never part of the remote location
+		u.User = url.User(r.user)
+	}
+	return r
+}
+
+// Get implements Remote
+func (r *HTTPRemote) Get(ctx context.Context, name string) (io.ReadCloser, error) {
+	res, err := r.do(ctx, http.MethodGet, name, nil)
+	if err != nil {
+		return nil, err
+	}
+	switch res.StatusCode {
+	case http.StatusOK:
+		return res.Body, nil
+	case http.StatusNotFound:
+		res.Body.Close()
+		return nil, fmt.Errorf("%w: %s", ErrRemoteNotFound, name)
+	}
+	res.Body.Close()
+	return nil, fmt.Errorf("could not read the remote file %s: %s", name, res.Status)
+}
+
+// Put implements Remote
+func (r *HTTPRemote) Put(ctx context.Context, name string, rd io.Reader) error {
+	// the content is buffered to be able to retry after the creation of the parent collections
+	b, err := io.ReadAll(rd)
+	if err != nil {
+		return fmt.Errorf("could not read the content to write: %w", err)
+	}
+
+	res, err := r.do(ctx, http.MethodPut, name, b)
+	if err != nil {
+		return err
+	}
+	res.Body.Close()
+	if res.StatusCode == http.StatusConflict {
+		// WebDAV servers reply with 409 when the parent collection does not exist
+		err = r.mkcol(ctx, path.Dir(remoteFileName(name)))
+		if err != nil {
+			return err
+		}
+		res, err = r.do(ctx, http.MethodPut, name, b)
+		if err != nil {
+			return err
+		}
+		res.Body.Close()
+	}
+	switch res.StatusCode {
+	case http.StatusOK, http.StatusCreated, http.StatusNoContent:
+		return nil
+	}
+	return fmt.Errorf("could not write the remote file %s: %s", name, res.Status)
+}
+
+// Delete implements Remote
+func (r *HTTPRemote) Delete(ctx context.Context, name string) error {
+	res, err := r.do(ctx, http.MethodDelete, name, nil)
+	if err != nil {
+		return err
+	}
+	res.Body.Close()
+	switch res.StatusCode {
+	case http.StatusOK, http.StatusAccepted, http.StatusNoContent, http.StatusNotFound:
+		return nil
+	}
+	return fmt.Errorf("could not delete the remote file %s: %s", name, res.Status)
+}
+
+// String implements Remote
+func (r *HTTPRemote) String() string {
+	return r.base.String()
+}
+
+// mkcol creates the collection and its parents
+func (r *HTTPRemote) mkcol(ctx context.Context, dir string) error {
+	if dir == "." || dir == "" {
+		return nil
+	}
+	err := r.mkcol(ctx, path.Dir(dir))
+	if err != nil {
+		return err
+	}
+	res, err := r.do(ctx, "MKCOL", dir+"/", nil)
+	if err != nil {
+		return err
+	}
+	res.Body.Close()
+	switch res.StatusCode {
+	case http.StatusOK, http.StatusCreated, http.StatusMethodNotAllowed:
+		// 405 is returned when the collection already exists
+		return nil
+	}
+	return fmt.Errorf("could not create the remote dir %s: %s", dir, res.Status)
+}
+
+func (r *HTTPRemote) do(ctx context.Context, method string, name string, body []byte) (*http.Response, error) {
+	u := *r.base
+	u.User = nil
+	u.Path = u.Path + "/" + remoteFileName(name)
+	if strings.HasSuffix(name, "/") {
+		u.Path += "/"
+	}
+
+	var rd io.Reader
+	if body != nil {
+		rd = bytes.NewReader(body)
+	}
+	req, err := http.NewRequestWithContext(ctx, method, u.String(), rd)
+	if err != nil {
+		return nil, fmt.Errorf("could not create the request: %w", err)
+	}
+	if r.user != "" {
+		req.SetBasicAuth(r.user, r.password)
+	}
+	res, err := r.client.Do(req)
+	if err != nil {
+		return nil, fmt.Errorf("could not connect to the remote: %w", err)
+	}
+	if res.StatusCode == http.StatusUnauthorized || res.StatusCode == http.StatusForbidden {
+		res.Body.Close()
+		return nil, fmt.Errorf("could not access the remote: %s", res.Status)
+	}
+	return res, nil
+}
+
+// NewRemoteHandler returns an HTTP handler serving the remote files using the
+// same WebDAV-style protocol understood by HTTPRemote.
+// It allows to expose a DirRemote, e.g. for testing or on a local network.
+func NewRemoteHandler(remote Remote) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		name := remoteFileName(r.URL.Path)
+		switch r.Method {
+		case http.MethodGet:
+			rc, err := remote.Get(r.Context(), name)
+			if errors.Is(err, ErrRemoteNotFound) {
+				http.Error(w, err.Error(), http.StatusNotFound)
+				return
+			}
+			if err != nil {
+				http.Error(w, err.Error(), http.StatusInternalServerError)
+				return
+			}
+			defer rc.Close()
+			io.Copy(w, rc)
+		case http.MethodPut:
+			err := remote.Put(r.Context(), name, r.Body)
+			if err != nil {
+				http.Error(w, err.Error(), http.StatusInternalServerError)
+				return
+			}
+			w.WriteHeader(http.StatusCreated)
+		case http.MethodDelete:
+			err := remote.Delete(r.Context(), name)
+			if err != nil {
+				http.Error(w, err.Error(), http.StatusInternalServerError)
+				return
+			}
+			w.WriteHeader(http.StatusNoContent)
+		case "MKCOL":
+			// the parent directories are created on put
+			w.WriteHeader(http.StatusCreated)
+		default:
+			http.Error(w, http.StatusText(http.StatusMethodNotAllowed), http.StatusMethodNotAllowed)
+		}
+	})
+}
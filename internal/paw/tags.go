@@ -0,0 +1,98 @@
+// SPDX-FileCopyrightText: 2025 Luca Corbo, Paw contributors
+// SPDX-License-Identifier: AGPL-3.0-or-later
+
+package paw
+
+import (
+	"sort"
+	"strings"
+)
+
+// NormalizeTags returns the tags trimmed, deduplicated case-insensitively and sorted.
+// Empty tags are removed. Commas are not allowed as they are used as separator.
+func NormalizeTags(tags []string) []string {
+	seen := map[string]bool{}
+	normalized := []string{}
+	for _, tag := range tags {
+		tag = strings.TrimSpace(strings.ReplaceAll(tag, ",", " "))
+		if tag == "" || seen[strings.ToLower(tag)] {
+			continue
+		}
+		seen[strings.ToLower(tag)] = true
+		normalized = append(normalized, tag)
+	}
+	sort.Slice(normalized, func(i, j int) bool {
+		return strings.ToLower(normalized[i]) < strings.ToLower(normalized[j])
+	})
+	if len(normalized) == 0 {
+		return nil
+	}
+	return normalized
+}
+
+// ParseTags parses a comma separated list of tags
+func ParseTags(s string) []string {
+	return NormalizeTags(strings.Split(s, ","))
+}
+
+// NormalizeFolder returns the folder path without empty elements and leading or trailing slashes
+func NormalizeFolder(folder string) string {
+	elems := []string{}
+	for _, elem := range strings.Split(folder, "/") {
+		elem = strings.TrimSpace(elem)
+		if elem == "" {
+			continue
+		}
+		elems = append(elems, elem)
+	}
+	return strings.Join(elems, "/")
+}
+
+// HasTag reports whether the item has the tag. The comparison is case-insensitive.
+func (m *Metadata) HasTag(tag string) bool {
+	for _, t := range m.Tags {
+		if strings.EqualFold(t, strings.TrimSpace(tag)) {
+			return true
+		}
+	}
+	return false
+}
+
+// InFolder reports whether the item is into the folder or into one of its subfolders
+func (m *Metadata) InFolder(folder string) bool {
+	folder = NormalizeFolder(folder)
+	if folder == "" {
+		return true
+	}
+	return m.Folder == folder || strings.HasPrefix(m.Folder, folder+"/")
+}
+
+// Tags returns the tags used by the vault items sorted
+func (v *Vault) Tags() []string {
+	tags := []string{}
+	v.Range(func(id string, meta *Metadata) bool {
+		tags = append(tags, meta.Tags...)
+		return true
+	})
+	return NormalizeTags(tags)
+}
+
+// Folders returns the folders used by the vault items sorted, parent folders included
+func (v *Vault) Folders() []string {
+	seen := map[string]bool{}
+	folders := []string{}
+	v.Range(func(id string, meta *Metadata) bool {
+		elems := strings.Split(meta.Folder, "/")
+		for i := range elems {
+			folder := strings.Join(elems[:i+1], "/")
+			if folder == "" || seen[folder] {
+				continue
+			}
+			seen[folder] = true
+			folders = append(folders, folder)
+		}
+		return true
+	})
+	sort.Strings(folders)
+	return folders
+}
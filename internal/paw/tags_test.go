@@ -0,0 +1,58 @@
+// SPDX-FileCopyrightText: 2025 Luca Corbo, Paw contributors
+// SPDX-License-Identifier: AGPL-3.0-or-later
+
+package paw
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestNormalizeTags(t *testing.T) {
+	tests := []struct {
+		name string
+		tags []string
+		want []string
+	}{
+		{name: "nil", tags: nil, want: nil},
+		{name: "empty", tags: []string{"", " "}, want: nil},
+		{name: "trim and sort", tags: []string{" work", "Banking "}, want: []string{"Banking", "work"}},
+		{name: "deduplicate case-insensitively", tags: []string{"work", "Work"}, want: []string{"work"}},
+		{name: "comma", tags: []string{"a,b"}, want: []string{"a b"}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.want, NormalizeTags(tt.tags))
+		})
+	}
+}
+
+func TestParseTags(t *testing.T) {
+	assert.Equal(t, []string{"bank", "work"}, ParseTags("work, bank,,work"))
+	assert.Nil(t, ParseTags(""))
+}
+
+func TestNormalizeFolder(t *testing.T) {
+	assert.Equal(t, "", NormalizeFolder(" / "))
+	assert.Equal(t, "work/servers", NormalizeFolder("/work//servers/ "))
+}
+
+func TestVault_TagsAndFolders(t *testing.T) {
+	v := NewVault(nil, "test")
+
+	note := NewNote()
+	note.Name = "note"
+	note.Tags = []string{"work", "personal"}
+	note.Folder = "home/notes"
+	v.AddItem(note)
+
+	password := NewPassword()
+	password.Name = "password"
+	password.Tags = []string{"work"}
+	password.Folder = "home"
+	v.AddItem(password)
+
+	assert.Equal(t, []string{"personal", "work"}, v.Tags())
+	assert.Equal(t, []string{"home", "home/notes"}, v.Folders())
+}
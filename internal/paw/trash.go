@@ -0,0 +1,172 @@
+// SPDX-FileCopyrightText: 2025 Luca Corbo, Paw contributors
+// SPDX-License-Identifier: AGPL-3.0-or-later
+
+package paw
+
+import (
+	"fmt"
+	"sort"
+	"time"
+)
+
+// TrashRetentionDaysDefault is the default number of days the deleted items are kept into the trash
+const TrashRetentionDaysDefault = 30
+
+// Tombstone represents an item moved to the vault trash
+type Tombstone struct {
+	*Metadata `json:"metadata"`
+	// Deleted is the time the item has been moved to the trash
+	Deleted time.Time `json:"deleted"`
+}
+
+// TrashItem removes the item from the vault adding a tombstone into the trash.
+// The caller is responsible to move the item file and to store the vault.
+func (v *Vault) TrashItem(item Item, t time.Time) {
+	meta := item.GetMetadata()
+	if meta == nil {
+		return
+	}
+	v.DeleteItem(item)
+	if v.Trash == nil {
+		v.Trash = make(map[string]*Tombstone)
+	}
+	v.Trash[item.ID()] = &Tombstone{Metadata: meta, Deleted: t}
+}
+
+// RestoreItem removes the tombstone from the trash adding the item metadata back to the vault.
+// ErrItemAlreadyExists is returned if an item with the same type and name is present into the vault.
+func (v *Vault) RestoreItem(id string) (*Metadata, error) {
+	tombstone, ok := v.Trash[id]
+	if !ok {
+		return nil, fmt.Errorf("%w: %q is not into the trash", ErrItemNotFound, id)
+	}
+	if _, ok := v.ItemMetadataByName(tombstone.Type, tombstone.Name); ok {
+		return nil, fmt.Errorf("%w: a %s item named %q is already present", ErrItemAlreadyExists, tombstone.Type, tombstone.Name)
+	}
+	delete(v.Trash, id)
+	err := v.AddItem(tombstone.Metadata)
+	if err != nil {
+		return nil, err
+	}
+	return tombstone.Metadata, nil
+}
+
+// PurgeItem removes the tombstone from the trash
+func (v *Vault) PurgeItem(id string) {
+	delete(v.Trash, id)
+	v.Modified = time.Now().UTC()
+}
+
+// TrashedItems returns the tombstones into the trash, the most recently deleted first
+func (v *Vault) TrashedItems() []*Tombstone {
+	tombstones := make([]*Tombstone, 0, len(v.Trash))
+	for _, tombstone := range v.Trash {
+		tombstones = append(tombstones, tombstone)
+	}
+	sort.Slice(tombstones, func(i, j int) bool {
+		if tombstones[i].Deleted.Equal(tombstones[j].Deleted) {
+			return tombstones[i].Name < tombstones[j].Name
+		}
+		return tombstones[i].Deleted.After(tombstones[j].Deleted)
+	})
+	return tombstones
+}
+
+// TrashedItemByName returns the most recently deleted item with the specified type and name
+func (v *Vault) TrashedItemByName(itemType ItemType, name string) (*Tombstone, bool) {
+	for _, tombstone := range v.TrashedItems() {
+		if tombstone.Type == itemType && tombstone.Name == name {
+			return tombstone, true
+		}
+	}
+	return nil, false
+}
+
+// MoveItemToTrash moves the item to the vault trash and stores the vault
+func MoveItemToTrash(s Storage, vault *Vault, item Item) error {
+	unlock, err := LockVault(s, vault)
+	if err != nil {
+		return err
+	}
+	defer unlock()
+
+	err = s.TrashItem(vault, item)
+	if err != nil {
+		return err
+	}
+	now := time.Now().UTC()
+	vault.TrashItem(item, now)
+	vault.Modified = now
+	return s.StoreVault(vault)
+}
+
+// RestoreItemFromTrash restores the item from the vault trash and stores the vault
+func RestoreItemFromTrash(s Storage, vault *Vault, id string) (*Metadata, error) {
+	unlock, err := LockVault(s, vault)
+	if err != nil {
+		return nil, err
+	}
+	defer unlock()
+
+	meta, err := vault.RestoreItem(id)
+	if err != nil {
+		return nil, err
+	}
+	err = s.RestoreItem(vault, meta)
+	if err != nil {
+		// keep the vault consistent with the storage
+		vault.TrashItem(meta, time.Now().UTC())
+		return nil, err
+	}
+	vault.Modified = time.Now().UTC()
+	return meta, s.StoreVault(vault)
+}
+
+// PurgeItemFromTrash deletes permanently the item from the vault trash and stores the vault
+func PurgeItemFromTrash(s Storage, vault *Vault, id string) error {
+	unlock, err := LockVault(s, vault)
+	if err != nil {
+		return err
+	}
+	defer unlock()
+
+	tombstone, ok := vault.Trash[id]
+	if !ok {
+		return fmt.Errorf("%w: %q is not into the trash", ErrItemNotFound, id)
+	}
+	err = s.PurgeItem(vault, tombstone)
+	if err != nil {
+		return err
+	}
+	deleteAttachments(s, vault, tombstone.Metadata)
+	vault.PurgeItem(id)
+	return s.StoreVault(vault)
+}
+
+// PurgeExpiredTrash deletes permanently the items moved to the trash before the retention period.
+// It returns the number of the purged items.
+func PurgeExpiredTrash(s Storage, vault *Vault, retention time.Duration, now time.Time) (int, error) {
+	unlock, err := LockVault(s, vault)
+	if err != nil {
+		return 0, err
+	}
+	defer unlock()
+
+	purged := 0
+	for id, tombstone := range vault.Trash {
+		if now.Sub(tombstone.Deleted) < retention {
+			continue
+		}
+		err := s.PurgeItem(vault, tombstone)
+		if err != nil {
+			return purged, err
+		}
+		deleteAttachments(s, vault, tombstone.Metadata)
+		vault.PurgeItem(id)
+		purged++
+	}
+	if purged == 0 {
+		return 0, nil
+	}
+	return purged, s.StoreVault(vault)
+}
@@ -0,0 +1,109 @@
+// SPDX-FileCopyrightText: 2025 Luca Corbo, Paw contributors
+// SPDX-License-Identifier: AGPL-3.0-or-later
+
+package paw
+
+import (
+	"os"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestVault_Trash(t *testing.T) {
+	now := time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC)
+	v := NewVault(nil, "test")
+
+	note := NewNote()
+	note.Name = "note"
+	require.NoError(t, v.AddItem(note))
+
+	v.TrashItem(note, now)
+	assert.False(t, v.HasItem(note))
+	tombstone, ok := v.TrashedItemByName(NoteItemType, "note")
+	require.True(t, ok)
+	assert.Equal(t, note.ID(), tombstone.ID())
+	assert.Equal(t, now, tombstone.Deleted)
+
+	// an item with the same name has been created in the meantime
+	other := NewNote()
+	other.Name = "note"
+	require.NoError(t, v.AddItem(other))
+	_, err := v.RestoreItem(note.ID())
+	assert.ErrorIs(t, err, ErrItemAlreadyExists)
+
+	v.TrashItem(other, now.Add(time.Hour))
+	tombstones := v.TrashedItems()
+	require.Len(t, tombstones, 2)
+	assert.Equal(t, other.ID(), tombstones[0].ID(), "most recently deleted first")
+
+	meta, err := v.RestoreItem(note.ID())
+	require.NoError(t, err)
+	assert.Equal(t, note.GetMetadata(), meta)
+	assert.True(t, v.HasItem(note))
+
+	_, err = v.RestoreItem(note.ID())
+	assert.ErrorIs(t, err, ErrItemNotFound)
+
+	v.PurgeItem(other.ID())
+	assert.Empty(t, v.TrashedItems())
+}
+
+func TestTrash_OSStorage(t *testing.T) {
+	root, err := os.MkdirTemp(os.TempDir(), "paw")
+	require.NoError(t, err)
+	defer os.RemoveAll(root)
+
+	s, err := NewOSStorageRooted(root)
+	require.NoError(t, err)
+	key, err := s.CreateVaultKey("test", "secret")
+	require.NoError(t, err)
+	vault, err := s.CreateVault("test", key)
+	require.NoError(t, err)
+
+	newStoredNote := func(name string) *Note {
+		note := NewNote()
+		note.Name = name
+		note.Value = "value of " + name
+		require.NoError(t, s.StoreItem(vault, note))
+		require.NoError(t, vault.AddItem(note))
+		require.NoError(t, s.StoreVault(vault))
+		return note
+	}
+
+	note := newStoredNote("note")
+	require.NoError(t, MoveItemToTrash(s, vault, note))
+	assert.NoFileExists(t, itemPath(s, vault.Name, note.ID()))
+	assert.FileExists(t, trashItemPath(s, vault.Name, note.ID()))
+
+	// the tombstone is persisted
+	stored, err := s.LoadVault(vault.Name, key)
+	require.NoError(t, err)
+	_, ok := stored.TrashedItemByName(NoteItemType, "note")
+	assert.True(t, ok)
+
+	meta, err := RestoreItemFromTrash(s, vault, note.ID())
+	require.NoError(t, err)
+	item, err := s.LoadItem(vault, meta)
+	require.NoError(t, err)
+	assert.Equal(t, "value of note", item.(*Note).Value)
+
+	require.NoError(t, MoveItemToTrash(s, vault, note))
+	require.NoError(t, PurgeItemFromTrash(s, vault, note.ID()))
+	assert.NoFileExists(t, trashItemPath(s, vault.Name, note.ID()))
+	assert.Empty(t, vault.TrashedItems())
+
+	expired := newStoredNote("expired")
+	recent := newStoredNote("recent")
+	require.NoError(t, MoveItemToTrash(s, vault, expired))
+	require.NoError(t, MoveItemToTrash(s, vault, recent))
+	vault.Trash[expired.ID()].Deleted = time.Now().UTC().Add(-48 * time.Hour)
+
+	purged, err := PurgeExpiredTrash(s, vault, 24*time.Hour, time.Now().UTC())
+	require.NoError(t, err)
+	assert.Equal(t, 1, purged)
+	assert.NoFileExists(t, trashItemPath(s, vault.Name, expired.ID()))
+	assert.FileExists(t, trashItemPath(s, vault.Name, recent.ID()))
+}
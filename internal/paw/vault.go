@@ -4,18 +4,38 @@
 package paw
 
 import (
+	"errors"
 	"fmt"
 	"sort"
 	"strings"
 	"time"
 )
 
+var (
+	// ErrItemAlreadyExists is returned when an item with the same type and name is already present into the vault
+	ErrItemAlreadyExists = errors.New("item already exists")
+	// ErrItemNotFound is returned when an item is not present into the vault
+	ErrItemNotFound = errors.New("item not found")
+	// ErrVaultNotFound is returned when a vault does not exist into the storage
+	ErrVaultNotFound = errors.New("vault not found")
+)
+
 type Vault struct {
 	key *Key
+	// base is the vault index as last read or written, see MergeVault
+	base *vaultBase
+	// pendingCopies holds the stored items kept as pending conflict copy, see keepConflictCopy
+	pendingCopies map[string]Item
+	// lockDepth is the number of the holders of the vault lock acquired through the instance, see LockVault
+	lockDepth int
 
 	Name string
 	// Items represents the list of the item IDs available into the vault grouped by ItemType
 	ItemMetadata map[ItemType]map[string]*Metadata //map[ItemType]map[<ID>]
+	// Trash represents the tombstones of the items moved to the trash
+	Trash map[string]*Tombstone //map[<ID>]
+	// Conflicts represents the concurrent changes discarded merging the vault, see MergeVault
+	Conflicts map[string]*Conflict `json:",omitempty"` //map[<ID>]
 	// Version represents the specification version
 	Version string
 	// Created represents the creation date
@@ -52,22 +72,27 @@ func (v *Vault) Key() *Key {
 	return v.key
 }
 
-// HasItem returns true if a item with the same ID is present into the vault
+// HasItem returns true if an item with the same type and name is present into the vault
 func (v *Vault) HasItem(item Item) bool {
 	meta := item.GetMetadata()
 	if meta == nil {
 		return false
 	}
 
-	metaByType, ok := v.ItemMetadata[meta.Type]
-	if !ok {
-		return false
-	}
-
-	_, ok = metaByType[item.ID()]
+	_, ok := v.ItemMetadataByName(meta.Type, meta.Name)
 	return ok
 }
 
+// ItemMetadataByName returns the metadata of the item with the specified type and name
+func (v *Vault) ItemMetadataByName(itemType ItemType, name string) (*Metadata, bool) {
+	for _, meta := range v.ItemMetadata[itemType] {
+		if meta.Name == name {
+			return meta, true
+		}
+	}
+	return nil, false
+}
+
 func (v *Vault) AddItem(item Item) error {
 	meta := item.GetMetadata()
 	if meta == nil {
@@ -81,6 +106,25 @@ func (v *Vault) AddItem(item Item) error {
 	return nil
 }
 
+// RenameItem renames the item updating the vault's item metadata.
+// The item ID does not change, the caller is responsible to store the item
+// and the vault to persist the new name.
+func (v *Vault) RenameItem(item Item, name string) error {
+	meta := item.GetMetadata()
+	if meta == nil {
+		return fmt.Errorf("item metadata is nil")
+	}
+	if name == "" {
+		return fmt.Errorf("item name cannot be empty")
+	}
+	if other, ok := v.ItemMetadataByName(meta.Type, name); ok && other.ID() != item.ID() {
+		return fmt.Errorf("%w: a %s item named %q is already present", ErrItemAlreadyExists, meta.Type, name)
+	}
+	meta.Name = name
+	meta.Modified = time.Now().UTC()
+	return v.AddItem(item)
+}
+
 func (v *Vault) DeleteItem(item Item) {
 	meta := item.GetMetadata()
 	if meta == nil {
@@ -101,7 +145,7 @@ func (v *Vault) Range(f func(id string, meta *Metadata) bool) {
 	for _, itemMetadataByType := range v.ItemMetadata {
 		for id, itemMetadata := range itemMetadataByType {
 			if !f(id, itemMetadata) {
-				break
+				return
 			}
 		}
 	}
@@ -120,6 +164,12 @@ func (v *Vault) FilterItemMetadata(opts *VaultFilterOptions) []*Metadata {
 			if nameFilter != "" && !strings.Contains(strings.ToLower(itemMetadata.Name), nameFilter) {
 				continue
 			}
+			if !itemMetadata.InFolder(opts.Folder) {
+				continue
+			}
+			if !hasAllTags(itemMetadata, opts.Tags) {
+				continue
+			}
 			metadata = append(metadata, itemMetadata)
 		}
 	}
@@ -132,4 +182,18 @@ func (v *Vault) FilterItemMetadata(opts *VaultFilterOptions) []*Metadata {
 type VaultFilterOptions struct {
 	Name     string
 	ItemType ItemType
+	// Tags filters the items having all the tags
+	Tags []string
+	// Folder filters the items into the folder or into its subfolders
+	Folder string
+}
+
+// hasAllTags reports whether the item has all the tags
+func hasAllTags(meta *Metadata, tags []string) bool {
+	for _, tag := range tags {
+		if !meta.HasTag(tag) {
+			return false
+		}
+	}
+	return true
 }
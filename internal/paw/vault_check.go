@@ -0,0 +1,640 @@
+// SPDX-FileCopyrightText: 2025 Luca Corbo, Paw contributors
+// SPDX-License-Identifier: AGPL-3.0-or-later
+
+package paw
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"time"
+)
+
+// VaultIssueKind represents the kind of an integrity issue of a vault
+type VaultIssueKind string
+
+const (
+	// VaultIssueIndex reports that the vault index cannot be read
+	VaultIssueIndex VaultIssueKind = "index"
+	// VaultIssueMissing reports an item listed into the index without its file
+	VaultIssueMissing VaultIssueKind = "missing"
+	// VaultIssueOrphan reports an item file not listed into the index
+	VaultIssueOrphan VaultIssueKind = "orphan"
+	// VaultIssueUndecryptable reports a file that cannot be decrypted
+	VaultIssueUndecryptable VaultIssueKind = "undecryptable"
+	// VaultIssueMismatch reports an item whose metadata differ from the index ones
+	VaultIssueMismatch VaultIssueKind = "mismatch"
+	// VaultIssueMissingAttachment reports an attachment referenced by an item without its file
+	VaultIssueMissingAttachment VaultIssueKind = "missing_attachment"
+	// VaultIssueOrphanAttachment reports an attachment file not referenced by any item
+	VaultIssueOrphanAttachment VaultIssueKind = "orphan_attachment"
+	// VaultIssueTmpFile reports a temporary file left by an interrupted write
+	VaultIssueTmpFile VaultIssueKind = "tmp_file"
+)
+
+// VaultIssue is an integrity issue found checking a vault
+type VaultIssue struct {
+	Kind VaultIssueKind `json:"kind"`
+	// File is the path of the affected file relative to the vault dir
+	File string `json:"file"`
+	// Metadata holds the metadata of the affected item, if known
+	Metadata *Metadata `json:"metadata,omitempty"`
+	// Detail describes the issue
+	Detail string `json:"detail"`
+	// Repaired reports whether the issue has been repaired
+	Repaired bool `json:"repaired"`
+}
+
+// VaultCheckResult reports the result of a vault integrity check
+type VaultCheckResult struct {
+	// Checked is the number of the files decrypted
+	Checked int `json:"checked"`
+	// Issues lists the issues found
+	Issues []*VaultIssue `json:"issues"`
+}
+
+// Unrepaired returns the number of the issues not repaired
+func (r *VaultCheckResult) Unrepaired() int {
+	n := 0
+	for _, issue := range r.Issues {
+		if !issue.Repaired {
+			n++
+		}
+	}
+	return n
+}
+
+// vaultCheck holds the state of a vault integrity check
+type vaultCheck struct {
+	s      Storage
+	vault  *Vault
+	repair bool
+	result *VaultCheckResult
+	// modified reports whether the vault index has been repaired
+	modified bool
+	// indexIssue is the issue reported if the vault index cannot be read
+	indexIssue *VaultIssue
+	// items holds the decrypted items by ID
+	items map[string]Item
+	// undecryptable reports whether some item could not be decrypted
+	undecryptable bool
+}
+
+// CheckVault verifies the integrity of the vault: the vault index and all the
+// item and attachment files are decrypted, then the items listed into the index
+// are compared with the files found into the vault dir. The item files are the
+// source of truth: when repair is true the index is updated from the decrypted
+// items and rebuilt from scratch if it cannot be read, the unreadable index is
+// moved aside to not lose the trash, the conflicts, the tags and the folders if
+// it is only temporarily unreadable.
+// The files that cannot be decrypted are reported but never deleted, the ones
+// left half-written by an interrupted write are reported as such. The stale
+// temporary files left by an interrupted write are reported and removed on repair.
+func CheckVault(s Storage, name string, key *Key, repair bool) (*VaultCheckResult, error) {
+	unlock, err := s.LockVault(name)
+	if err != nil {
+		return nil, err
+	}
+	defer unlock()
+
+	c := &vaultCheck{
+		s:      s,
+		repair: repair,
+		result: &VaultCheckResult{Issues: []*VaultIssue{}},
+		items:  map[string]Item{},
+	}
+
+	c.vault, err = s.LoadVault(name, key)
+	if err != nil {
+		if _, statErr := os.Stat(vaultPath(s, name)); statErr != nil && !errors.Is(statErr, os.ErrNotExist) {
+			return nil, err
+		}
+		c.vault = NewVault(key, name)
+		c.modified = repair
+		c.indexIssue = &VaultIssue{
+			Kind:     VaultIssueIndex,
+			File:     vaultFileName,
+			Detail:   undecryptableDetail("the vault index cannot be read", vaultPath(s, name), err),
+			Repaired: repair,
+		}
+		c.addIssue(c.indexIssue)
+	} else {
+		c.result.Checked++
+	}
+	defer holdVaultLock(c.vault)()
+
+	files, err := listVaultFiles(vaultRootPath(s, name), itemFileNames)
+	if err != nil {
+		return nil, err
+	}
+	trashFiles, err := listVaultFiles(trashRootPath(s, name), nil)
+	if err != nil {
+		return nil, err
+	}
+
+	err = c.checkTmpFiles(time.Now())
+	if err != nil {
+		return nil, err
+	}
+	c.checkIndexedItems(files, trashFiles)
+	c.checkOrphanItems(files, trashFiles)
+	err = c.checkAttachments()
+	if err != nil {
+		return nil, err
+	}
+
+	if c.modified {
+		now := time.Now().UTC()
+		if c.indexIssue != nil {
+			err = c.moveIndexAside(now)
+			if err != nil {
+				return nil, err
+			}
+		}
+		c.vault.Modified = now
+		err = s.StoreVault(c.vault)
+		if err != nil {
+			return nil, fmt.Errorf("could not store the repaired vault: %w", err)
+		}
+	}
+	return c.result, nil
+}
+
+// moveIndexAside renames the unreadable vault index before it is rebuilt
+func (c *vaultCheck) moveIndexAside(now time.Time) error {
+	name := vaultPath(c.s, c.vault.Name)
+	if _, err := os.Stat(name); errors.Is(err, os.ErrNotExist) {
+		return nil
+	}
+	corrupt := fmt.Sprintf("%s.corrupt-%s", name, now.Format("20060102T150405Z"))
+	err := os.Rename(name, corrupt)
+	if err != nil {
+		return fmt.Errorf("could not move aside the vault index: %w", err)
+	}
+	c.indexIssue.Detail = fmt.Sprintf("%s, moved to %s", c.indexIssue.Detail, c.relPath(corrupt))
+	return nil
+}
+
+// itemFileNames are the files into the vault dir that are not item files
+var itemFileNames = map[string]bool{
+	keyFileName:      true,
+	vaultFileName:    true,
+	syncBaseFileName: true,
+}
+
+// listVaultFiles returns the paths of the age files into dir by ID, skipping the excluded names
+func listVaultFiles(dir string, exclude map[string]bool) (map[string]string, error) {
+	files := map[string]string{}
+	entries, err := os.ReadDir(dir)
+	if errors.Is(err, os.ErrNotExist) {
+		return files, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("could not list the vault files: %w", err)
+	}
+	for _, entry := range entries {
+		name := entry.Name()
+		if entry.IsDir() || exclude[name] || filepath.Ext(name) != ".age" {
+			continue
+		}
+		files[strings.TrimSuffix(name, ".age")] = filepath.Join(dir, name)
+	}
+	return files, nil
+}
+
+// checkIndexedItems checks the items and the tombstones listed into the index
+func (c *vaultCheck) checkIndexedItems(files map[string]string, trashFiles map[string]string) {
+	type indexed struct {
+		id      string
+		meta    *Metadata
+		trashed bool
+	}
+	entries := []indexed{}
+	c.vault.Range(func(id string, meta *Metadata) bool {
+		entries = append(entries, indexed{id: id, meta: meta})
+		return true
+	})
+	for id, tombstone := range c.vault.Trash {
+		entries = append(entries, indexed{id: id, meta: tombstone.Metadata, trashed: true})
+	}
+	sort.Slice(entries, func(i, j int) bool {
+		return entries[i].id < entries[j].id
+	})
+
+	for _, entry := range entries {
+		path, ok := files[entry.id]
+		if entry.trashed {
+			path, ok = trashFiles[entry.id]
+		}
+		if !ok {
+			issue := &VaultIssue{
+				Kind:     VaultIssueMissing,
+				File:     c.relPath(itemPath(c.s, c.vault.Name, entry.id)),
+				Metadata: entry.meta,
+				Detail:   "the item is listed into the index but its file does not exist",
+				Repaired: c.repair,
+			}
+			if entry.trashed {
+				issue.File = c.relPath(trashItemPath(c.s, c.vault.Name, entry.id))
+				issue.Detail = "the item is listed into the trash but its file does not exist"
+			}
+			c.addIssue(issue)
+			if c.repair {
+				c.unindex(entry.id, entry.meta, entry.trashed)
+			}
+			continue
+		}
+
+		item, ok := c.loadItem(path, entry.meta)
+		if !ok {
+			continue
+		}
+		c.items[entry.id] = item
+
+		meta := item.GetMetadata()
+		var mismatches []string
+		if item.ID() != entry.id {
+			mismatches = append(mismatches, fmt.Sprintf("ID %q", item.ID()))
+		}
+		if meta.Type != entry.meta.Type {
+			mismatches = append(mismatches, fmt.Sprintf("type %q instead of %q", meta.Type, entry.meta.Type))
+		}
+		if meta.Name != entry.meta.Name {
+			mismatches = append(mismatches, fmt.Sprintf("name %q instead of %q", meta.Name, entry.meta.Name))
+		}
+		if len(mismatches) == 0 {
+			continue
+		}
+
+		issue := &VaultIssue{
+			Kind:     VaultIssueMismatch,
+			File:     c.relPath(path),
+			Metadata: meta,
+			Detail:   fmt.Sprintf("the item file has %s", strings.Join(mismatches, ", ")),
+		}
+		c.addIssue(issue)
+		if !c.repair {
+			continue
+		}
+		if entry.trashed {
+			if item.ID() != entry.id {
+				// the trashed items cannot be stored, the ID cannot be fixed
+				continue
+			}
+			c.vault.Trash[entry.id].Metadata = meta
+		} else {
+			c.unindex(entry.id, entry.meta, false)
+			if !c.indexItem(entry.id, item) {
+				continue
+			}
+		}
+		c.modified = true
+		issue.Repaired = true
+	}
+}
+
+// checkOrphanItems checks the item files not listed into the index
+func (c *vaultCheck) checkOrphanItems(files map[string]string, trashFiles map[string]string) {
+	for _, trashed := range []bool{false, true} {
+		paths := files
+		if trashed {
+			paths = trashFiles
+		}
+		ids := make([]string, 0, len(paths))
+		for id := range paths {
+			ids = append(ids, id)
+		}
+		sort.Strings(ids)
+
+		for _, id := range ids {
+			path := paths[id]
+			if c.isIndexed(id, trashed) {
+				continue
+			}
+			item, ok := c.loadItem(path, nil)
+			if !ok {
+				continue
+			}
+
+			issue := &VaultIssue{
+				Kind:     VaultIssueOrphan,
+				File:     c.relPath(path),
+				Metadata: item.GetMetadata(),
+				Detail:   "the item file is not listed into the index",
+			}
+			c.addIssue(issue)
+			if _, ok := c.items[id]; ok {
+				// a copy of an item already listed, e.g. an interrupted move to the trash
+				issue.Detail = "the item file is a copy of an item listed into the index"
+				continue
+			}
+			c.items[id] = item
+			if !c.repair {
+				continue
+			}
+
+			if trashed {
+				if item.ID() != id {
+					continue
+				}
+				deleted := time.Now().UTC()
+				if fi, err := os.Stat(path); err == nil {
+					deleted = fi.ModTime().UTC()
+				}
+				c.vault.TrashItem(item, deleted)
+			} else if !c.indexItem(id, item) {
+				continue
+			}
+			c.modified = true
+			issue.Repaired = true
+		}
+	}
+}
+
+// checkAttachments checks the attachments referenced by the decrypted items and the attachment files
+func (c *vaultCheck) checkAttachments() error {
+	files, err := listVaultFiles(attachmentsRootPath(c.s, c.vault.Name), nil)
+	if err != nil {
+		return err
+	}
+
+	ids := make([]string, 0, len(c.items))
+	for id := range c.items {
+		ids = append(ids, id)
+	}
+	sort.Strings(ids)
+
+	referenced := map[string]bool{}
+	for _, id := range ids {
+		item := c.items[id]
+		meta := item.GetMetadata()
+		attachments := make([]*Attachment, 0, len(meta.Attachments))
+		var missing []*VaultIssue
+		for _, attachment := range meta.Attachments {
+			referenced[attachment.ID] = true
+			path, ok := files[attachment.ID]
+			if !ok {
+				issue := &VaultIssue{
+					Kind:     VaultIssueMissingAttachment,
+					File:     c.relPath(attachmentPath(c.s, c.vault.Name, attachment.ID)),
+					Metadata: meta,
+					Detail:   fmt.Sprintf("the attachment %q does not exist", attachment.Name),
+				}
+				c.addIssue(issue)
+				missing = append(missing, issue)
+				continue
+			}
+			attachments = append(attachments, attachment)
+			err := checkAttachmentFile(c.vault.key, path)
+			if err != nil {
+				c.addIssue(&VaultIssue{
+					Kind:     VaultIssueUndecryptable,
+					File:     c.relPath(path),
+					Metadata: meta,
+					Detail:   undecryptableDetail(fmt.Sprintf("the attachment %q cannot be decrypted", attachment.Name), path, err),
+				})
+				continue
+			}
+			c.result.Checked++
+		}
+
+		// only the items listed into the index can be stored
+		if len(missing) == 0 || !c.repair || c.isTrashed(id) {
+			continue
+		}
+		meta.Attachments = attachments
+		err := c.s.StoreItem(c.vault, item)
+		if err != nil {
+			return fmt.Errorf("could not store the item %q: %w", meta.Name, err)
+		}
+		err = c.vault.AddItem(item)
+		if err != nil {
+			return err
+		}
+		c.modified = true
+		for _, issue := range missing {
+			issue.Repaired = true
+		}
+	}
+
+	orphans := make([]string, 0, len(files))
+	for id := range files {
+		if !referenced[id] {
+			orphans = append(orphans, id)
+		}
+	}
+	sort.Strings(orphans)
+	for _, id := range orphans {
+		issue := &VaultIssue{
+			Kind:   VaultIssueOrphanAttachment,
+			File:   c.relPath(files[id]),
+			Detail: "the attachment file is not referenced by any item",
+		}
+		c.addIssue(issue)
+		// the attachment could be referenced by an item that cannot be decrypted
+		if !c.repair || c.undecryptable {
+			continue
+		}
+		err := c.s.DeleteAttachment(c.vault, &Attachment{ID: id})
+		if err != nil {
+			return err
+		}
+		issue.Repaired = true
+	}
+	return nil
+}
+
+// checkTmpFiles checks the temporary files left into the vault dir by an
+// interrupted write, the stale ones are removed on repair. The recent ones
+// could belong to a concurrent writer and are skipped.
+func (c *vaultCheck) checkTmpFiles(now time.Time) error {
+	return filepath.WalkDir(vaultRootPath(c.s, c.vault.Name), func(path string, d fs.DirEntry, err error) error {
+		if errors.Is(err, os.ErrNotExist) {
+			return nil
+		}
+		if err != nil {
+			return fmt.Errorf("could not list the vault files: %w", err)
+		}
+		if d.IsDir() {
+			// the key rotation dir is recovered on load, see recoverVaultFiles
+			if d.Name() == ".git" || d.Name() == rotationDirName {
+				return fs.SkipDir
+			}
+			return nil
+		}
+		if !isTmpFile(path) {
+			return nil
+		}
+		fi, err := d.Info()
+		if err != nil || now.Sub(fi.ModTime()) < staleTmpFileAge {
+			return nil
+		}
+		issue := &VaultIssue{
+			Kind:   VaultIssueTmpFile,
+			File:   c.relPath(path),
+			Detail: "the temporary file has been left by an interrupted write",
+		}
+		c.addIssue(issue)
+		if !c.repair {
+			return nil
+		}
+		err = os.Remove(path)
+		if err != nil {
+			return fmt.Errorf("could not remove the temporary file: %w", err)
+		}
+		issue.Repaired = true
+		return nil
+	})
+}
+
+// undecryptableDetail returns the detail of an issue for the file that cannot
+// be decrypted, reporting if the file has been left half-written
+func undecryptableDetail(detail string, path string, err error) string {
+	if checkErr := checkAgeFile(path); checkErr != nil && !errors.Is(checkErr, os.ErrNotExist) {
+		return fmt.Sprintf("%s, the file has been left half-written by an interrupted write: %s", detail, checkErr)
+	}
+	return fmt.Sprintf("%s: %s", detail, err)
+}
+
+// loadItem decrypts the item file, the issue is reported if it cannot be decrypted.
+// The item type is read from the file if meta is nil.
+func (c *vaultCheck) loadItem(path string, meta *Metadata) (Item, bool) {
+	item, err := loadItemFile(c.vault.key, path)
+	if err != nil {
+		c.undecryptable = true
+		c.addIssue(&VaultIssue{
+			Kind:     VaultIssueUndecryptable,
+			File:     c.relPath(path),
+			Metadata: meta,
+			Detail:   undecryptableDetail("the item file cannot be decrypted", path, err),
+		})
+		return nil, false
+	}
+	c.result.Checked++
+	return item, true
+}
+
+// indexItem adds the item into the index using the ID of its file.
+// The item is renamed if an item with the same type and name is already listed.
+func (c *vaultCheck) indexItem(id string, item Item) bool {
+	meta := item.GetMetadata()
+	store := false
+	if item.ID() != id {
+		meta.UUID = id
+		store = true
+	}
+	names := map[string]*Metadata{}
+	for _, other := range c.vault.ItemMetadata[meta.Type] {
+		names[other.Name] = other
+	}
+	if other, ok := names[meta.Name]; ok && other.ID() != id {
+		meta.Name = uniqueName(names, meta.Name)
+		store = true
+	}
+	if store && c.s.StoreItem(c.vault, item) != nil {
+		return false
+	}
+	return c.vault.AddItem(item) == nil
+}
+
+// unindex removes the item from the index
+func (c *vaultCheck) unindex(id string, meta *Metadata, trashed bool) {
+	if trashed {
+		delete(c.vault.Trash, id)
+	} else {
+		delete(c.vault.ItemMetadata[meta.Type], id)
+	}
+	c.modified = true
+}
+
+// isIndexed reports whether the item is listed into the index
+func (c *vaultCheck) isIndexed(id string, trashed bool) bool {
+	if trashed {
+		return c.isTrashed(id)
+	}
+	for _, metas := range c.vault.ItemMetadata {
+		if _, ok := metas[id]; ok {
+			return true
+		}
+	}
+	return false
+}
+
+// isTrashed reports whether the item is listed into the trash
+func (c *vaultCheck) isTrashed(id string) bool {
+	_, ok := c.vault.Trash[id]
+	return ok
+}
+
+func (c *vaultCheck) addIssue(issue *VaultIssue) {
+	c.result.Issues = append(c.result.Issues, issue)
+}
+
+// relPath returns the path relative to the vault dir
+func (c *vaultCheck) relPath(path string) string {
+	rel, err := filepath.Rel(vaultRootPath(c.s, c.vault.Name), path)
+	if err != nil {
+		return path
+	}
+	return filepath.ToSlash(rel)
+}
+
+// loadItemFile decrypts the item file reading the item type from its metadata
+func loadItemFile(key *Key, path string) (Item, error) {
+	f, err := os.Open(path)
+	if err != nil {
+		return nil, err
+	}
+	defer f.Close()
+
+	r, err := key.Decrypt(f)
+	if err != nil {
+		return nil, fmt.Errorf("could not decrypt content: %w", err)
+	}
+	b, err := io.ReadAll(r)
+	if err != nil {
+		return nil, fmt.Errorf("could not decrypt content: %w", err)
+	}
+
+	v := struct {
+		Metadata *Metadata `json:"metadata"`
+	}{}
+	err = json.Unmarshal(b, &v)
+	if err != nil {
+		return nil, fmt.Errorf("could not decode content: %w", err)
+	}
+	if v.Metadata == nil {
+		return nil, errors.New("the item metadata is missing")
+	}
+	item, err := emptyItem(v.Metadata.Type)
+	if err != nil {
+		return nil, err
+	}
+	err = json.Unmarshal(b, item)
+	if err != nil {
+		return nil, fmt.Errorf("could not decode content: %w", err)
+	}
+	return item, nil
+}
+
+// checkAttachmentFile decrypts the whole attachment content
+func checkAttachmentFile(key *Key, path string) error {
+	f, err := os.Open(path)
+	if err != nil {
+		return err
+	}
+	defer f.Close()
+
+	r, err := key.Decrypt(f)
+	if err != nil {
+		return err
+	}
+	_, err = io.Copy(io.Discard, r)
+	return err
+}
@@ -0,0 +1,180 @@
+// SPDX-FileCopyrightText: 2025 Luca Corbo, Paw contributors
+// SPDX-License-Identifier: AGPL-3.0-or-later
+
+package paw
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// issueKinds returns the kinds of the issues by file
+func issueKinds(res *VaultCheckResult) map[string]VaultIssueKind {
+	kinds := map[string]VaultIssueKind{}
+	for _, issue := range res.Issues {
+		kinds[issue.File] = issue.Kind
+	}
+	return kinds
+}
+
+func TestCheckVault(t *testing.T) {
+	s := newSyncTestStorage(t)
+	key, err := s.CreateVaultKey("test", "secret")
+	require.NoError(t, err)
+	vault, err := s.CreateVault("test", key)
+	require.NoError(t, err)
+
+	ok := storeSyncTestNote(t, s, vault, "ok", "ok")
+	_, err = AddAttachment(s, vault, ok, "ok.txt", strings.NewReader("ok"))
+	require.NoError(t, err)
+	missing := storeSyncTestNote(t, s, vault, "missing", "missing")
+	renamed := storeSyncTestNote(t, s, vault, "renamed", "renamed")
+	trashed := storeSyncTestNote(t, s, vault, "trashed", "trashed")
+	require.NoError(t, MoveItemToTrash(s, vault, trashed))
+	attached := storeSyncTestNote(t, s, vault, "attached", "attached")
+	attachment, err := AddAttachment(s, vault, attached, "lost.txt", strings.NewReader("lost"))
+	require.NoError(t, err)
+
+	// the item file is stored but the index is not
+	orphan := NewNote()
+	orphan.Name = "ok"
+	require.NoError(t, s.StoreItem(vault, orphan))
+	require.NoError(t, os.Remove(itemPath(s, "test", missing.ID())))
+	vault.ItemMetadata[NoteItemType][renamed.ID()].Name = "other"
+	require.NoError(t, s.StoreVault(vault))
+	require.NoError(t, os.Rename(attachmentPath(s, "test", attachment.ID), attachmentPath(s, "test", "orphan")))
+	require.NoError(t, os.WriteFile(itemPath(s, "test", "corrupted"), []byte(ageHeader), 0600))
+
+	want := map[string]VaultIssueKind{
+		missing.ID() + ".age":                   VaultIssueMissing,
+		orphan.ID() + ".age":                    VaultIssueOrphan,
+		renamed.ID() + ".age":                   VaultIssueMismatch,
+		"corrupted.age":                         VaultIssueUndecryptable,
+		"attachments/" + attachment.ID + ".age": VaultIssueMissingAttachment,
+		"attachments/orphan.age":                VaultIssueOrphanAttachment,
+	}
+
+	res, err := CheckVault(s, "test", key, false)
+	require.NoError(t, err)
+	assert.Equal(t, want, issueKinds(res))
+	assert.Equal(t, len(want), res.Unrepaired())
+
+	// the orphan attachment is kept since it could belong to the undecryptable item
+	res, err = CheckVault(s, "test", key, true)
+	require.NoError(t, err)
+	assert.Equal(t, want, issueKinds(res))
+	assert.Equal(t, 2, res.Unrepaired())
+
+	vault, err = s.LoadVault("test", key)
+	require.NoError(t, err)
+	_, found := vault.ItemMetadataByName(NoteItemType, "missing")
+	assert.False(t, found)
+	_, found = vault.ItemMetadataByName(NoteItemType, "renamed")
+	assert.True(t, found)
+	restored, found := vault.ItemMetadataByName(NoteItemType, "ok (1)")
+	require.True(t, found)
+	assert.Equal(t, orphan.ID(), restored.ID())
+	item, err := s.LoadItem(vault, vault.ItemMetadata[NoteItemType][attached.ID()])
+	require.NoError(t, err)
+	assert.Empty(t, item.GetMetadata().Attachments)
+	assert.Contains(t, vault.Trash, trashed.ID())
+
+	require.NoError(t, os.Remove(itemPath(s, "test", "corrupted")))
+	res, err = CheckVault(s, "test", key, true)
+	require.NoError(t, err)
+	assert.Equal(t, map[string]VaultIssueKind{"attachments/orphan.age": VaultIssueOrphanAttachment}, issueKinds(res))
+	assert.Zero(t, res.Unrepaired())
+	assert.NoFileExists(t, attachmentPath(s, "test", "orphan"))
+
+	res, err = CheckVault(s, "test", key, false)
+	require.NoError(t, err)
+	assert.Empty(t, res.Issues)
+	// the index, 5 items and an attachment
+	assert.Equal(t, 7, res.Checked)
+}
+
+func TestCheckVault_RebuildIndex(t *testing.T) {
+	s := newSyncTestStorage(t)
+	key, err := s.CreateVaultKey("test", "secret")
+	require.NoError(t, err)
+	vault, err := s.CreateVault("test", key)
+	require.NoError(t, err)
+	note := storeSyncTestNote(t, s, vault, "note", "note")
+	trashed := storeSyncTestNote(t, s, vault, "trashed", "trashed")
+	require.NoError(t, MoveItemToTrash(s, vault, trashed))
+
+	require.NoError(t, os.WriteFile(vaultPath(s, "test"), []byte(ageHeader), 0600))
+	_, err = s.LoadVault("test", key)
+	require.Error(t, err)
+
+	res, err := CheckVault(s, "test", key, false)
+	require.NoError(t, err)
+	assert.NotZero(t, res.Unrepaired())
+	b, err := os.ReadFile(vaultPath(s, "test"))
+	require.NoError(t, err)
+	assert.Equal(t, ageHeader, string(b))
+
+	res, err = CheckVault(s, "test", key, true)
+	require.NoError(t, err)
+	assert.Zero(t, res.Unrepaired())
+	assert.Equal(t, VaultIssueIndex, res.Issues[0].Kind)
+	assert.Contains(t, res.Issues[0].Detail, "moved to vault.age.corrupt-")
+
+	// the unreadable index is kept
+	corrupt, err := filepath.Glob(vaultPath(s, "test") + ".corrupt-*")
+	require.NoError(t, err)
+	require.Len(t, corrupt, 1)
+	b, err = os.ReadFile(corrupt[0])
+	require.NoError(t, err)
+	assert.Equal(t, ageHeader, string(b))
+
+	vault, err = s.LoadVault("test", key)
+	require.NoError(t, err)
+	assert.Contains(t, vault.ItemMetadata[NoteItemType], note.ID())
+	assert.Contains(t, vault.Trash, trashed.ID())
+	assert.Equal(t, "note", loadSyncTestNote(t, s, vault, "note").Value)
+}
+
+func TestCheckVault_InterruptedWrites(t *testing.T) {
+	s := newSyncTestStorage(t)
+	key, err := s.CreateVaultKey("test", "secret")
+	require.NoError(t, err)
+	vault, err := s.CreateVault("test", key)
+	require.NoError(t, err)
+	note := storeSyncTestNote(t, s, vault, "note", "note")
+
+	now := time.Now()
+	stale := vaultPath(s, "test") + ".1234" + tmpFileSuffix
+	require.NoError(t, os.WriteFile(stale, []byte("age"), 0600))
+	require.NoError(t, os.Chtimes(stale, now.Add(-time.Hour), now.Add(-time.Hour)))
+	fresh := itemPath(s, "test", note.ID()) + ".5678" + tmpFileSuffix
+	require.NoError(t, os.WriteFile(fresh, []byte("age"), 0600))
+	// the item file left half-written
+	require.NoError(t, os.WriteFile(itemPath(s, "test", note.ID()), []byte(ageHeader), 0600))
+
+	res, err := CheckVault(s, "test", key, false)
+	require.NoError(t, err)
+	kinds := issueKinds(res)
+	assert.Len(t, kinds, 2)
+	assert.Equal(t, VaultIssueTmpFile, kinds["vault.age.1234.tmp"])
+	require.Equal(t, VaultIssueUndecryptable, kinds[note.ID()+".age"])
+	for _, issue := range res.Issues {
+		if issue.Kind == VaultIssueUndecryptable {
+			assert.Contains(t, issue.Detail, "half-written")
+		}
+	}
+	assert.FileExists(t, stale)
+
+	res, err = CheckVault(s, "test", key, true)
+	require.NoError(t, err)
+	assert.Equal(t, 1, res.Unrepaired(), "the half-written file is never deleted")
+	assert.NoFileExists(t, stale)
+	assert.FileExists(t, fresh)
+	assert.FileExists(t, itemPath(s, "test", note.ID()))
+}
@@ -0,0 +1,401 @@
+// SPDX-FileCopyrightText: 2025 Luca Corbo, Paw contributors
+// SPDX-License-Identifier: AGPL-3.0-or-later
+
+package paw
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strings"
+	"time"
+)
+
+// ErrGitNoRemote is returned when the git backed vault has no remote configured
+var ErrGitNoRemote = errors.New("no git remote configured")
+
+const (
+	gitRemoteName = "origin"
+	// the sync base is local to the device, see SyncVault
+	gitIgnore = "*.tmp\n" + syncBaseFileName + "\n"
+)
+
+// GitCommit represents a commit of a git backed vault
+type GitCommit struct {
+	Hash    string    `json:"hash"`
+	Date    time.Time `json:"date"`
+	Message string    `json:"message"`
+}
+
+// GitPullResult reports the result of a pull
+type GitPullResult struct {
+	// Updated reports whether the remote changes have been merged
+	Updated bool
+	// Merged lists the conflicting files resolved merging the vault index
+	Merged []string
+	// Conflicts lists the items changed both locally and remotely, see Conflict
+	Conflicts []*Conflict
+}
+
+// IsVaultGitRepository reports whether the vault is backed by a git repository
+func IsVaultGitRepository(s Storage, vaultName string) bool {
+	return isGitRepository(vaultRootPath(s, vaultName))
+}
+
+// InitVaultGit initialises the git repository for the vault committing its
+// current state. If the remote URL is specified it is set as the "origin" remote.
+// If the vault does not exist into the storage it is cloned from the remote.
+func InitVaultGit(s Storage, vaultName string, remoteURL string) error {
+	dir := vaultRootPath(s, vaultName)
+	if _, err := os.Stat(dir); errors.Is(err, os.ErrNotExist) {
+		if remoteURL == "" {
+			return fmt.Errorf("%w: %q", ErrVaultNotFound, vaultName)
+		}
+		_, err := runGit(storageRootPath(s), "clone", "-q", "--origin", gitRemoteName, remoteURL, vaultName)
+		if err != nil {
+			return err
+		}
+		return gitConfigureIdentity(dir)
+	}
+
+	if isGitRepository(dir) {
+		if remoteURL == "" {
+			return fmt.Errorf("vault %q is already a git repository", vaultName)
+		}
+		if _, err := runGit(dir, "remote", "get-url", gitRemoteName); err == nil {
+			_, err = runGit(dir, "remote", "set-url", gitRemoteName, remoteURL)
+			return err
+		}
+		_, err := runGit(dir, "remote", "add", gitRemoteName, remoteURL)
+		return err
+	}
+
+	_, err := runGit(dir, "init", "-q")
+	if err != nil {
+		return err
+	}
+	err = gitConfigureIdentity(dir)
+	if err != nil {
+		return err
+	}
+	err = os.WriteFile(filepath.Join(dir, ".gitignore"), []byte(gitIgnore), 0600)
+	if err != nil {
+		return fmt.Errorf("could not write the .gitignore file: %w", err)
+	}
+	if remoteURL != "" {
+		_, err = runGit(dir, "remote", "add", gitRemoteName, remoteURL)
+		if err != nil {
+			return err
+		}
+	}
+	return gitCommitAll(dir, "Initialise the vault")
+}
+
+// VaultGitLog returns the latest n commits of the git backed vault, the most recent first
+func VaultGitLog(s Storage, vaultName string, n int) ([]*GitCommit, error) {
+	dir, err := vaultGitDir(s, vaultName)
+	if err != nil {
+		return nil, err
+	}
+	out, err := runGit(dir, "log", fmt.Sprintf("-n%d", n), "--format=%H%x1f%aI%x1f%s")
+	if err != nil {
+		return nil, err
+	}
+	commits := []*GitCommit{}
+	for _, line := range strings.Split(strings.TrimSpace(out), "\n") {
+		fields := strings.Split(line, "\x1f")
+		if len(fields) != 3 {
+			continue
+		}
+		date, err := time.Parse(time.RFC3339, fields[1])
+		if err != nil {
+			return nil, fmt.Errorf("invalid commit date %q: %w", fields[1], err)
+		}
+		commits = append(commits, &GitCommit{Hash: fields[0], Date: date, Message: fields[2]})
+	}
+	return commits, nil
+}
+
+// PushVaultGit pushes the commits of the git backed vault to the remote
+func PushVaultGit(s Storage, vaultName string) error {
+	dir, err := vaultGitDir(s, vaultName)
+	if err != nil {
+		return err
+	}
+	err = gitEnsureRemote(dir)
+	if err != nil {
+		return err
+	}
+	_, err = runGit(dir, "push", "-q", "-u", gitRemoteName, "HEAD")
+	return err
+}
+
+// PullVaultGit fetches and merges the remote commits of the git backed vault.
+// The vault index is encrypted, hence git cannot merge it: on conflict both
+// sides and their common ancestor are decrypted using the key and merged by
+// item as SyncVault does. The item files are then taken from the winning side.
+func PullVaultGit(s Storage, vaultName string, key *Key) (*GitPullResult, error) {
+	dir, err := vaultGitDir(s, vaultName)
+	if err != nil {
+		return nil, err
+	}
+	err = gitEnsureRemote(dir)
+	if err != nil {
+		return nil, err
+	}
+
+	_, err = runGit(dir, "fetch", "-q", gitRemoteName)
+	if err != nil {
+		return nil, err
+	}
+	if _, err := runGit(dir, "rev-parse", "-q", "--verify", "FETCH_HEAD"); err != nil {
+		// the remote is empty
+		return &GitPullResult{}, nil
+	}
+
+	// the working tree is updated by the merge, lock the vault from here
+	unlock, err := s.LockVault(vaultName)
+	if err != nil {
+		return nil, err
+	}
+	defer unlock()
+
+	before, err := runGit(dir, "rev-parse", "HEAD")
+	if err != nil {
+		return nil, err
+	}
+
+	_, mergeErr := runGit(dir, "merge", "-q", "--no-edit", "FETCH_HEAD")
+	if mergeErr == nil {
+		after, err := runGit(dir, "rev-parse", "HEAD")
+		if err != nil {
+			return nil, err
+		}
+		return &GitPullResult{Updated: before != after}, nil
+	}
+
+	out, err := runGit(dir, "diff", "--name-only", "--diff-filter=U")
+	if err != nil || strings.TrimSpace(out) == "" {
+		runGit(dir, "merge", "--abort")
+		return nil, mergeErr
+	}
+	merged := strings.Split(strings.TrimSpace(out), "\n")
+	conflicts, err := gitResolveConflicts(s, dir, vaultName, key, merged)
+	if err != nil {
+		runGit(dir, "merge", "--abort")
+		return nil, fmt.Errorf("could not merge the remote changes: %w", err)
+	}
+	return &GitPullResult{Updated: true, Merged: merged, Conflicts: conflicts}, nil
+}
+
+// gitResolveConflicts resolves the merge conflicts merging the vault index and
+// taking the item files from the winning side, then commits the merge.
+// It returns the items changed on both sides.
+// The item files merged by git are not trusted: moving an item to the trash
+// could be detected as a rename and merged with a change of the other side.
+func gitResolveConflicts(s Storage, dir string, vaultName string, key *Key, conflicts []string) ([]*Conflict, error) {
+	ours, err := gitLoadVault(dir, "HEAD:"+vaultFileName, vaultName, key)
+	if err != nil {
+		return nil, err
+	}
+	theirs, err := gitLoadVault(dir, "FETCH_HEAD:"+vaultFileName, vaultName, key)
+	if err != nil {
+		return nil, err
+	}
+	// without a common ancestor, e.g. unrelated histories, the vaults are merged comparing the modification times
+	var base *vaultBase
+	if out, err := runGit(dir, "merge-base", "HEAD", "FETCH_HEAD"); err == nil {
+		if v, err := gitLoadVault(dir, strings.TrimSpace(out)+":"+vaultFileName, vaultName, key); err == nil {
+			base = newVaultBase(v)
+		}
+	}
+	// the caller holds the vault lock
+	defer holdVaultLock(ours)()
+	// store without committing, the merge is committed below
+	if gs, ok := s.(*GitStorage); ok {
+		s = gs.Storage
+	}
+	merges, itemConflicts := ours.merge(base, theirs, time.Now().UTC())
+
+	resolved := map[string]bool{vaultFileName: true}
+	for _, m := range merges {
+		if !m.changed || m.lose == nil {
+			// unchanged or changed only on a side, merged by git
+			continue
+		}
+		if m.conflict && m.win != nil && !m.lose.trashed() {
+			err = gitKeepConflictCopy(s, dir, ours, m)
+			if err != nil {
+				return nil, err
+			}
+		}
+
+		name := ""
+		if m.win != nil {
+			commit := "HEAD"
+			if m.pull {
+				commit = "FETCH_HEAD"
+			}
+			name = m.win.fileName("")
+			_, err = runGit(dir, "checkout", commit, "--", name)
+			// the file of a trashed item could be already purged
+			if err != nil && !m.win.trashed() {
+				return nil, err
+			}
+			resolved[name] = true
+		}
+
+		// remove the file of the losing side, if in a different location
+		other := m.lose.fileName("")
+		if other == name {
+			continue
+		}
+		resolved[other] = true
+		_, err = runGit(dir, "rm", "-q", "-f", "--ignore-unmatch", "--", other)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	for _, name := range conflicts {
+		if resolved[name] {
+			continue
+		}
+		// attachments are never modified, the file could exist only on a side
+		if _, err := runGit(dir, "checkout", "--ours", "--", name); err != nil {
+			_, err = runGit(dir, "checkout", "--theirs", "--", name)
+			if err != nil {
+				return nil, err
+			}
+		}
+		_, err = runGit(dir, "add", "--", name)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	ours.Modified = time.Now().UTC()
+	err = s.StoreVault(ours)
+	if err != nil {
+		return nil, err
+	}
+	return itemConflicts, gitCommitAll(dir, "Merge the remote vault changes")
+}
+
+// gitKeepConflictCopy stores the item of the losing side as conflict copy
+func gitKeepConflictCopy(s Storage, dir string, vault *Vault, m *syncMerge) error {
+	commit := "FETCH_HEAD"
+	if m.pull {
+		commit = "HEAD"
+	}
+	out, err := runGit(dir, "show", commit+":"+m.lose.fileName(""))
+	if err != nil {
+		return err
+	}
+	item, err := emptyItem(m.lose.meta.Type)
+	if err != nil {
+		return err
+	}
+	err = decrypt(vault.key, strings.NewReader(out), item)
+	if err != nil {
+		return fmt.Errorf("could not decrypt the item to keep as conflict copy: %w", err)
+	}
+	err = s.StoreConflictCopy(vault, item)
+	if err != nil {
+		return fmt.Errorf("could not keep the conflict copy: %w", err)
+	}
+	vault.Conflicts[m.id].Copy = true
+	return nil
+}
+
+// gitLoadVault decrypts the vault index stored into the git object
+func gitLoadVault(dir string, object string, vaultName string, key *Key) (*Vault, error) {
+	out, err := runGit(dir, "show", object)
+	if err != nil {
+		return nil, err
+	}
+	vault := NewVault(key, vaultName)
+	err = decrypt(key, strings.NewReader(out), vault)
+	if err != nil {
+		return nil, fmt.Errorf("could not decrypt the vault index %s: %w", object, err)
+	}
+	return vault, nil
+}
+
+// vaultGitDir returns the vault dir ensuring it is a git repository
+func vaultGitDir(s Storage, vaultName string) (string, error) {
+	dir := vaultRootPath(s, vaultName)
+	if _, err := os.Stat(dir); errors.Is(err, os.ErrNotExist) {
+		return "", fmt.Errorf("%w: %q", ErrVaultNotFound, vaultName)
+	}
+	if !isGitRepository(dir) {
+		return "", fmt.Errorf("vault %q is not a git repository", vaultName)
+	}
+	return dir, nil
+}
+
+// gitEnsureRemote returns ErrGitNoRemote if the repository has no remote
+func gitEnsureRemote(dir string) error {
+	if _, err := runGit(dir, "remote", "get-url", gitRemoteName); err != nil {
+		return ErrGitNoRemote
+	}
+	return nil
+}
+
+// gitConfigureIdentity sets a repository identity if none is configured,
+// otherwise git would refuse to commit
+func gitConfigureIdentity(dir string) error {
+	if out, err := runGit(dir, "config", "user.email"); err == nil && strings.TrimSpace(out) != "" {
+		return nil
+	}
+	_, err := runGit(dir, "config", "user.name", "Paw")
+	if err != nil {
+		return err
+	}
+	_, err = runGit(dir, "config", "user.email", "paw@localhost")
+	return err
+}
+
+// gitCommitAll commits all the changes into the repository, if any
+func gitCommitAll(dir string, message string) error {
+	_, err := runGit(dir, "add", "-A")
+	if err != nil {
+		return err
+	}
+	if _, err := runGit(dir, "diff", "--cached", "--quiet"); err == nil {
+		// nothing to commit, unless a merge is in progress
+		if _, err := os.Stat(filepath.Join(dir, ".git", "MERGE_HEAD")); err != nil {
+			return nil
+		}
+	}
+	_, err = runGit(dir, "commit", "-q", "-m", message)
+	return err
+}
+
+// isGitRepository reports whether the dir is the root of a git repository
+func isGitRepository(dir string) bool {
+	_, err := os.Stat(filepath.Join(dir, ".git"))
+	return err == nil
+}
+
+// runGit runs the git command into the dir returning its standard output
+func runGit(dir string, args ...string) (string, error) {
+	cmd := exec.Command("git", args...)
+	cmd.Dir = dir
+	stdout := &bytes.Buffer{}
+	stderr := &bytes.Buffer{}
+	cmd.Stdout = stdout
+	cmd.Stderr = stderr
+	err := cmd.Run()
+	if err != nil {
+		msg := strings.TrimSpace(stderr.String())
+		if msg == "" {
+			return "", fmt.Errorf("git %s: %w", args[0], err)
+		}
+		return "", fmt.Errorf("git %s: %s", args[0], msg)
+	}
+	return stdout.String(), nil
+}
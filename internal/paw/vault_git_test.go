@@ -0,0 +1,202 @@
+// SPDX-FileCopyrightText: 2025 Luca Corbo, Paw contributors
+// SPDX-License-Identifier: AGPL-3.0-or-later
+
+package paw
+
+import (
+	"os"
+	"os/exec"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestVaultGit(t *testing.T) {
+	if _, err := exec.LookPath("git"); err != nil {
+		t.Skip("git is not available")
+	}
+
+	name := "test"
+	password := "secret"
+
+	remote, err := os.MkdirTemp(os.TempDir(), "paw-git")
+	require.NoError(t, err)
+	defer os.RemoveAll(remote)
+	_, err = runGit(remote, "init", "-q", "--bare")
+	require.NoError(t, err)
+
+	// first device: the vault is committed and pushed
+	s1 := NewGitStorage(newSyncTestStorage(t))
+	key, err := s1.CreateVaultKey(name, password)
+	require.NoError(t, err)
+	v1, err := s1.CreateVault(name, key)
+	require.NoError(t, err)
+	require.NoError(t, InitVaultGit(s1, name, remote))
+	assert.True(t, IsVaultGitRepository(s1, name))
+	note := storeSyncTestNote(t, s1, v1, "private note", "first")
+	trashed := storeSyncTestNote(t, s1, v1, "trashed", "trashed")
+
+	commits, err := VaultGitLog(s1, name, 10)
+	require.NoError(t, err)
+	require.Len(t, commits, 5)
+	assert.Equal(t, "Update the vault index", commits[0].Message)
+	assert.Equal(t, "Store note item "+note.ID(), commits[3].Message)
+	assert.Equal(t, "Initialise the vault", commits[4].Message)
+	for _, c := range commits {
+		assert.NotContains(t, c.Message, "private")
+	}
+	require.NoError(t, PushVaultGit(s1, name))
+
+	// second device: the vault is cloned
+	s2 := NewGitStorage(newSyncTestStorage(t))
+	assert.ErrorIs(t, InitVaultGit(s2, name, ""), ErrVaultNotFound)
+	require.NoError(t, InitVaultGit(s2, name, remote))
+	key2, err := s2.LoadVaultKey(name, password)
+	require.NoError(t, err)
+	v2, err := s2.LoadVault(name, key2)
+	require.NoError(t, err)
+	assert.Equal(t, "first", loadSyncTestNote(t, s2, v2, "private note").Value)
+
+	// concurrent changes: the vault index conflicts and is merged
+	edited := loadSyncTestNote(t, s1, v1, "private note")
+	edited.Value = "second"
+	edited.Modified = time.Now().UTC().Add(time.Second)
+	require.NoError(t, s1.StoreItem(v1, edited))
+	require.NoError(t, v1.AddItem(edited))
+	require.NoError(t, s1.StoreVault(v1))
+	require.NoError(t, MoveItemToTrash(s1, v1, trashed))
+	require.NoError(t, PushVaultGit(s1, name))
+
+	storeSyncTestNote(t, s2, v2, "added", "added")
+	restored := loadSyncTestNote(t, s2, v2, "trashed")
+	restored.Value = "modified after the deletion"
+	restored.Modified = time.Now().UTC().Add(time.Minute)
+	require.NoError(t, s2.StoreItem(v2, restored))
+	require.NoError(t, v2.AddItem(restored))
+	require.NoError(t, s2.StoreVault(v2))
+
+	res, err := PullVaultGit(s2, name, key2)
+	require.NoError(t, err)
+	assert.True(t, res.Updated)
+	assert.Contains(t, res.Merged, vaultFileName)
+	// the item has been changed on both the sides, the deletion is discarded
+	require.Len(t, res.Conflicts, 1)
+	assert.Equal(t, trashed.ID(), res.Conflicts[0].ID())
+	assert.False(t, res.Conflicts[0].Deleted.IsZero())
+
+	v2, err = s2.LoadVault(name, key2)
+	require.NoError(t, err)
+	assert.Equal(t, "second", loadSyncTestNote(t, s2, v2, "private note").Value)
+	assert.Equal(t, "added", loadSyncTestNote(t, s2, v2, "added").Value)
+	// the item modified after the deletion wins over the trashed one
+	assert.Equal(t, "modified after the deletion", loadSyncTestNote(t, s2, v2, "trashed").Value)
+	assert.Empty(t, v2.Trash)
+	assert.Contains(t, v2.Conflicts, trashed.ID())
+	assert.NoFileExists(t, trashItemPath(s2, name, trashed.ID()))
+	status, err := runGit(vaultRootPath(s2, name), "status", "--porcelain")
+	require.NoError(t, err)
+	assert.Empty(t, status)
+
+	// the merge is pushed and fast-forwarded on the first device
+	require.NoError(t, PushVaultGit(s2, name))
+	res, err = PullVaultGit(s1, name, key)
+	require.NoError(t, err)
+	assert.True(t, res.Updated)
+	assert.Empty(t, res.Merged)
+	v1, err = s1.LoadVault(name, key)
+	require.NoError(t, err)
+	assert.Equal(t, 3, v1.Size())
+	assert.Len(t, v1.Conflicts, 1)
+
+	res, err = PullVaultGit(s1, name, key)
+	require.NoError(t, err)
+	assert.False(t, res.Updated)
+
+	// the discarded change is committed as conflict copy
+	edited = loadSyncTestNote(t, s1, v1, "private note")
+	edited.Value = "discarded"
+	edited.Modified = time.Now().UTC().Add(time.Hour)
+	require.NoError(t, s1.StoreItem(v1, edited))
+	require.NoError(t, v1.AddItem(edited))
+	require.NoError(t, s1.StoreVault(v1))
+	require.NoError(t, PushVaultGit(s1, name))
+	edited = loadSyncTestNote(t, s2, v2, "private note")
+	edited.Value = "kept"
+	edited.Modified = time.Now().UTC().Add(2 * time.Hour)
+	require.NoError(t, s2.StoreItem(v2, edited))
+	require.NoError(t, v2.AddItem(edited))
+	require.NoError(t, s2.StoreVault(v2))
+
+	res, err = PullVaultGit(s2, name, key2)
+	require.NoError(t, err)
+	require.Len(t, res.Conflicts, 1)
+	assert.True(t, res.Conflicts[0].Copy)
+	v2, err = s2.LoadVault(name, key2)
+	require.NoError(t, err)
+	assert.Equal(t, "kept", loadSyncTestNote(t, s2, v2, "private note").Value)
+	item, err := LoadConflictCopy(s2, v2, note.ID())
+	require.NoError(t, err)
+	assert.Equal(t, "discarded", item.(*Note).Value)
+	status, err = runGit(vaultRootPath(s2, name), "status", "--porcelain")
+	require.NoError(t, err)
+	assert.Empty(t, status)
+
+	require.NoError(t, PushVaultGit(s2, name))
+	_, err = PullVaultGit(s1, name, key)
+	require.NoError(t, err)
+	v1, err = s1.LoadVault(name, key)
+	require.NoError(t, err)
+	item, err = LoadConflictCopy(s1, v1, note.ID())
+	require.NoError(t, err)
+	assert.Equal(t, "discarded", item.(*Note).Value)
+}
+
+func TestVaultGit_NotRepository(t *testing.T) {
+	s := NewGitStorage(newSyncTestStorage(t))
+	key, err := s.CreateVaultKey("test", "secret")
+	require.NoError(t, err)
+	vault, err := s.CreateVault("test", key)
+	require.NoError(t, err)
+
+	// the changes of the vaults that are not repositories are not committed
+	storeSyncTestNote(t, s, vault, "note", "value")
+	assert.False(t, IsVaultGitRepository(s, "test"))
+	_, err = VaultGitLog(s, "test", 10)
+	assert.ErrorContains(t, err, "not a git repository")
+}
+
+func TestVaultGit_CommitFailure(t *testing.T) {
+	if _, err := exec.LookPath("git"); err != nil {
+		t.Skip("git is not available")
+	}
+
+	s := NewGitStorage(newSyncTestStorage(t))
+	key, err := s.CreateVaultKey("test", "secret")
+	require.NoError(t, err)
+	vault, err := s.CreateVault("test", key)
+	require.NoError(t, err)
+	require.NoError(t, InitVaultGit(s, "test", ""))
+
+	// a failing hook does not fail the store, the changes are committed by the next commit
+	hook := filepath.Join(vaultRootPath(s, "test"), ".git", "hooks", "pre-commit")
+	require.NoError(t, os.MkdirAll(filepath.Dir(hook), 0700))
+	require.NoError(t, os.WriteFile(hook, []byte("#!/bin/sh\nexit 1\n"), 0700))
+	note := storeSyncTestNote(t, s, vault, "note", "value")
+	assert.Equal(t, "value", loadSyncTestNote(t, s, vault, "note").Value)
+	commits, err := VaultGitLog(s, "test", 10)
+	require.NoError(t, err)
+	require.Len(t, commits, 1)
+
+	require.NoError(t, os.Remove(hook))
+	note.Value = "modified"
+	require.NoError(t, s.StoreItem(vault, note))
+	commits, err = VaultGitLog(s, "test", 10)
+	require.NoError(t, err)
+	require.Len(t, commits, 2)
+	status, err := runGit(vaultRootPath(s, "test"), "status", "--porcelain")
+	require.NoError(t, err)
+	assert.Empty(t, status)
+}
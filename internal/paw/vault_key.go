@@ -0,0 +1,358 @@
+// SPDX-FileCopyrightText: 2025 Luca Corbo, Paw contributors
+// SPDX-License-Identifier: AGPL-3.0-or-later
+
+package paw
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"io"
+	"io/fs"
+	"log"
+	"os"
+	"path/filepath"
+)
+
+// ChangeVaultPassword changes the password used to protect the vault key.
+// The key does not change so the vault and its items are not re-encrypted.
+// On a git backed vault the key file protected by the previous password is kept
+// into the git history, see IsVaultGitRepository.
+func ChangeVaultPassword(s VaultStorage, name string, password string, newPassword string) error {
+	if newPassword == "" {
+		return errors.New("the new password cannot be empty")
+	}
+	unlock, err := s.LockVault(name)
+	if err != nil {
+		return err
+	}
+	defer unlock()
+
+	key, err := s.LoadVaultKey(name, password)
+	if err != nil {
+		return fmt.Errorf("could not unlock the vault: %w", err)
+	}
+	return s.StoreVaultKey(name, newPassword, key)
+}
+
+// RotateVaultKey generates a new key for the vault re-encrypting the vault and
+// all its files. The new key is protected using the vault password.
+// The re-encrypted files are written into a staging dir then swapped with the
+// vault ones, the key last, see keyRotation. On failure the swapped files are
+// restored so the vault is still accessible using the previous key.
+// On a git backed vault the previous key file and the files encrypted with it
+// are kept into the git history, see IsVaultGitRepository.
+// It returns the vault using the new key.
+func RotateVaultKey(s Storage, vault *Vault, password string) (*Vault, error) {
+	unlock, err := LockVault(s, vault)
+	if err != nil {
+		return nil, err
+	}
+	defer unlock()
+
+	dir := vaultRootPath(s, vault.Name)
+	// a rotation left by a crashed process is rolled back first
+	err = recoverKeyRotation(dir)
+	if err != nil {
+		return nil, err
+	}
+
+	key, err := s.LoadVaultKey(vault.Name, password)
+	if err != nil {
+		return nil, fmt.Errorf("could not unlock the vault: %w", err)
+	}
+	if key.String() != vault.key.String() {
+		return nil, errors.New("the vault key does not match the stored one")
+	}
+	// the changes of the other writers are re-encrypted too
+	_, err = MergeVault(s, vault)
+	if err != nil {
+		return nil, err
+	}
+
+	// decrypt all the files first, rotation cannot proceed if any item is not readable
+	items := map[string]Item{}
+	vault.Range(func(id string, meta *Metadata) bool {
+		var item Item
+		item, err = s.LoadItem(vault, meta)
+		if err != nil {
+			err = fmt.Errorf("could not load item %q: %w", meta.Name, err)
+			return false
+		}
+		items[itemPath(s, vault.Name, id)] = item
+		return true
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	// the trashed items are re-encrypted too, otherwise they could not be restored
+	for id, tombstone := range vault.Trash {
+		name := trashItemPath(s, vault.Name, id)
+		item, err := loadItemFile(vault.key, name)
+		if errors.Is(err, os.ErrNotExist) {
+			// nothing to re-encrypt
+			continue
+		}
+		if err != nil {
+			return nil, fmt.Errorf("could not load the trashed item %q: %w", tombstone.Name, err)
+		}
+		items[name] = item
+	}
+
+	// the conflict copies too, otherwise they could not be restored
+	for id, c := range vault.Conflicts {
+		if !c.Copy {
+			continue
+		}
+		item, err := s.LoadConflictCopy(vault, c.Metadata)
+		if errors.Is(err, os.ErrNotExist) {
+			continue
+		}
+		if err != nil {
+			return nil, fmt.Errorf("could not load the conflict copy of %q: %w", c.Name, err)
+		}
+		items[conflictCopyPath(s, vault.Name, id)] = item
+	}
+
+	// the attachments are decrypted in memory, their size is limited by AttachmentMaxSize
+	attachments := map[string][]byte{}
+	for _, item := range items {
+		for _, attachment := range item.GetMetadata().Attachments {
+			name := attachmentPath(s, vault.Name, attachment.ID)
+			if _, ok := attachments[name]; ok {
+				continue
+			}
+			var data []byte
+			data, err = loadAttachmentContent(s, vault, attachment)
+			if err != nil {
+				return nil, fmt.Errorf("could not load the attachment %q of item %q: %w", attachment.Name, item.GetMetadata().Name, err)
+			}
+			attachments[name] = data
+		}
+	}
+
+	// the new age identity is protected with the vault password once stored
+	newKey, err := MakeOneTimeKey()
+	if err != nil {
+		return nil, err
+	}
+
+	rotated := *vault
+	rotated.key = newKey
+	rotated.lockDepth = 0
+	rotated.pendingCopies = nil
+
+	r, err := newKeyRotation(dir)
+	if err != nil {
+		return nil, err
+	}
+	err = r.stageAll(&rotated, password, items, attachments)
+	if err != nil {
+		return nil, r.rollback(err)
+	}
+	err = r.swap()
+	if err != nil {
+		return nil, r.rollback(err)
+	}
+	r.cleanup()
+
+	if gs, ok := s.(*GitStorage); ok {
+		gs.commit(vault.Name, "Rotate the vault key")
+	}
+	rotated.base = newVaultBase(&rotated)
+	return &rotated, nil
+}
+
+// loadAttachmentContent returns the decrypted content of the attachment
+func loadAttachmentContent(s Storage, vault *Vault, attachment *Attachment) ([]byte, error) {
+	r, err := s.LoadAttachment(vault, attachment)
+	if err != nil {
+		return nil, err
+	}
+	defer r.Close()
+	return io.ReadAll(r)
+}
+
+const (
+	// rotationDirName is the staging dir of a key rotation into the vault dir,
+	// the temporary suffix excludes it from git
+	rotationDirName = "rotation" + tmpFileSuffix
+	// rotationReplacedDirName is the dir into the staging dir holding the replaced files
+	rotationReplacedDirName = "replaced"
+)
+
+// keyRotation replaces the vault files with the ones encrypted using a new key.
+// The files are written into a staging dir then swapped with the vault ones,
+// the key last. The replaced files are moved into the staging dir, so that
+// until the key is swapped they can be restored, see recoverKeyRotation.
+type keyRotation struct {
+	// dir is the vault dir
+	dir string
+	// staging is the staging dir
+	staging string
+	// files lists the vault files to replace, the key excluded
+	files []string
+}
+
+// newKeyRotation creates the staging dir into the vault dir
+func newKeyRotation(dir string) (*keyRotation, error) {
+	staging := filepath.Join(dir, rotationDirName)
+	err := os.Mkdir(staging, 0700)
+	if err != nil {
+		return nil, fmt.Errorf("could not create the key rotation dir: %w", err)
+	}
+	return &keyRotation{dir: dir, staging: staging}, nil
+}
+
+// stageAll stages the files of the vault encrypted with its key. The key is
+// staged last: a staged key marks the staging as complete.
+func (r *keyRotation) stageAll(vault *Vault, password string, items map[string]Item, attachments map[string][]byte) error {
+	for name, item := range items {
+		err := r.stage(name, func(w io.Writer) error {
+			return encrypt(vault.key, w, item)
+		})
+		if err != nil {
+			return fmt.Errorf("could not re-encrypt item %q: %w", item.GetMetadata().Name, err)
+		}
+	}
+	for name, data := range attachments {
+		err := r.stage(name, func(w io.Writer) error {
+			_, err := encryptStream(vault.key, w, bytes.NewReader(data))
+			return err
+		})
+		if err != nil {
+			return fmt.Errorf("could not re-encrypt the attachment %s: %w", filepath.Base(name), err)
+		}
+	}
+	err := r.stage(filepath.Join(r.dir, vaultFileName), func(w io.Writer) error {
+		return encrypt(vault.key, w, vault)
+	})
+	if err != nil {
+		return fmt.Errorf("could not re-encrypt the vault: %w", err)
+	}
+	err = r.stage(filepath.Join(r.dir, keyFileName), func(w io.Writer) error {
+		return StoreKey(vault.key, password, w)
+	})
+	if err != nil {
+		return fmt.Errorf("could not store the new vault key: %w", err)
+	}
+	return nil
+}
+
+// stage writes the content of the named vault file into the staging dir
+func (r *keyRotation) stage(name string, write func(w io.Writer) error) error {
+	rel, err := filepath.Rel(r.dir, name)
+	if err != nil {
+		return err
+	}
+	staged := filepath.Join(r.staging, rel)
+	err = os.MkdirAll(filepath.Dir(staged), 0700)
+	if err != nil {
+		return err
+	}
+	w, err := createAtomicFile(staged)
+	if err != nil {
+		return err
+	}
+	defer w.Close()
+	err = write(w)
+	if err != nil {
+		return err
+	}
+	err = w.Commit()
+	if err != nil {
+		return err
+	}
+	if rel != keyFileName {
+		r.files = append(r.files, name)
+	}
+	return nil
+}
+
+// swap replaces the vault files with the staged ones, the key last
+func (r *keyRotation) swap() error {
+	for _, name := range r.files {
+		err := r.swapFile(name)
+		if err != nil {
+			return err
+		}
+	}
+	return r.swapFile(filepath.Join(r.dir, keyFileName))
+}
+
+// swapFile moves the named vault file into the replaced dir and the staged one in its place
+func (r *keyRotation) swapFile(name string) error {
+	rel, err := filepath.Rel(r.dir, name)
+	if err != nil {
+		return err
+	}
+	replaced := filepath.Join(r.staging, rotationReplacedDirName, rel)
+	err = os.MkdirAll(filepath.Dir(replaced), 0700)
+	if err != nil {
+		return fmt.Errorf("could not replace %s: %w", rel, err)
+	}
+	err = os.Rename(name, replaced)
+	if err != nil && !errors.Is(err, os.ErrNotExist) {
+		return fmt.Errorf("could not replace %s: %w", rel, err)
+	}
+	err = os.Rename(filepath.Join(r.staging, rel), name)
+	if err != nil {
+		return fmt.Errorf("could not replace %s: %w", rel, err)
+	}
+	syncDir(filepath.Dir(name))
+	return nil
+}
+
+// rollback restores the replaced files and removes the staging dir. It returns
+// the cause of the failure along with the rollback one, if any.
+func (r *keyRotation) rollback(cause error) error {
+	err := recoverKeyRotation(r.dir)
+	if err != nil {
+		return fmt.Errorf("%v, the rollback failed and the vault could be unreadable: %w", cause, err)
+	}
+	return cause
+}
+
+// cleanup removes the staging dir once the key has been swapped
+func (r *keyRotation) cleanup() {
+	err := os.RemoveAll(r.staging)
+	if err != nil {
+		log.Printf("could not remove the key rotation dir: %s", err)
+	}
+}
+
+// recoverKeyRotation rolls back the key rotation left into the vault dir by an
+// interrupted process: unless the key has been swapped the replaced files are
+// restored, then the staging dir is removed.
+func recoverKeyRotation(dir string) error {
+	staging := filepath.Join(dir, rotationDirName)
+	if _, err := os.Stat(staging); errors.Is(err, os.ErrNotExist) {
+		return nil
+	}
+	if _, err := os.Stat(filepath.Join(staging, keyFileName)); err == nil {
+		replaced := filepath.Join(staging, rotationReplacedDirName)
+		err = filepath.WalkDir(replaced, func(path string, d fs.DirEntry, err error) error {
+			if errors.Is(err, os.ErrNotExist) {
+				return nil
+			}
+			if err != nil || d.IsDir() {
+				return err
+			}
+			rel, err := filepath.Rel(replaced, path)
+			if err != nil {
+				return err
+			}
+			return os.Rename(path, filepath.Join(dir, rel))
+		})
+		if err != nil {
+			return fmt.Errorf("could not restore the files replaced by the key rotation: %w", err)
+		}
+		syncDir(dir)
+	}
+	err := os.RemoveAll(staging)
+	if err != nil {
+		return fmt.Errorf("could not remove the key rotation dir: %w", err)
+	}
+	return nil
+}
@@ -0,0 +1,184 @@
+// SPDX-FileCopyrightText: 2025 Luca Corbo, Paw contributors
+// SPDX-License-Identifier: AGPL-3.0-or-later
+
+package paw
+
+import (
+	"io"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestChangeVaultPassword(t *testing.T) {
+	name := "test"
+	password := "secret"
+	newPassword := "new secret"
+
+	root, err := os.MkdirTemp(os.TempDir(), "paw")
+	require.NoError(t, err)
+	defer os.RemoveAll(root)
+
+	storage, err := NewOSStorageRooted(root)
+	require.NoError(t, err)
+
+	key, err := storage.CreateVaultKey(name, password)
+	require.NoError(t, err)
+
+	err = ChangeVaultPassword(storage, name, "wrong", newPassword)
+	require.Error(t, err)
+
+	err = ChangeVaultPassword(storage, name, password, newPassword)
+	require.NoError(t, err)
+
+	_, err = storage.LoadVaultKey(name, password)
+	require.Error(t, err)
+
+	loadedKey, err := storage.LoadVaultKey(name, newPassword)
+	require.NoError(t, err)
+	assert.Equal(t, key.String(), loadedKey.String())
+}
+
+func TestRotateVaultKey(t *testing.T) {
+	name := "test"
+	password := "secret"
+
+	root, err := os.MkdirTemp(os.TempDir(), "paw")
+	require.NoError(t, err)
+	defer os.RemoveAll(root)
+
+	storage, err := NewOSStorageRooted(root)
+	require.NoError(t, err)
+
+	key, err := storage.CreateVaultKey(name, password)
+	require.NoError(t, err)
+
+	vault, err := storage.CreateVault(name, key)
+	require.NoError(t, err)
+
+	note := NewNote()
+	note.Name = "test note"
+	note.Value = "a secret note"
+	require.NoError(t, storage.StoreItem(vault, note))
+	require.NoError(t, vault.AddItem(note))
+	require.NoError(t, storage.StoreVault(vault))
+	_, err = AddAttachment(storage, vault, note, "file.txt", strings.NewReader("attached"))
+	require.NoError(t, err)
+
+	trashed := NewNote()
+	trashed.Name = "trashed note"
+	trashed.Value = "a deleted note"
+	require.NoError(t, storage.StoreItem(vault, trashed))
+	require.NoError(t, vault.AddItem(trashed))
+	require.NoError(t, MoveItemToTrash(storage, vault, trashed))
+
+	// the change discarded by a conflict is kept as conflict copy
+	app, err := storage.LoadVault(name, key)
+	require.NoError(t, err)
+	cli, err := storage.LoadVault(name, key)
+	require.NoError(t, err)
+	for i, v := range []*Vault{cli, app} {
+		item := loadSyncTestNote(t, storage, v, note.Name)
+		item.Value = []string{"cli", "app"}[i]
+		item.Modified = time.Now().UTC().Add(time.Duration(i+1) * time.Minute)
+		require.NoError(t, storage.StoreItem(v, item))
+		require.NoError(t, v.AddItem(item))
+		require.NoError(t, storage.StoreVault(v))
+	}
+	require.Contains(t, app.Conflicts, note.ID())
+	require.True(t, app.Conflicts[note.ID()].Copy)
+
+	rotated, err := RotateVaultKey(storage, vault, password)
+	require.NoError(t, err)
+	require.NotEqual(t, key.String(), rotated.Key().String())
+	assert.NoDirExists(t, filepath.Join(vaultRootPath(storage, name), rotationDirName))
+
+	// the previous key cannot decrypt the vault anymore
+	_, err = storage.LoadVault(name, key)
+	require.Error(t, err)
+
+	newKey, err := storage.LoadVaultKey(name, password)
+	require.NoError(t, err)
+	assert.Equal(t, rotated.Key().String(), newKey.String())
+
+	loadedVault, err := storage.LoadVault(name, newKey)
+	require.NoError(t, err)
+	meta, ok := loadedVault.ItemMetadataByName(NoteItemType, note.Name)
+	require.True(t, ok)
+	item, err := storage.LoadItem(loadedVault, meta)
+	require.NoError(t, err)
+	assert.Equal(t, "app", item.(*Note).Value)
+
+	// the attachments are re-encrypted too
+	r, _, err := OpenAttachment(storage, loadedVault, item, "file.txt")
+	require.NoError(t, err)
+	content, err := io.ReadAll(r)
+	require.NoError(t, err)
+	r.Close()
+	assert.Equal(t, "attached", string(content))
+
+	// the trashed items can be restored using the new key
+	meta, err = RestoreItemFromTrash(storage, loadedVault, trashed.ID())
+	require.NoError(t, err)
+	item, err = storage.LoadItem(loadedVault, meta)
+	require.NoError(t, err)
+	assert.Equal(t, trashed.Value, item.(*Note).Value)
+
+	// the conflict copies can be restored using the new key
+	restored, err := RestoreConflictCopy(storage, loadedVault, note.ID())
+	require.NoError(t, err)
+	assert.Equal(t, "cli", restored.(*Note).Value)
+	assert.Len(t, restored.GetMetadata().Attachments, 1)
+}
+
+func TestRotateVaultKey_Interrupted(t *testing.T) {
+	s := newSyncTestStorage(t)
+	key, err := s.CreateVaultKey("test", "secret")
+	require.NoError(t, err)
+	vault, err := s.CreateVault("test", key)
+	require.NoError(t, err)
+	note := storeSyncTestNote(t, s, vault, "note", "value")
+	dir := vaultRootPath(s, "test")
+	items := map[string]Item{itemPath(s, "test", note.ID()): note}
+
+	newKey, err := MakeOneTimeKey()
+	require.NoError(t, err)
+	rotated := *vault
+	rotated.key = newKey
+
+	// the rotation is interrupted once the item has been swapped
+	r, err := newKeyRotation(dir)
+	require.NoError(t, err)
+	require.NoError(t, r.stageAll(&rotated, "secret", items, nil))
+	require.NoError(t, r.swapFile(itemPath(s, "test", note.ID())))
+	_, err = s.LoadItem(vault, note.GetMetadata())
+	require.Error(t, err, "the item is encrypted with the new key")
+
+	// the replaced files are restored once the rotation is stale
+	assert.Empty(t, recoverVaultFiles(dir, time.Now()))
+	assert.DirExists(t, r.staging)
+	assert.Empty(t, recoverVaultFiles(dir, time.Now().Add(staleTmpFileAge)))
+	assert.NoDirExists(t, r.staging)
+	v, err := s.LoadVault("test", key)
+	require.NoError(t, err)
+	assert.Equal(t, "value", loadSyncTestNote(t, s, v, "note").Value)
+
+	// the rotation is completed once the key has been swapped
+	r, err = newKeyRotation(dir)
+	require.NoError(t, err)
+	require.NoError(t, r.stageAll(&rotated, "secret", items, nil))
+	require.NoError(t, r.swap())
+	require.NoError(t, recoverKeyRotation(dir))
+	assert.NoDirExists(t, r.staging)
+	loadedKey, err := s.LoadVaultKey("test", "secret")
+	require.NoError(t, err)
+	assert.Equal(t, newKey.String(), loadedKey.String())
+	v, err = s.LoadVault("test", loadedKey)
+	require.NoError(t, err)
+	assert.Equal(t, "value", loadSyncTestNote(t, s, v, "note").Value)
+}
@@ -0,0 +1,140 @@
+// SPDX-FileCopyrightText: 2025 Luca Corbo, Paw contributors
+// SPDX-License-Identifier: AGPL-3.0-or-later
+
+package paw
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"sync"
+	"time"
+)
+
+const (
+	// vaultLockTimeout is the max time to wait for a vault locked by another writer
+	vaultLockTimeout = 30 * time.Second
+	// vaultLockRetry is the interval between the attempts to lock a vault
+	vaultLockRetry = 50 * time.Millisecond
+)
+
+// ErrVaultLocked is returned when a vault is locked by another writer for longer than the timeout
+var ErrVaultLocked = errors.New("vault locked by another writer")
+
+var (
+	vaultMutexesMu sync.Mutex
+	// vaultMutexes holds the in-process locks by lock file name
+	vaultMutexes = map[string]*sync.Mutex{}
+)
+
+// vaultMutex returns the in-process lock for the named lock file
+func vaultMutex(name string) *sync.Mutex {
+	vaultMutexesMu.Lock()
+	defer vaultMutexesMu.Unlock()
+	mu, ok := vaultMutexes[name]
+	if !ok {
+		mu = &sync.Mutex{}
+		vaultMutexes[name] = mu
+	}
+	return mu
+}
+
+// lockVaultFile acquires the lock on the named file, used by the storages to
+// serialise the read-modify-write sequences on a vault among the writers: an
+// in-process mutex serialises the goroutines, e.g. the app and the Secret
+// Service, and an advisory lock on the file the processes, e.g. the app, the
+// CLI and the browser extension.
+// The lock is not reentrant, see LockVault. The returned func releases the lock.
+func lockVaultFile(name string) (func(), error) {
+	deadline := time.Now().Add(vaultLockTimeout)
+	mu := vaultMutex(name)
+	for !mu.TryLock() {
+		if time.Now().After(deadline) {
+			return nil, ErrVaultLocked
+		}
+		time.Sleep(vaultLockRetry)
+	}
+
+	var f *os.File
+	for {
+		var err error
+		f, err = tryLockVaultFile(name)
+		if err != nil {
+			mu.Unlock()
+			return nil, err
+		}
+		if f != nil {
+			break
+		}
+		if time.Now().After(deadline) {
+			mu.Unlock()
+			return nil, ErrVaultLocked
+		}
+		time.Sleep(vaultLockRetry)
+	}
+
+	var once sync.Once
+	return func() {
+		once.Do(func() {
+			unlockFile(f)
+			f.Close()
+			mu.Unlock()
+		})
+	}, nil
+}
+
+// tryLockVaultFile tries to acquire the advisory lock on the named file
+// returning the locked file, nil if locked by another process
+func tryLockVaultFile(name string) (*os.File, error) {
+	f, err := os.OpenFile(name, os.O_RDWR|os.O_CREATE, 0600)
+	if err != nil {
+		return nil, fmt.Errorf("could not open the lock file: %w", err)
+	}
+	ok, err := tryLockFile(f)
+	if err != nil || !ok {
+		f.Close()
+		if err != nil {
+			return nil, fmt.Errorf("could not lock the file: %w", err)
+		}
+		return nil, nil
+	}
+	return f, nil
+}
+
+// LockVault acquires the lock on the vault on behalf of the vault instance, see
+// Storage.LockVault. The lock can be acquired again through the same instance
+// without blocking, e.g. by the storage storing the vault while the caller
+// holds the lock, so a vault instance must not be shared among goroutines.
+// The returned func releases the lock.
+func LockVault(s Storage, vault *Vault) (func(), error) {
+	if vault.lockDepth > 0 {
+		return holdVaultLock(vault), nil
+	}
+	unlock, err := s.LockVault(vault.Name)
+	if err != nil {
+		return nil, err
+	}
+	release := holdVaultLock(vault)
+	var once sync.Once
+	return func() {
+		once.Do(func() {
+			release()
+			if vault.lockDepth == 0 {
+				unlock()
+			}
+		})
+	}, nil
+}
+
+// holdVaultLock records that the vault lock is held on behalf of the vault
+// instance, e.g. a vault loaded by a caller that acquired the lock by name.
+// The returned func releases the record.
+func holdVaultLock(vault *Vault) func() {
+	vault.lockDepth++
+	var once sync.Once
+	return func() {
+		once.Do(func() {
+			vault.lockDepth--
+		})
+	}
+}
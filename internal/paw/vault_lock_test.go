@@ -0,0 +1,141 @@
+// SPDX-FileCopyrightText: 2025 Luca Corbo, Paw contributors
+// SPDX-License-Identifier: AGPL-3.0-or-later
+
+package paw
+
+import (
+	"os"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestLockVault(t *testing.T) {
+	root, err := os.MkdirTemp(os.TempDir(), "paw")
+	require.NoError(t, err)
+	defer os.RemoveAll(root)
+
+	s, err := NewOSStorageRooted(root)
+	require.NoError(t, err)
+	name := vaultLockPath(s, "test")
+
+	unlock, err := s.LockVault("test")
+	require.NoError(t, err)
+
+	// another process cannot acquire the lock
+	f, err := os.Open(name)
+	require.NoError(t, err)
+	defer f.Close()
+	ok, err := tryLockFile(f)
+	require.NoError(t, err)
+	assert.False(t, ok)
+
+	// nor another goroutine
+	locked := make(chan struct{})
+	released := make(chan struct{})
+	go func() {
+		unlock, err := s.LockVault("test")
+		if err != nil {
+			t.Error(err)
+			close(locked)
+			return
+		}
+		close(locked)
+		<-released
+		unlock()
+	}()
+	select {
+	case <-locked:
+		t.Fatal("the lock is held by another goroutine")
+	case <-time.After(5 * vaultLockRetry):
+	}
+
+	unlock()
+	unlock()
+	select {
+	case <-locked:
+	case <-time.After(vaultLockTimeout):
+		t.Fatal("the lock has not been acquired once released")
+	}
+	ok, err = tryLockFile(f)
+	require.NoError(t, err)
+	assert.False(t, ok, "held by the goroutine")
+	close(released)
+
+	// the goroutine releases the lock, wait for it
+	unlock, err = s.LockVault("test")
+	require.NoError(t, err)
+	unlock()
+	ok, err = tryLockFile(f)
+	require.NoError(t, err)
+	assert.True(t, ok)
+	require.NoError(t, unlockFile(f))
+}
+
+func TestLockVault_Vault(t *testing.T) {
+	s := newSyncTestStorage(t)
+	key, err := s.CreateVaultKey("test", "secret")
+	require.NoError(t, err)
+	v, err := s.CreateVault("test", key)
+	require.NoError(t, err)
+
+	// the lock can be acquired again through the same vault instance
+	unlock, err := LockVault(s, v)
+	require.NoError(t, err)
+	storeSyncTestNote(t, s, v, "note", "value")
+	unlockNested, err := LockVault(s, v)
+	require.NoError(t, err)
+	unlockNested()
+	unlockNested()
+	assert.Equal(t, 1, v.lockDepth)
+
+	// the writers using other instances are serialised
+	other, err := s.LoadVault("test", key)
+	require.NoError(t, err)
+	var mu sync.Mutex
+	events := []string{}
+	event := func(e string) {
+		mu.Lock()
+		defer mu.Unlock()
+		events = append(events, e)
+	}
+	var wg sync.WaitGroup
+	wg.Add(1)
+	go func() {
+		defer wg.Done()
+		unlock, err := LockVault(s, other)
+		if err != nil {
+			t.Error(err)
+			return
+		}
+		defer unlock()
+		event("other locked")
+		note := NewNote()
+		note.Name = "other note"
+		err = s.StoreItem(other, note)
+		if err == nil {
+			err = other.AddItem(note)
+		}
+		if err == nil {
+			err = s.StoreVault(other)
+		}
+		if err != nil {
+			t.Error(err)
+		}
+	}()
+
+	time.Sleep(5 * vaultLockRetry)
+	event("vault unlocked")
+	unlock()
+	wg.Wait()
+	assert.Equal(t, []string{"vault unlocked", "other locked"}, events)
+	assert.Equal(t, 0, v.lockDepth)
+	assert.Equal(t, 0, other.lockDepth)
+
+	stored, err := s.LoadVault("test", key)
+	require.NoError(t, err)
+	assert.Equal(t, 2, stored.Size())
+}
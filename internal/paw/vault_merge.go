@@ -0,0 +1,375 @@
+// SPDX-FileCopyrightText: 2025 Luca Corbo, Paw contributors
+// SPDX-License-Identifier: AGPL-3.0-or-later
+
+package paw
+
+import (
+	"fmt"
+	"sort"
+	"time"
+)
+
+// Conflict represents a change to an item discarded merging the concurrent
+// changes of two writers, e.g. the app and the CLI or two devices.
+// When both writers changed the same item the most recent change is kept, the
+// discarded one is recorded to let the user review it. When the discarded
+// change left the item into the vault, its item file is kept as conflict copy
+// to let the user restore it, see RestoreConflictCopy.
+type Conflict struct {
+	// Metadata is the item metadata as left by the discarded change
+	*Metadata `json:"metadata"`
+	// Deleted is the time the discarded change moved the item to the trash, if any
+	Deleted time.Time `json:"deleted"`
+	// Purged reports whether the discarded change deleted permanently the item
+	Purged bool `json:"purged"`
+	// Detected is the time the conflict has been detected
+	Detected time.Time `json:"detected"`
+	// Copy reports whether the item file of the discarded change has been kept
+	Copy bool `json:"copy,omitempty"`
+}
+
+// vaultBase represents the state of the vault index as last read or written,
+// it is the common ancestor used to merge the changes of the other writers
+type vaultBase struct {
+	entries   map[string]*syncEntry
+	conflicts map[string]bool
+}
+
+// newVaultBase returns a copy of the vault index state, the metadata are
+// copied since the items are modified in place
+func newVaultBase(v *Vault) *vaultBase {
+	base := &vaultBase{
+		entries:   map[string]*syncEntry{},
+		conflicts: map[string]bool{},
+	}
+	for id, e := range syncEntries(v) {
+		meta := *e.meta
+		base.entries[id] = &syncEntry{meta: &meta, deleted: e.deleted}
+	}
+	for id := range v.Conflicts {
+		base.conflicts[id] = true
+	}
+	return base
+}
+
+// MergeVault merges into the vault the changes stored by the other writers
+// since the vault has been loaded or stored, e.g. by the CLI while the app is
+// running. Items changed only by a writer are taken from it, items changed by
+// both are merged comparing the modification time, or the deletion time for
+// the items into the trash: the most recent change wins and the discarded one
+// is recorded as a conflict. It returns the conflicts detected.
+// The storages call it before storing the vault, so the changes of the other
+// writers are never overwritten.
+func MergeVault(s Storage, vault *Vault) ([]*Conflict, error) {
+	if vault.base == nil {
+		// vault never stored or not loaded from the storage, nothing to merge
+		return nil, nil
+	}
+	stored, err := s.LoadVault(vault.Name, vault.key)
+	if err != nil {
+		return nil, fmt.Errorf("could not load the stored vault to merge: %w", err)
+	}
+	merges, conflicts := vault.merge(vault.base, stored, time.Now().UTC())
+	err = keepPendingConflictCopies(s, vault, merges)
+	if err != nil {
+		return nil, err
+	}
+	// the stored vault is the common ancestor of the next merge
+	vault.base = newVaultBase(stored)
+	return conflicts, nil
+}
+
+// keepConflictCopy keeps in memory the stored item before it is
+// overwritten, if another writer changed the item since the vault has been
+// loaded or stored. The copy is pending until MergeVault detects whether the
+// change is a conflict, see keepPendingConflictCopies.
+// The storages call it before storing an item.
+func keepConflictCopy(s Storage, vault *Vault, id string) error {
+	if vault.base == nil {
+		return nil
+	}
+	if _, ok := vault.pendingCopies[id]; ok {
+		// the first copy holds the change of the other writer
+		return nil
+	}
+	e, ok := vault.base.entries[id]
+	if !ok || e.trashed() {
+		return nil
+	}
+	stored, err := s.LoadItem(vault, e.meta)
+	if err != nil {
+		// nothing to keep, the file could be missing or half-written
+		return nil
+	}
+	if stored.GetMetadata().Modified.Equal(e.meta.Modified) {
+		return nil
+	}
+	if vault.pendingCopies == nil {
+		vault.pendingCopies = make(map[string]Item)
+	}
+	vault.pendingCopies[id] = stored
+	return nil
+}
+
+// keepPendingConflictCopies stores as conflict copy the pending copies of the
+// items the merge detected as conflict and drops the others. When the stored
+// change wins the item file holds the discarded one: the discarded item is
+// kept as conflict copy and the stored one is written back.
+func keepPendingConflictCopies(s Storage, vault *Vault, merges []*syncMerge) error {
+	if len(vault.pendingCopies) == 0 {
+		return nil
+	}
+	conflicted := map[string]*syncMerge{}
+	for _, m := range merges {
+		if m.conflict && m.win != nil && m.lose != nil {
+			conflicted[m.id] = m
+		}
+	}
+	for id, pending := range vault.pendingCopies {
+		m, ok := conflicted[id]
+		c, found := vault.Conflicts[id]
+		if !ok || !found {
+			continue
+		}
+		switch {
+		case m.pull:
+			// the item file holds the discarded change
+			discarded, err := s.LoadItem(vault, pending.GetMetadata())
+			if err != nil {
+				return fmt.Errorf("could not load the item to keep as conflict copy: %w", err)
+			}
+			err = s.StoreConflictCopy(vault, discarded)
+			if err != nil {
+				return fmt.Errorf("could not keep the conflict copy: %w", err)
+			}
+			if m.win.trashed() {
+				err = s.DeleteItem(vault, discarded)
+			} else {
+				err = s.StoreItem(vault, pending)
+			}
+			if err != nil {
+				return fmt.Errorf("could not restore the merged item: %w", err)
+			}
+		case m.lose.trashed():
+			continue
+		default:
+			err := s.StoreConflictCopy(vault, pending)
+			if err != nil {
+				return fmt.Errorf("could not keep the conflict copy: %w", err)
+			}
+		}
+		c.Copy = true
+	}
+	vault.pendingCopies = nil
+	return nil
+}
+
+// merge merges the other vault into v using base as common ancestor returning
+// the merges by item and the conflicts detected.
+// A nil base merges comparing only the modification times and reports no conflicts.
+func (v *Vault) merge(base *vaultBase, other *Vault, now time.Time) ([]*syncMerge, []*Conflict) {
+	var baseEntries map[string]*syncEntry
+	var baseConflicts map[string]bool
+	if base != nil {
+		baseEntries = base.entries
+		baseConflicts = base.conflicts
+	}
+
+	merges := mergeSyncEntries(baseEntries, syncEntries(v), syncEntries(other))
+	setSyncEntries(v, merges)
+	v.mergeConflicts(baseConflicts, other)
+	if other.Created.Before(v.Created) {
+		v.Created = other.Created
+	}
+
+	conflicts := []*Conflict{}
+	for _, m := range merges {
+		if !m.conflict {
+			continue
+		}
+		c := &Conflict{Detected: now}
+		switch {
+		case m.lose == nil:
+			// both changed, the item is present on a side only: it has been purged on the other
+			meta := *baseEntries[m.id].meta
+			c.Metadata = &meta
+			c.Purged = true
+		default:
+			meta := *m.lose.meta
+			c.Metadata = &meta
+			c.Deleted = m.lose.deleted
+		}
+		if v.Conflicts == nil {
+			v.Conflicts = make(map[string]*Conflict)
+		}
+		v.Conflicts[m.id] = c
+		conflicts = append(conflicts, c)
+	}
+	return merges, conflicts
+}
+
+// mergeConflicts merges the conflicts recorded by the other vault: a conflict
+// is kept unless dismissed by a writer, i.e. present into the base but not
+// into one of the vaults
+func (v *Vault) mergeConflicts(base map[string]bool, other *Vault) {
+	merged := map[string]*Conflict{}
+	for id, c := range v.Conflicts {
+		if _, ok := other.Conflicts[id]; ok || !base[id] {
+			merged[id] = c
+		}
+	}
+	for id, c := range other.Conflicts {
+		if prev, ok := merged[id]; ok {
+			if c.Detected.After(prev.Detected) {
+				merged[id] = c
+			}
+			continue
+		}
+		if _, ok := v.Conflicts[id]; !ok && !base[id] {
+			merged[id] = c
+		}
+	}
+	v.Conflicts = nil
+	if len(merged) > 0 {
+		v.Conflicts = merged
+	}
+}
+
+// sameConflicts reports whether the vaults record the same conflicts
+func sameConflicts(a *Vault, b *Vault) bool {
+	if len(a.Conflicts) != len(b.Conflicts) {
+		return false
+	}
+	for id := range a.Conflicts {
+		if _, ok := b.Conflicts[id]; !ok {
+			return false
+		}
+	}
+	return true
+}
+
+// ConflictedItems returns the conflicts to review, the most recently detected first
+func (v *Vault) ConflictedItems() []*Conflict {
+	conflicts := make([]*Conflict, 0, len(v.Conflicts))
+	for _, c := range v.Conflicts {
+		conflicts = append(conflicts, c)
+	}
+	sort.Slice(conflicts, func(i, j int) bool {
+		if conflicts[i].Detected.Equal(conflicts[j].Detected) {
+			return conflicts[i].Name < conflicts[j].Name
+		}
+		return conflicts[i].Detected.After(conflicts[j].Detected)
+	})
+	return conflicts
+}
+
+// DismissConflicts removes the conflicts of the items with the specified IDs
+// once reviewed and stores the vault. All the conflicts are removed if no ID
+// is specified.
+func DismissConflicts(s Storage, vault *Vault, ids ...string) error {
+	if len(ids) == 0 {
+		for id := range vault.Conflicts {
+			ids = append(ids, id)
+		}
+	}
+	for _, id := range ids {
+		if _, ok := vault.Conflicts[id]; !ok {
+			return fmt.Errorf("%w: %q has no conflict", ErrItemNotFound, id)
+		}
+	}
+	unlock, err := LockVault(s, vault)
+	if err != nil {
+		return err
+	}
+	defer unlock()
+
+	for _, id := range ids {
+		if c := vault.Conflicts[id]; c.Copy {
+			err = s.DeleteConflictCopy(vault, c.Metadata)
+			if err != nil {
+				return err
+			}
+		}
+		delete(vault.Conflicts, id)
+	}
+	if len(vault.Conflicts) == 0 {
+		vault.Conflicts = nil
+	}
+	vault.Modified = time.Now().UTC()
+	return s.StoreVault(vault)
+}
+
+// LoadConflictCopy loads the item as left by the change discarded by the conflict
+func LoadConflictCopy(s Storage, vault *Vault, id string) (Item, error) {
+	c, ok := vault.Conflicts[id]
+	if !ok || !c.Copy {
+		return nil, fmt.Errorf("%w: %q has no conflict copy", ErrItemNotFound, id)
+	}
+	item, err := s.LoadConflictCopy(vault, c.Metadata)
+	if err != nil {
+		return nil, fmt.Errorf("could not load the conflict copy: %w", err)
+	}
+	return item, nil
+}
+
+// RestoreConflictCopy restores the change discarded by the conflict as a new
+// item, renamed if the name is already in use, then dismisses the conflict.
+// The attachments no longer stored are dropped from the restored item.
+// It returns the restored item.
+func RestoreConflictCopy(s Storage, vault *Vault, id string) (Item, error) {
+	item, err := LoadConflictCopy(s, vault, id)
+	if err != nil {
+		return nil, err
+	}
+
+	unlock, err := LockVault(s, vault)
+	if err != nil {
+		return nil, err
+	}
+	defer unlock()
+
+	now := time.Now().UTC()
+	meta := item.GetMetadata()
+	meta.UUID = newUUID()
+	if _, ok := vault.ItemMetadataByName(meta.Type, meta.Name); ok {
+		names := map[string]*Metadata{}
+		for _, m := range vault.ItemMetadata[meta.Type] {
+			names[m.Name] = m
+		}
+		meta.Name = uniqueName(names, meta.Name)
+	}
+	meta.Modified = now
+	attachments := meta.Attachments[:0]
+	for _, a := range meta.Attachments {
+		r, err := s.LoadAttachment(vault, a)
+		if err != nil {
+			continue
+		}
+		r.Close()
+		attachments = append(attachments, a)
+	}
+	meta.Attachments = attachments
+
+	err = s.StoreItem(vault, item)
+	if err != nil {
+		return nil, err
+	}
+	err = vault.AddItem(item)
+	if err != nil {
+		return nil, err
+	}
+	err = s.DeleteConflictCopy(vault, vault.Conflicts[id].Metadata)
+	if err != nil {
+		return nil, err
+	}
+	delete(vault.Conflicts, id)
+	if len(vault.Conflicts) == 0 {
+		vault.Conflicts = nil
+	}
+	vault.Modified = now
+	err = s.StoreVault(vault)
+	if err != nil {
+		return nil, err
+	}
+	return item, nil
+}
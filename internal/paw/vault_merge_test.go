@@ -0,0 +1,417 @@
+// SPDX-FileCopyrightText: 2025 Luca Corbo, Paw contributors
+// SPDX-License-Identifier: AGPL-3.0-or-later
+
+package paw
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestMergeVault(t *testing.T) {
+	t0 := time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC)
+
+	// the base vault has the "a" and "b" notes and "t" into the trash
+	newBase := func() *Vault {
+		v := NewVault(nil, "test")
+		v.Created = t0
+		v.Modified = t0
+		require.NoError(t, v.AddItem(newMergeTestMeta("a", t0)))
+		require.NoError(t, v.AddItem(newMergeTestMeta("b", t0)))
+		v.TrashItem(newMergeTestMeta("t", t0), t0)
+		return v
+	}
+	edit := func(id string, d time.Duration) func(v *Vault) {
+		return func(v *Vault) {
+			require.NoError(t, v.AddItem(newMergeTestMeta(id, t0.Add(d))))
+		}
+	}
+	trash := func(id string, d time.Duration) func(v *Vault) {
+		return func(v *Vault) {
+			v.TrashItem(newMergeTestMeta(id, t0), t0.Add(d))
+		}
+	}
+	restore := func(id string) func(v *Vault) {
+		return func(v *Vault) {
+			_, err := v.RestoreItem(id)
+			require.NoError(t, err)
+		}
+	}
+	purge := func(id string) func(v *Vault) {
+		return func(v *Vault) {
+			v.PurgeItem(id)
+		}
+	}
+	conflict := func(id string) func(v *Vault) {
+		return func(v *Vault) {
+			if v.Conflicts == nil {
+				v.Conflicts = make(map[string]*Conflict)
+			}
+			v.Conflicts[id] = &Conflict{Metadata: newMergeTestMeta(id, t0), Detected: t0}
+		}
+	}
+	dismiss := func(id string) func(v *Vault) {
+		return func(v *Vault) {
+			delete(v.Conflicts, id)
+		}
+	}
+
+	tests := []struct {
+		name   string
+		base   []func(v *Vault)
+		ours   []func(v *Vault)
+		theirs []func(v *Vault)
+		// want is the expected state by item ID, see mergeTestState
+		want map[string]string
+		// wantConflicts are the expected conflicts by item ID, see mergeTestConflict
+		wantConflicts map[string]string
+	}{
+		{
+			name: "unchanged",
+			want: map[string]string{"a": "active 0s", "b": "active 0s", "t": "trashed 0s"},
+		},
+		{
+			name:   "concurrent additions",
+			ours:   []func(v *Vault){edit("c", time.Hour)},
+			theirs: []func(v *Vault){edit("d", time.Minute)},
+			want:   map[string]string{"a": "active 0s", "b": "active 0s", "c": "active 1h0m0s", "d": "active 1m0s", "t": "trashed 0s"},
+		},
+		{
+			name:   "changes on different items",
+			ours:   []func(v *Vault){edit("a", time.Minute)},
+			theirs: []func(v *Vault){edit("b", time.Hour), purge("t")},
+			want:   map[string]string{"a": "active 1m0s", "b": "active 1h0m0s"},
+		},
+		{
+			name:          "edited on both sides, theirs is more recent",
+			ours:          []func(v *Vault){edit("a", time.Minute)},
+			theirs:        []func(v *Vault){edit("a", time.Hour)},
+			want:          map[string]string{"a": "active 1h0m0s", "b": "active 0s", "t": "trashed 0s"},
+			wantConflicts: map[string]string{"a": "active 1m0s"},
+		},
+		{
+			name:          "edited on both sides, ours is more recent",
+			ours:          []func(v *Vault){edit("a", time.Hour)},
+			theirs:        []func(v *Vault){edit("a", time.Minute)},
+			want:          map[string]string{"a": "active 1h0m0s", "b": "active 0s", "t": "trashed 0s"},
+			wantConflicts: map[string]string{"a": "active 1m0s"},
+		},
+		{
+			name:   "edited on both sides the same way",
+			ours:   []func(v *Vault){edit("a", time.Hour)},
+			theirs: []func(v *Vault){edit("a", time.Hour)},
+			want:   map[string]string{"a": "active 1h0m0s", "b": "active 0s", "t": "trashed 0s"},
+		},
+		{
+			name:   "trashed by ours",
+			ours:   []func(v *Vault){trash("a", time.Minute)},
+			theirs: []func(v *Vault){edit("b", time.Hour)},
+			want:   map[string]string{"a": "trashed 1m0s", "b": "active 1h0m0s", "t": "trashed 0s"},
+		},
+		{
+			name:          "trashed by ours after an edit of theirs",
+			ours:          []func(v *Vault){trash("a", time.Hour)},
+			theirs:        []func(v *Vault){edit("a", time.Minute)},
+			want:          map[string]string{"a": "trashed 1h0m0s", "b": "active 0s", "t": "trashed 0s"},
+			wantConflicts: map[string]string{"a": "active 1m0s"},
+		},
+		{
+			name:          "edited by ours after a deletion of theirs",
+			ours:          []func(v *Vault){edit("a", time.Hour)},
+			theirs:        []func(v *Vault){trash("a", time.Minute)},
+			want:          map[string]string{"a": "active 1h0m0s", "b": "active 0s", "t": "trashed 0s"},
+			wantConflicts: map[string]string{"a": "trashed 1m0s"},
+		},
+		{
+			// the restored item keeps its modification time, older than the deletion
+			name:   "restored by ours",
+			ours:   []func(v *Vault){restore("t")},
+			theirs: []func(v *Vault){edit("b", time.Hour)},
+			want:   map[string]string{"a": "active 0s", "b": "active 1h0m0s", "t": "active 0s"},
+		},
+		{
+			name:   "purged by ours",
+			ours:   []func(v *Vault){purge("t")},
+			theirs: []func(v *Vault){edit("a", time.Hour)},
+			want:   map[string]string{"a": "active 1h0m0s", "b": "active 0s"},
+		},
+		{
+			name:          "purged by theirs, restored by ours",
+			ours:          []func(v *Vault){restore("t")},
+			theirs:        []func(v *Vault){purge("t")},
+			want:          map[string]string{"a": "active 0s", "b": "active 0s", "t": "active 0s"},
+			wantConflicts: map[string]string{"t": "purged"},
+		},
+		{
+			name:          "conflict recorded by theirs",
+			theirs:        []func(v *Vault){conflict("a")},
+			want:          map[string]string{"a": "active 0s", "b": "active 0s", "t": "trashed 0s"},
+			wantConflicts: map[string]string{"a": "active 0s"},
+		},
+		{
+			name:   "conflict dismissed by theirs",
+			base:   []func(v *Vault){conflict("a")},
+			theirs: []func(v *Vault){dismiss("a")},
+			want:   map[string]string{"a": "active 0s", "b": "active 0s", "t": "trashed 0s"},
+		},
+		{
+			name:          "conflict dismissed by ours, recorded again by theirs",
+			base:          []func(v *Vault){conflict("a")},
+			ours:          []func(v *Vault){dismiss("a")},
+			theirs:        []func(v *Vault){edit("b", time.Hour), conflict("b")},
+			want:          map[string]string{"a": "active 0s", "b": "active 1h0m0s", "t": "trashed 0s"},
+			wantConflicts: map[string]string{"b": "active 0s"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			base := newBase()
+			for _, f := range tt.base {
+				f(base)
+			}
+			ours := cloneMergeTestVault(t, base)
+			ours.base = newVaultBase(base)
+			for _, f := range tt.ours {
+				f(ours)
+			}
+			theirs := cloneMergeTestVault(t, base)
+			for _, f := range tt.theirs {
+				f(theirs)
+			}
+
+			s := &StorageMock{}
+			s.OnLoadVault = func(name string, key *Key) (*Vault, error) {
+				assert.Equal(t, "test", name)
+				return cloneMergeTestVault(t, theirs), nil
+			}
+
+			_, err := MergeVault(s, ours)
+			require.NoError(t, err)
+
+			got := map[string]string{}
+			for id := range syncEntries(ours) {
+				got[id] = mergeTestState(ours, id, t0)
+			}
+			assert.Equal(t, tt.want, got)
+
+			gotConflicts := map[string]string{}
+			for id, c := range ours.Conflicts {
+				gotConflicts[id] = mergeTestConflict(c, t0)
+			}
+			if tt.wantConflicts == nil {
+				tt.wantConflicts = map[string]string{}
+			}
+			assert.Equal(t, tt.wantConflicts, gotConflicts)
+
+			// the stored vault is the base of the next merge
+			assert.Equal(t, newVaultBase(theirs), ours.base)
+		})
+	}
+}
+
+func TestMergeVault_NotLoaded(t *testing.T) {
+	// no callback: the stored vault must not be loaded
+	s := &StorageMock{}
+	v := NewVault(nil, "test")
+	conflicts, err := MergeVault(s, v)
+	require.NoError(t, err)
+	assert.Empty(t, conflicts)
+
+	v.base = newVaultBase(v)
+	_, err = MergeVault(s, v)
+	assert.ErrorIs(t, err, ErrCallbackRequired)
+}
+
+func TestDismissConflicts(t *testing.T) {
+	t0 := time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC)
+	v := NewVault(nil, "test")
+	v.Conflicts = map[string]*Conflict{
+		"a": {Metadata: newMergeTestMeta("a", t0), Detected: t0, Copy: true},
+		"b": {Metadata: newMergeTestMeta("b", t0), Detected: t0.Add(time.Hour)},
+	}
+	stored := 0
+	deleted := []string{}
+	s := &StorageMock{}
+	s.OnStoreVault = func(vault *Vault) error {
+		stored++
+		return nil
+	}
+	s.OnDeleteConflictCopy = func(vault *Vault, itemMetadata *Metadata) error {
+		deleted = append(deleted, itemMetadata.ID())
+		return nil
+	}
+
+	conflicts := v.ConflictedItems()
+	require.Len(t, conflicts, 2)
+	assert.Equal(t, "b", conflicts[0].ID(), "most recently detected first")
+
+	assert.ErrorIs(t, DismissConflicts(s, v, "c"), ErrItemNotFound)
+	assert.Equal(t, 0, stored)
+	require.NoError(t, DismissConflicts(s, v, "a"))
+	assert.Len(t, v.Conflicts, 1)
+	require.NoError(t, DismissConflicts(s, v))
+	assert.Nil(t, v.Conflicts)
+	assert.Equal(t, 2, stored)
+	assert.Equal(t, []string{"a"}, deleted, "only the kept copies are deleted")
+}
+
+func TestStoreVault_ConcurrentWriters(t *testing.T) {
+	root, err := os.MkdirTemp(os.TempDir(), "paw")
+	require.NoError(t, err)
+	defer os.RemoveAll(root)
+
+	s, err := NewOSStorageRooted(root)
+	require.NoError(t, err)
+	key, err := s.CreateVaultKey("test", "secret")
+	require.NoError(t, err)
+	_, err = s.CreateVault("test", key)
+	require.NoError(t, err)
+
+	// e.g. the app and the CLI load the vault at the same time
+	app, err := s.LoadVault("test", key)
+	require.NoError(t, err)
+	cli, err := s.LoadVault("test", key)
+	require.NoError(t, err)
+
+	storeNote := func(v *Vault, name string) *Note {
+		note := NewNote()
+		note.Name = name
+		require.NoError(t, s.StoreItem(v, note))
+		require.NoError(t, v.AddItem(note))
+		require.NoError(t, s.StoreVault(v))
+		return note
+	}
+	trashed := storeNote(cli, "added by the CLI")
+	storeNote(app, "added by the app")
+	assert.Equal(t, 2, app.Size())
+
+	require.NoError(t, MoveItemToTrash(s, cli, trashed))
+	assert.Equal(t, 1, cli.Size(), "the item added by the app is merged")
+	assert.Contains(t, cli.Trash, trashed.ID())
+
+	storeNote(app, "added again by the app")
+	assert.Equal(t, 2, app.Size())
+	assert.Contains(t, app.Trash, trashed.ID(), "trashed by the CLI")
+
+	v, err := s.LoadVault("test", key)
+	require.NoError(t, err)
+	assert.Equal(t, 2, v.Size())
+	assert.Len(t, v.Trash, 1)
+	assert.Empty(t, v.Conflicts)
+}
+
+func TestStoreVault_ConflictCopy(t *testing.T) {
+	s := newSyncTestStorage(t)
+	key, err := s.CreateVaultKey("test", "secret")
+	require.NoError(t, err)
+	v, err := s.CreateVault("test", key)
+	require.NoError(t, err)
+	t0 := time.Now().UTC()
+	note := NewNote()
+	note.Name = "note"
+	note.Modified = t0
+	require.NoError(t, s.StoreItem(v, note))
+	require.NoError(t, v.AddItem(note))
+	require.NoError(t, s.StoreVault(v))
+
+	// storeNote changes the note as loaded by a writer
+	storeNote := func(v *Vault, value string, modified time.Time) {
+		note := loadSyncTestNote(t, s, v, "note")
+		note.Value = value
+		note.Modified = modified
+		require.NoError(t, s.StoreItem(v, note))
+		require.NoError(t, v.AddItem(note))
+		require.NoError(t, s.StoreVault(v))
+	}
+
+	// the change stored last wins
+	app, err := s.LoadVault("test", key)
+	require.NoError(t, err)
+	cli, err := s.LoadVault("test", key)
+	require.NoError(t, err)
+	storeNote(cli, "cli", t0.Add(time.Minute))
+	storeNote(app, "app", t0.Add(2*time.Minute))
+	require.Contains(t, app.Conflicts, note.ID())
+	assert.True(t, app.Conflicts[note.ID()].Copy)
+	assert.Equal(t, "app", loadSyncTestNote(t, s, app, "note").Value)
+	item, err := LoadConflictCopy(s, app, note.ID())
+	require.NoError(t, err)
+	assert.Equal(t, "cli", item.(*Note).Value)
+	assert.Empty(t, app.pendingCopies)
+	require.NoError(t, DismissConflicts(s, app))
+	assert.NoFileExists(t, conflictCopyPath(s, "test", note.ID()))
+
+	// the stored change wins
+	app, err = s.LoadVault("test", key)
+	require.NoError(t, err)
+	cli, err = s.LoadVault("test", key)
+	require.NoError(t, err)
+	storeNote(cli, "cli", t0.Add(4*time.Minute))
+	storeNote(app, "app", t0.Add(3*time.Minute))
+	require.Contains(t, app.Conflicts, note.ID())
+	assert.Equal(t, "cli", loadSyncTestNote(t, s, app, "note").Value)
+	item, err = LoadConflictCopy(s, app, note.ID())
+	require.NoError(t, err)
+	assert.Equal(t, "app", item.(*Note).Value)
+
+	// the discarded change is restored as a new item
+	restored, err := RestoreConflictCopy(s, app, note.ID())
+	require.NoError(t, err)
+	assert.Equal(t, "note (1)", restored.GetMetadata().Name)
+	assert.NotEqual(t, note.ID(), restored.ID())
+	assert.Empty(t, app.Conflicts)
+	assert.NoFileExists(t, conflictCopyPath(s, "test", note.ID()))
+	stored, err := s.LoadVault("test", key)
+	require.NoError(t, err)
+	assert.Equal(t, 2, stored.Size())
+	assert.Equal(t, "app", loadSyncTestNote(t, s, stored, "note (1)").Value)
+	assert.Equal(t, "cli", loadSyncTestNote(t, s, stored, "note").Value)
+
+	_, err = LoadConflictCopy(s, app, note.ID())
+	assert.ErrorIs(t, err, ErrItemNotFound)
+}
+
+func newMergeTestMeta(id string, modified time.Time) *Metadata {
+	return &Metadata{UUID: id, Name: id, Type: NoteItemType, Modified: modified}
+}
+
+// cloneMergeTestVault returns a deep copy of the vault as stored
+func cloneMergeTestVault(t *testing.T, v *Vault) *Vault {
+	b, err := json.Marshal(v)
+	require.NoError(t, err)
+	clone := NewVault(v.key, v.Name)
+	require.NoError(t, json.Unmarshal(b, clone))
+	return clone
+}
+
+// mergeTestState describes the item state as "active" or "trashed" followed
+// by the time of the last change since t0
+func mergeTestState(v *Vault, id string, t0 time.Time) string {
+	if tombstone, ok := v.Trash[id]; ok {
+		return fmt.Sprintf("trashed %s", tombstone.Deleted.Sub(t0))
+	}
+	for _, metadataByType := range v.ItemMetadata {
+		if meta, ok := metadataByType[id]; ok {
+			return fmt.Sprintf("active %s", meta.Modified.Sub(t0))
+		}
+	}
+	return "absent"
+}
+
+// mergeTestConflict describes the discarded change as mergeTestState does
+func mergeTestConflict(c *Conflict, t0 time.Time) string {
+	switch {
+	case c.Purged:
+		return "purged"
+	case !c.Deleted.IsZero():
+		return fmt.Sprintf("trashed %s", c.Deleted.Sub(t0))
+	}
+	return fmt.Sprintf("active %s", c.Modified.Sub(t0))
+}
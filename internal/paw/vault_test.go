@@ -4,6 +4,7 @@
 package paw
 
 import (
+	"errors"
 	"reflect"
 	"testing"
 )
@@ -15,10 +16,13 @@ func TestVault_FilterItemMetadata(t *testing.T) {
 	}
 	note := NewNote()
 	note.Name = "test name"
+	note.Tags = []string{"Personal", "work"}
+	note.Folder = "home/notes"
 	v.AddItem(note)
 
 	password := NewPassword()
 	password.Name = "test password"
+	password.Tags = []string{"work"}
 	v.AddItem(password)
 
 	tests := []struct {
@@ -43,6 +47,40 @@ func TestVault_FilterItemMetadata(t *testing.T) {
 				note.GetMetadata(),
 			},
 		},
+		{
+			name: "filter by tag",
+			opts: &VaultFilterOptions{
+				Tags: []string{"personal"},
+			},
+			want: []*Metadata{
+				note.GetMetadata(),
+			},
+		},
+		{
+			name: "filter by all the tags",
+			opts: &VaultFilterOptions{
+				Tags: []string{"work", "personal"},
+			},
+			want: []*Metadata{
+				note.GetMetadata(),
+			},
+		},
+		{
+			name: "filter by folder",
+			opts: &VaultFilterOptions{
+				Folder: "home",
+			},
+			want: []*Metadata{
+				note.GetMetadata(),
+			},
+		},
+		{
+			name: "filter by folder prefix",
+			opts: &VaultFilterOptions{
+				Folder: "ho",
+			},
+			want: []*Metadata{},
+		},
 		{
 			name: "filter by type",
 			opts: &VaultFilterOptions{
@@ -61,3 +99,40 @@ func TestVault_FilterItemMetadata(t *testing.T) {
 		})
 	}
 }
+
+func TestVault_RenameItem(t *testing.T) {
+	v := NewVault(nil, "test vault")
+
+	note := NewNote()
+	note.Name = "note"
+	v.AddItem(note)
+
+	other := NewNote()
+	other.Name = "other note"
+	v.AddItem(other)
+
+	id := note.ID()
+
+	err := v.RenameItem(note, "renamed note")
+	if err != nil {
+		t.Fatalf("Vault.RenameItem() unexpected error: %v", err)
+	}
+	if note.ID() != id {
+		t.Errorf("Vault.RenameItem() changed the item ID: got %s, want %s", note.ID(), id)
+	}
+	if _, ok := v.ItemMetadataByName(NoteItemType, "note"); ok {
+		t.Errorf("Vault.RenameItem() old name still present into the vault")
+	}
+	meta, ok := v.ItemMetadataByName(NoteItemType, "renamed note")
+	if !ok || meta.ID() != id {
+		t.Errorf("Vault.RenameItem() new name not found into the vault")
+	}
+	if v.Size() != 2 {
+		t.Errorf("Vault.Size() = %d, want 2", v.Size())
+	}
+
+	err = v.RenameItem(note, other.Name)
+	if !errors.Is(err, ErrItemAlreadyExists) {
+		t.Errorf("Vault.RenameItem() error = %v, want %v", err, ErrItemAlreadyExists)
+	}
+}
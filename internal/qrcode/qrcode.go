@@ -0,0 +1,65 @@
+// SPDX-FileCopyrightText: 2025 Luca Corbo, Paw contributors
+// SPDX-License-Identifier: AGPL-3.0-or-later
+
+// Package qrcode encodes and decodes QR codes
+package qrcode
+
+import (
+	"errors"
+	"fmt"
+	"image"
+	"io"
+
+	// register the supported image formats
+	_ "image/jpeg"
+	_ "image/png"
+
+	"github.com/liyue201/goqr"
+	qrcode "github.com/skip2/go-qrcode"
+)
+
+// ErrNotFound is returned when a QR code is not found into the image
+var ErrNotFound = errors.New("QR code not found")
+
+// Decode decodes the content of the first QR code found into a PNG or JPEG image
+func Decode(r io.Reader) (string, error) {
+	img, _, err := image.Decode(r)
+	if err != nil {
+		return "", fmt.Errorf("could not decode the image: %w", err)
+	}
+	return DecodeImage(img)
+}
+
+// DecodeImage decodes the content of the first QR code found into the image
+func DecodeImage(img image.Image) (string, error) {
+	codes, err := goqr.Recognize(img)
+	if err != nil || len(codes) == 0 {
+		return "", ErrNotFound
+	}
+	return string(codes[0].Payload), nil
+}
+
+// Image returns the QR code image for the content. Size is the width and
+// height in pixels.
+func Image(content string, size int) (image.Image, error) {
+	q, err := qrcode.New(content, qrcode.Medium)
+	if err != nil {
+		return nil, err
+	}
+	return q.Image(size), nil
+}
+
+// PNG returns the PNG encoded QR code image for the content. Size is the width
+// and height in pixels.
+func PNG(content string, size int) ([]byte, error) {
+	return qrcode.Encode(content, qrcode.Medium, size)
+}
+
+// String returns the QR code for the content as text to print in a terminal
+func String(content string) (string, error) {
+	q, err := qrcode.New(content, qrcode.Medium)
+	if err != nil {
+		return "", err
+	}
+	return q.ToSmallString(false), nil
+}
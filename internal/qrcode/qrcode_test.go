@@ -0,0 +1,48 @@
+// SPDX-FileCopyrightText: 2025 Luca Corbo, Paw contributors
+// SPDX-License-Identifier: AGPL-3.0-or-later
+
+package qrcode
+
+import (
+	"bytes"
+	"image"
+	"image/jpeg"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestEncodeDecode(t *testing.T) {
+	content := "otpauth://totp/Example:alice@example.com?secret=JBSWY3DPEHPK3PXP&issuer=Example"
+
+	t.Run("png", func(t *testing.T) {
+		b, err := PNG(content, 256)
+		require.NoError(t, err)
+
+		got, err := Decode(bytes.NewReader(b))
+		require.NoError(t, err)
+		assert.Equal(t, content, got)
+	})
+
+	t.Run("jpeg", func(t *testing.T) {
+		img, err := Image(content, 256)
+		require.NoError(t, err)
+
+		buf := &bytes.Buffer{}
+		require.NoError(t, jpeg.Encode(buf, img, nil))
+
+		got, err := Decode(buf)
+		require.NoError(t, err)
+		assert.Equal(t, content, got)
+	})
+}
+
+func TestDecodeImageNotFound(t *testing.T) {
+	img := image.NewGray(image.Rect(0, 0, 64, 64))
+	for i := range img.Pix {
+		img.Pix[i] = 0xff
+	}
+	_, err := DecodeImage(img)
+	assert.ErrorIs(t, err, ErrNotFound)
+}
@@ -0,0 +1,452 @@
+// SPDX-FileCopyrightText: 2025 Luca Corbo, Paw contributors
+// SPDX-License-Identifier: AGPL-3.0-or-later
+
+package secretservice
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/godbus/dbus/v5"
+
+	"lucor.dev/paw/internal/paw"
+)
+
+// serviceObject implements the org.freedesktop.Secret.Service interface
+type serviceObject struct {
+	svc *Service
+}
+
+// OpenSession opens a session to transfer the secrets
+func (o *serviceObject) OpenSession(msg dbus.Message, algorithm string, input dbus.Variant) (dbus.Variant, dbus.ObjectPath, *dbus.Error) {
+	if messagePath(msg) != servicePath {
+		return dbus.MakeVariant(""), noPrompt, errNoSuchObject(messagePath(msg))
+	}
+
+	o.svc.mu.Lock()
+	defer o.svc.mu.Unlock()
+
+	o.svc.sessionSeed++
+	s := &session{
+		path:   dbus.ObjectPath(fmt.Sprintf("%s%d", sessionPrefix, o.svc.sessionSeed)),
+		sender: messageSender(msg),
+	}
+
+	output := dbus.MakeVariant("")
+	switch algorithm {
+	case plainAlgorithm:
+	case dhAlgorithm:
+		peerPublic, ok := input.Value().([]byte)
+		if !ok {
+			return output, noPrompt, errInvalidArgs("the input must be the client public key")
+		}
+		private, public, err := dhKeyPair()
+		if err != nil {
+			return output, noPrompt, errFailed(err)
+		}
+		s.key, err = dhSharedKey(private, peerPublic)
+		if err != nil {
+			return output, noPrompt, errInvalidArgs(err.Error())
+		}
+		output = dbus.MakeVariant(public)
+	default:
+		return output, noPrompt, errNotSupported(fmt.Sprintf("algorithm %q is not supported", algorithm))
+	}
+
+	o.svc.sessions[s.path] = s
+	return output, s.path, nil
+}
+
+// CreateCollection is not supported, vaults can be created only using Paw
+func (o *serviceObject) CreateCollection(msg dbus.Message, properties map[string]dbus.Variant, alias string) (dbus.ObjectPath, dbus.ObjectPath, *dbus.Error) {
+	return noPrompt, noPrompt, errNotSupported("collections can be created only using Paw")
+}
+
+// SearchItems returns the items matching the attributes. Locked items are not exposed.
+func (o *serviceObject) SearchItems(msg dbus.Message, attributes map[string]string) ([]dbus.ObjectPath, []dbus.ObjectPath, *dbus.Error) {
+	o.svc.lock()
+	defer o.svc.mu.Unlock()
+
+	unlocked := []dbus.ObjectPath{}
+	for _, vault := range o.svc.vaults {
+		unlocked = append(unlocked, o.svc.searchItems(vault, attributes)...)
+	}
+	return unlocked, []dbus.ObjectPath{}, nil
+}
+
+// Unlock returns the objects already unlocked. Vaults can be unlocked only using Paw.
+func (o *serviceObject) Unlock(msg dbus.Message, objects []dbus.ObjectPath) ([]dbus.ObjectPath, dbus.ObjectPath, *dbus.Error) {
+	o.svc.lock()
+	defer o.svc.mu.Unlock()
+
+	unlocked := []dbus.ObjectPath{}
+	for _, path := range objects {
+		if _, ok := o.svc.lookupVault(path); ok {
+			unlocked = append(unlocked, path)
+		}
+	}
+	return unlocked, noPrompt, nil
+}
+
+// Lock does not lock any object. Vaults can be locked only using Paw.
+func (o *serviceObject) Lock(msg dbus.Message, objects []dbus.ObjectPath) ([]dbus.ObjectPath, dbus.ObjectPath, *dbus.Error) {
+	return []dbus.ObjectPath{}, noPrompt, nil
+}
+
+// GetSecrets returns the secrets of the items
+func (o *serviceObject) GetSecrets(msg dbus.Message, items []dbus.ObjectPath, sessionPath dbus.ObjectPath) (map[dbus.ObjectPath]secret, *dbus.Error) {
+	o.svc.lock()
+	defer o.svc.mu.Unlock()
+
+	s, dbusErr := o.svc.session(sessionPath, messageSender(msg))
+	if dbusErr != nil {
+		return nil, dbusErr
+	}
+
+	secrets := map[dbus.ObjectPath]secret{}
+	for _, path := range items {
+		vault, meta, ok := o.svc.lookupItem(path)
+		if !ok {
+			continue
+		}
+		password, dbusErr := o.svc.loadPassword(vault, meta)
+		if dbusErr != nil {
+			return nil, dbusErr
+		}
+		sec, err := s.encrypt([]byte(password.Value), defaultContentType)
+		if err != nil {
+			return nil, errFailed(err)
+		}
+		secrets[path] = sec
+	}
+	return secrets, nil
+}
+
+// ReadAlias returns the collection for the alias or "/" if not found
+func (o *serviceObject) ReadAlias(msg dbus.Message, name string) (dbus.ObjectPath, *dbus.Error) {
+	o.svc.lock()
+	defer o.svc.mu.Unlock()
+
+	if name != defaultAlias || len(o.svc.vaults) == 0 {
+		return noPrompt, nil
+	}
+	return collectionPath(o.svc.vaults[0]), nil
+}
+
+// SetAlias is not supported, the default collection is the first unlocked vault
+func (o *serviceObject) SetAlias(msg dbus.Message, name string, collection dbus.ObjectPath) *dbus.Error {
+	return errNotSupported("aliases cannot be changed")
+}
+
+// collectionObject implements the org.freedesktop.Secret.Collection interface
+type collectionObject struct {
+	svc *Service
+}
+
+// Delete is not supported, vaults can be deleted only using Paw
+func (o *collectionObject) Delete(msg dbus.Message) (dbus.ObjectPath, *dbus.Error) {
+	return noPrompt, errNotSupported("collections can be deleted only using Paw")
+}
+
+// SearchItems returns the collection items matching the attributes
+func (o *collectionObject) SearchItems(msg dbus.Message, attributes map[string]string) ([]dbus.ObjectPath, *dbus.Error) {
+	o.svc.lock()
+	defer o.svc.mu.Unlock()
+
+	path := messagePath(msg)
+	vault, ok := o.svc.lookupVault(path)
+	if !ok || !isCollectionPath(path) {
+		return nil, errNoSuchObject(path)
+	}
+	return o.svc.searchItems(vault, attributes), nil
+}
+
+// CreateItem creates an item into the collection. If replace is true an item
+// with the same attributes is updated.
+func (o *collectionObject) CreateItem(msg dbus.Message, properties map[string]dbus.Variant, sec secret, replace bool) (dbus.ObjectPath, dbus.ObjectPath, *dbus.Error) {
+	o.svc.lock()
+	defer o.svc.mu.Unlock()
+
+	path := messagePath(msg)
+	vault, ok := o.svc.lookupVault(path)
+	if !ok || !isCollectionPath(path) {
+		return noPrompt, noPrompt, errNoSuchObject(path)
+	}
+
+	s, dbusErr := o.svc.session(sec.Session, messageSender(msg))
+	if dbusErr != nil {
+		return noPrompt, noPrompt, dbusErr
+	}
+	value, err := s.decrypt(sec)
+	if err != nil {
+		return noPrompt, noPrompt, errInvalidArgs(err.Error())
+	}
+
+	var label string
+	if v, ok := properties[itemLabelProperty]; ok {
+		label, ok = v.Value().(string)
+		if !ok {
+			return noPrompt, noPrompt, errInvalidArgs("the label must be a string")
+		}
+	}
+	attributes := map[string]string{}
+	if v, ok := properties[itemAttributesProperty]; ok {
+		attributes, ok = v.Value().(map[string]string)
+		if !ok {
+			return noPrompt, noPrompt, errInvalidArgs("the attributes must be a string dictionary")
+		}
+	}
+
+	if replace {
+		for _, meta := range vault.ItemMetadata[paw.PasswordItemType] {
+			if len(meta.Attributes) != len(attributes) || !matchAttributes(meta.Attributes, attributes) {
+				continue
+			}
+			password, dbusErr := o.svc.loadPassword(vault, meta)
+			if dbusErr != nil {
+				return noPrompt, noPrompt, dbusErr
+			}
+			previous := password.Value
+			password.Value = string(value)
+			password.UpdateHistory(previous, time.Now().UTC())
+			dbusErr = o.svc.storePassword(vault, password)
+			if dbusErr != nil {
+				return noPrompt, noPrompt, dbusErr
+			}
+			itemPath := itemPath(vault, meta)
+			o.svc.itemChanged(vault, "ItemChanged", itemPath)
+			return itemPath, noPrompt, nil
+		}
+	}
+
+	password, dbusErr := o.svc.createPassword(vault, label, attributes, value)
+	if dbusErr != nil {
+		return noPrompt, noPrompt, dbusErr
+	}
+	itemPath := itemPath(vault, password.Metadata)
+	o.svc.itemChanged(vault, "ItemCreated", itemPath)
+	return itemPath, noPrompt, nil
+}
+
+// itemObject implements the org.freedesktop.Secret.Item interface
+type itemObject struct {
+	svc *Service
+}
+
+// Delete deletes the item
+func (o *itemObject) Delete(msg dbus.Message) (dbus.ObjectPath, *dbus.Error) {
+	o.svc.lock()
+	defer o.svc.mu.Unlock()
+
+	path := messagePath(msg)
+	vault, meta, ok := o.svc.lookupItem(path)
+	if !ok {
+		return noPrompt, errNoSuchObject(path)
+	}
+	password, dbusErr := o.svc.loadPassword(vault, meta)
+	if dbusErr != nil {
+		return noPrompt, dbusErr
+	}
+	// the deleted items are moved to the trash to be restored if needed
+	err := paw.MoveItemToTrash(o.svc.storage, vault, password)
+	if err != nil {
+		return noPrompt, errFailed(err)
+	}
+	o.svc.itemChanged(vault, "ItemDeleted", itemPath(vault, meta))
+	return noPrompt, nil
+}
+
+// GetSecret returns the item secret
+func (o *itemObject) GetSecret(msg dbus.Message, sessionPath dbus.ObjectPath) (secret, *dbus.Error) {
+	o.svc.lock()
+	defer o.svc.mu.Unlock()
+
+	s, dbusErr := o.svc.session(sessionPath, messageSender(msg))
+	if dbusErr != nil {
+		return secret{}, dbusErr
+	}
+	path := messagePath(msg)
+	vault, meta, ok := o.svc.lookupItem(path)
+	if !ok {
+		return secret{}, errNoSuchObject(path)
+	}
+	password, dbusErr := o.svc.loadPassword(vault, meta)
+	if dbusErr != nil {
+		return secret{}, dbusErr
+	}
+	sec, err := s.encrypt([]byte(password.Value), defaultContentType)
+	if err != nil {
+		return secret{}, errFailed(err)
+	}
+	return sec, nil
+}
+
+// SetSecret sets the item secret
+func (o *itemObject) SetSecret(msg dbus.Message, sec secret) *dbus.Error {
+	o.svc.lock()
+	defer o.svc.mu.Unlock()
+
+	s, dbusErr := o.svc.session(sec.Session, messageSender(msg))
+	if dbusErr != nil {
+		return dbusErr
+	}
+	value, err := s.decrypt(sec)
+	if err != nil {
+		return errInvalidArgs(err.Error())
+	}
+	path := messagePath(msg)
+	vault, meta, ok := o.svc.lookupItem(path)
+	if !ok {
+		return errNoSuchObject(path)
+	}
+	password, dbusErr := o.svc.loadPassword(vault, meta)
+	if dbusErr != nil {
+		return dbusErr
+	}
+	previous := password.Value
+	password.Value = string(value)
+	password.UpdateHistory(previous, time.Now().UTC())
+	dbusErr = o.svc.storePassword(vault, password)
+	if dbusErr != nil {
+		return dbusErr
+	}
+	o.svc.itemChanged(vault, "ItemChanged", path)
+	return nil
+}
+
+// sessionObject implements the org.freedesktop.Secret.Session interface
+type sessionObject struct {
+	svc *Service
+}
+
+// Close closes the session
+func (o *sessionObject) Close(msg dbus.Message) *dbus.Error {
+	o.svc.mu.Lock()
+	defer o.svc.mu.Unlock()
+
+	path := messagePath(msg)
+	if _, dbusErr := o.svc.session(path, messageSender(msg)); dbusErr != nil {
+		return dbusErr
+	}
+	delete(o.svc.sessions, path)
+	return nil
+}
+
+// propertiesObject implements the org.freedesktop.DBus.Properties interface
+// for the service, collection and item objects
+type propertiesObject struct {
+	svc *Service
+}
+
+// Get returns the property value
+func (o *propertiesObject) Get(msg dbus.Message, iface string, property string) (dbus.Variant, *dbus.Error) {
+	props, dbusErr := o.GetAll(msg, iface)
+	if dbusErr != nil {
+		return dbus.MakeVariant(""), dbusErr
+	}
+	v, ok := props[property]
+	if !ok {
+		return dbus.MakeVariant(""), errInvalidArgs(fmt.Sprintf("unknown property %s.%s", iface, property))
+	}
+	return v, nil
+}
+
+// GetAll returns all the properties of the interface
+func (o *propertiesObject) GetAll(msg dbus.Message, iface string) (map[string]dbus.Variant, *dbus.Error) {
+	o.svc.lock()
+	defer o.svc.mu.Unlock()
+
+	path := messagePath(msg)
+	props := map[string]dbus.Variant{}
+	switch {
+	case path == servicePath:
+		if iface != serviceInterface {
+			return props, nil
+		}
+		collections := []dbus.ObjectPath{}
+		for _, vault := range o.svc.vaults {
+			collections = append(collections, collectionPath(vault))
+		}
+		props["Collections"] = dbus.MakeVariant(sortedPaths(collections))
+	case isCollectionPath(path):
+		vault, ok := o.svc.lookupVault(path)
+		if !ok {
+			return nil, errNoSuchObject(path)
+		}
+		if iface != collectionInterface {
+			return props, nil
+		}
+		props["Items"] = dbus.MakeVariant(o.svc.searchItems(vault, nil))
+		props["Label"] = dbus.MakeVariant(vault.Name)
+		props["Locked"] = dbus.MakeVariant(false)
+		props["Created"] = dbus.MakeVariant(uint64(vault.Created.Unix()))
+		props["Modified"] = dbus.MakeVariant(uint64(vault.Modified.Unix()))
+	default:
+		_, meta, ok := o.svc.lookupItem(path)
+		if !ok {
+			return nil, errNoSuchObject(path)
+		}
+		if iface != itemInterface {
+			return props, nil
+		}
+		attributes := meta.Attributes
+		if attributes == nil {
+			attributes = map[string]string{}
+		}
+		props["Label"] = dbus.MakeVariant(meta.Name)
+		props["Attributes"] = dbus.MakeVariant(attributes)
+		props["Locked"] = dbus.MakeVariant(false)
+		props["Created"] = dbus.MakeVariant(uint64(meta.Created.Unix()))
+		props["Modified"] = dbus.MakeVariant(uint64(meta.Modified.Unix()))
+	}
+	return props, nil
+}
+
+// Set sets the property value. Only the item label and attributes can be set.
+func (o *propertiesObject) Set(msg dbus.Message, iface string, property string, value dbus.Variant) *dbus.Error {
+	o.svc.lock()
+	defer o.svc.mu.Unlock()
+
+	path := messagePath(msg)
+	if iface != itemInterface {
+		return errNotSupported(fmt.Sprintf("property %s.%s cannot be set", iface, property))
+	}
+	vault, meta, ok := o.svc.lookupItem(path)
+	if !ok {
+		return errNoSuchObject(path)
+	}
+	password, dbusErr := o.svc.loadPassword(vault, meta)
+	if dbusErr != nil {
+		return dbusErr
+	}
+
+	switch property {
+	case "Label":
+		label, ok := value.Value().(string)
+		if !ok {
+			return errInvalidArgs("the label must be a string")
+		}
+		if label == password.Name {
+			return nil
+		}
+		err := vault.RenameItem(password, itemName(vault, label))
+		if err != nil {
+			return errFailed(err)
+		}
+	case "Attributes":
+		attributes, ok := value.Value().(map[string]string)
+		if !ok {
+			return errInvalidArgs("the attributes must be a string dictionary")
+		}
+		password.Attributes = attributes
+	default:
+		return errNotSupported(fmt.Sprintf("property %s.%s cannot be set", iface, property))
+	}
+
+	dbusErr = o.svc.storePassword(vault, password)
+	if dbusErr != nil {
+		return dbusErr
+	}
+	o.svc.itemChanged(vault, "ItemChanged", path)
+	return nil
+}
@@ -0,0 +1,406 @@
+// SPDX-FileCopyrightText: 2025 Luca Corbo, Paw contributors
+// SPDX-License-Identifier: AGPL-3.0-or-later
+
+// Package secretservice implements a provider for the Freedesktop Secret Service API
+// exposing the unlocked vaults as collections.
+// See https://specifications.freedesktop.org/secret-service-spec/latest/
+package secretservice
+
+import (
+	"errors"
+	"fmt"
+	"log"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/godbus/dbus/v5"
+
+	"lucor.dev/paw/internal/paw"
+)
+
+const (
+	// BusName is the well-known bus name owned by the Secret Service provider
+	BusName = "org.freedesktop.secrets"
+
+	servicePath      dbus.ObjectPath = "/org/freedesktop/secrets"
+	collectionPrefix                 = "/org/freedesktop/secrets/collection/"
+	aliasPrefix                      = "/org/freedesktop/secrets/aliases/"
+	sessionPrefix                    = "/org/freedesktop/secrets/session/"
+
+	// noPrompt is the object path returned when a prompt is not required
+	noPrompt dbus.ObjectPath = "/"
+	// defaultAlias is the alias of the collection used by default by the clients
+	defaultAlias = "default"
+
+	serviceInterface    = "org.freedesktop.Secret.Service"
+	collectionInterface = "org.freedesktop.Secret.Collection"
+	itemInterface       = "org.freedesktop.Secret.Item"
+	sessionInterface    = "org.freedesktop.Secret.Session"
+	propertiesInterface = "org.freedesktop.DBus.Properties"
+
+	itemLabelProperty      = itemInterface + ".Label"
+	itemAttributesProperty = itemInterface + ".Attributes"
+
+	// defaultContentType is the content type of the secrets returned to the clients
+	defaultContentType = "text/plain; charset=utf8"
+)
+
+// ErrNameTaken is returned when the bus name is already owned by another provider
+var ErrNameTaken = errors.New("the " + BusName + " bus name is owned by another application")
+
+// Service is the Secret Service provider. Only the password items are exposed.
+// The service loads its own copy of the unlocked vaults, so the app and the
+// service never share an in-memory vault: the changes of each side are merged
+// through the storage, see paw.MergeVault.
+type Service struct {
+	conn    *dbus.Conn
+	storage paw.Storage
+
+	// OnVaultChanged, if not nil, is called when a client creates, changes or
+	// deletes an item of the vault. It is called from the D-Bus goroutines.
+	OnVaultChanged func(name string)
+
+	mu sync.Mutex
+	// vaults are the copies of the unlocked vaults exposed as collections, the first one is the default
+	vaults      []*paw.Vault
+	sessions    map[dbus.ObjectPath]*session
+	sessionSeed int
+}
+
+// New returns a Secret Service provider using the D-Bus connection
+func New(conn *dbus.Conn, s paw.Storage) *Service {
+	return &Service{
+		conn:     conn,
+		storage:  s,
+		sessions: make(map[dbus.ObjectPath]*session),
+	}
+}
+
+// Start exports the Secret Service objects and requests the bus name.
+// ErrNameTaken is returned if another provider owns the bus name.
+func (svc *Service) Start() error {
+	objects := map[string]interface{}{
+		serviceInterface:    &serviceObject{svc},
+		collectionInterface: &collectionObject{svc},
+		itemInterface:       &itemObject{svc},
+		sessionInterface:    &sessionObject{svc},
+		propertiesInterface: &propertiesObject{svc},
+	}
+	for iface, v := range objects {
+		err := svc.conn.ExportSubtree(v, servicePath, iface)
+		if err != nil {
+			svc.unexport()
+			return fmt.Errorf("could not export the %s interface: %w", iface, err)
+		}
+	}
+
+	reply, err := svc.conn.RequestName(BusName, dbus.NameFlagDoNotQueue)
+	if err != nil {
+		svc.unexport()
+		return fmt.Errorf("could not request the bus name: %w", err)
+	}
+	if reply != dbus.RequestNameReplyPrimaryOwner {
+		svc.unexport()
+		return ErrNameTaken
+	}
+	return nil
+}
+
+// Stop releases the bus name and removes the exported objects
+func (svc *Service) Stop() error {
+	_, err := svc.conn.ReleaseName(BusName)
+	svc.unexport()
+
+	svc.mu.Lock()
+	svc.sessions = make(map[dbus.ObjectPath]*session)
+	svc.mu.Unlock()
+	return err
+}
+
+// Close closes the D-Bus connection
+func (svc *Service) Close() error {
+	return svc.conn.Close()
+}
+
+func (svc *Service) unexport() {
+	for _, iface := range []string{serviceInterface, collectionInterface, itemInterface, sessionInterface, propertiesInterface} {
+		_ = svc.conn.ExportSubtree(nil, servicePath, iface)
+	}
+}
+
+// AddVault exposes the unlocked vault as collection. The vault is loaded
+// from the storage using the vault key, a vault already exposed is reloaded.
+func (svc *Service) AddVault(vault *paw.Vault) error {
+	loaded, err := svc.storage.LoadVault(vault.Name, vault.Key())
+	if err != nil {
+		return fmt.Errorf("could not load the vault %q: %w", vault.Name, err)
+	}
+
+	svc.mu.Lock()
+	defer svc.mu.Unlock()
+	for i, v := range svc.vaults {
+		if v.Name == vault.Name {
+			svc.vaults[i] = loaded
+			return nil
+		}
+	}
+	svc.vaults = append(svc.vaults, loaded)
+	svc.emit(servicePath, serviceInterface+".CollectionCreated", collectionPath(loaded))
+	return nil
+}
+
+// RemoveVault removes the collection of the locked vault
+func (svc *Service) RemoveVault(name string) {
+	svc.mu.Lock()
+	defer svc.mu.Unlock()
+	for i, v := range svc.vaults {
+		if v.Name == name {
+			svc.vaults = append(svc.vaults[:i], svc.vaults[i+1:]...)
+			svc.emit(servicePath, serviceInterface+".CollectionDeleted", collectionPath(v))
+			return
+		}
+	}
+}
+
+// lock locks the service and merges into the vaults the changes stored by the
+// app or the other writers since the last request
+func (svc *Service) lock() {
+	svc.mu.Lock()
+	for _, v := range svc.vaults {
+		_, err := paw.MergeVault(svc.storage, v)
+		if err != nil {
+			// the vault is still consistent, the changes will be merged on store
+			log.Printf("secret service: could not merge the vault %q: %s", v.Name, err)
+		}
+	}
+}
+
+// itemChanged emits the signal for the item changed by a client and notifies the app
+func (svc *Service) itemChanged(vault *paw.Vault, signal string, path dbus.ObjectPath) {
+	svc.emit(collectionPath(vault), collectionInterface+"."+signal, path)
+	if svc.OnVaultChanged != nil {
+		svc.OnVaultChanged(vault.Name)
+	}
+}
+
+// emit emits a signal ignoring the errors, the clients are not required to listen them
+func (svc *Service) emit(path dbus.ObjectPath, name string, values ...interface{}) {
+	_ = svc.conn.Emit(path, name, values...)
+}
+
+// lookupVault returns the vault identified by the collection path or alias
+func (svc *Service) lookupVault(path dbus.ObjectPath) (*paw.Vault, bool) {
+	p := string(path)
+	switch {
+	case strings.HasPrefix(p, aliasPrefix):
+		name := strings.SplitN(strings.TrimPrefix(p, aliasPrefix), "/", 2)[0]
+		if name != defaultAlias || len(svc.vaults) == 0 {
+			return nil, false
+		}
+		return svc.vaults[0], true
+	case strings.HasPrefix(p, collectionPrefix):
+		name := strings.SplitN(strings.TrimPrefix(p, collectionPrefix), "/", 2)[0]
+		for _, v := range svc.vaults {
+			if encodePathElement(v.Name) == name {
+				return v, true
+			}
+		}
+	}
+	return nil, false
+}
+
+// lookupItem returns the vault and the item metadata identified by the item path
+func (svc *Service) lookupItem(path dbus.ObjectPath) (*paw.Vault, *paw.Metadata, bool) {
+	vault, ok := svc.lookupVault(path)
+	if !ok {
+		return nil, nil, false
+	}
+	parts := strings.Split(string(path), "/")
+	if len(parts) != 7 {
+		return nil, nil, false
+	}
+	id := parts[6]
+	for _, meta := range vault.ItemMetadata[paw.PasswordItemType] {
+		if encodePathElement(meta.ID()) == id {
+			return vault, meta, true
+		}
+	}
+	return nil, nil, false
+}
+
+// isCollectionPath reports whether the path identifies a collection, including the aliases
+func isCollectionPath(path dbus.ObjectPath) bool {
+	for _, prefix := range []string{collectionPrefix, aliasPrefix} {
+		if strings.HasPrefix(string(path), prefix) {
+			return !strings.Contains(strings.TrimPrefix(string(path), prefix), "/")
+		}
+	}
+	return false
+}
+
+// searchItems returns the paths of the vault items matching all the attributes
+func (svc *Service) searchItems(vault *paw.Vault, attributes map[string]string) []dbus.ObjectPath {
+	items := []dbus.ObjectPath{}
+	for _, meta := range vault.FilterItemMetadata(&paw.VaultFilterOptions{ItemType: paw.PasswordItemType}) {
+		if matchAttributes(meta.Attributes, attributes) {
+			items = append(items, itemPath(vault, meta))
+		}
+	}
+	return items
+}
+
+// session returns the session opened by the sender
+func (svc *Service) session(path dbus.ObjectPath, sender string) (*session, *dbus.Error) {
+	s, ok := svc.sessions[path]
+	if !ok || s.sender != sender {
+		return nil, errNoSession(path)
+	}
+	return s, nil
+}
+
+// loadPassword loads the password item from the storage
+func (svc *Service) loadPassword(vault *paw.Vault, meta *paw.Metadata) (*paw.Password, *dbus.Error) {
+	item, err := svc.storage.LoadItem(vault, meta)
+	if err != nil {
+		return nil, errFailed(err)
+	}
+	password, ok := item.(*paw.Password)
+	if !ok {
+		return nil, errNoSuchObject(itemPath(vault, meta))
+	}
+	return password, nil
+}
+
+// storePassword stores the password item and the vault
+func (svc *Service) storePassword(vault *paw.Vault, password *paw.Password) *dbus.Error {
+	unlock, err := paw.LockVault(svc.storage, vault)
+	if err != nil {
+		return errFailed(err)
+	}
+	defer unlock()
+
+	now := time.Now().UTC()
+	password.Modified = now
+	err = svc.storage.StoreItem(vault, password)
+	if err != nil {
+		return errFailed(err)
+	}
+	err = vault.AddItem(password)
+	if err != nil {
+		return errFailed(err)
+	}
+	vault.Modified = now
+	err = svc.storage.StoreVault(vault)
+	if err != nil {
+		return errFailed(err)
+	}
+	return nil
+}
+
+// createPassword creates a new password item into the vault
+func (svc *Service) createPassword(vault *paw.Vault, label string, attributes map[string]string, value []byte) (*paw.Password, *dbus.Error) {
+	name := itemName(vault, label)
+	item, err := paw.NewItem(name, paw.PasswordItemType)
+	if err != nil {
+		return nil, errFailed(err)
+	}
+	password := item.(*paw.Password)
+	password.Value = string(value)
+	password.Mode = paw.CustomPassword
+	password.Attributes = attributes
+	dbusErr := svc.storePassword(vault, password)
+	if dbusErr != nil {
+		return nil, dbusErr
+	}
+	return password, nil
+}
+
+// itemName returns an unique item name for the label.
+// The path separator is not allowed into the item names.
+func itemName(vault *paw.Vault, label string) string {
+	label = strings.TrimSpace(strings.ReplaceAll(label, "/", "-"))
+	if label == "" {
+		label = "Secret"
+	}
+	name := label
+	for i := 2; ; i++ {
+		if _, ok := vault.ItemMetadataByName(paw.PasswordItemType, name); !ok {
+			return name
+		}
+		name = fmt.Sprintf("%s (%d)", label, i)
+	}
+}
+
+// matchAttributes reports whether all the search attributes are defined with the same value
+func matchAttributes(attributes map[string]string, search map[string]string) bool {
+	for k, v := range search {
+		if attributes[k] != v {
+			return false
+		}
+	}
+	return true
+}
+
+// collectionPath returns the object path of the vault collection
+func collectionPath(vault *paw.Vault) dbus.ObjectPath {
+	return dbus.ObjectPath(collectionPrefix + encodePathElement(vault.Name))
+}
+
+// itemPath returns the object path of the vault item
+func itemPath(vault *paw.Vault, meta *paw.Metadata) dbus.ObjectPath {
+	return collectionPath(vault) + "/" + dbus.ObjectPath(encodePathElement(meta.ID()))
+}
+
+// encodePathElement encodes s as a valid object path element.
+// Chars other than [A-Za-z0-9] are encoded as _XX where XX is the hex value.
+func encodePathElement(s string) string {
+	sb := strings.Builder{}
+	for _, b := range []byte(s) {
+		if (b >= 'a' && b <= 'z') || (b >= 'A' && b <= 'Z') || (b >= '0' && b <= '9') {
+			sb.WriteByte(b)
+			continue
+		}
+		fmt.Fprintf(&sb, "_%02x", b)
+	}
+	return sb.String()
+}
+
+// sortedPaths returns the paths sorted
+func sortedPaths(paths []dbus.ObjectPath) []dbus.ObjectPath {
+	sort.Slice(paths, func(i, j int) bool { return paths[i] < paths[j] })
+	return paths
+}
+
+// messagePath returns the object path of the method call
+func messagePath(msg dbus.Message) dbus.ObjectPath {
+	path, _ := msg.Headers[dbus.FieldPath].Value().(dbus.ObjectPath)
+	return path
+}
+
+// messageSender returns the unique bus name of the method caller
+func messageSender(msg dbus.Message) string {
+	sender, _ := msg.Headers[dbus.FieldSender].Value().(string)
+	return sender
+}
+
+func errNoSuchObject(path dbus.ObjectPath) *dbus.Error {
+	return dbus.NewError("org.freedesktop.Secret.Error.NoSuchObject", []interface{}{fmt.Sprintf("no such object %s", path)})
+}
+
+func errNoSession(path dbus.ObjectPath) *dbus.Error {
+	return dbus.NewError("org.freedesktop.Secret.Error.NoSession", []interface{}{fmt.Sprintf("no such session %s", path)})
+}
+
+func errNotSupported(msg string) *dbus.Error {
+	return dbus.NewError("org.freedesktop.DBus.Error.NotSupported", []interface{}{msg})
+}
+
+func errInvalidArgs(msg string) *dbus.Error {
+	return dbus.NewError("org.freedesktop.DBus.Error.InvalidArgs", []interface{}{msg})
+}
+
+func errFailed(err error) *dbus.Error {
+	return dbus.NewError("org.freedesktop.DBus.Error.Failed", []interface{}{err.Error()})
+}
@@ -0,0 +1,437 @@
+// SPDX-FileCopyrightText: 2025 Luca Corbo, Paw contributors
+// SPDX-License-Identifier: AGPL-3.0-or-later
+
+package secretservice
+
+import (
+	"bufio"
+	"bytes"
+	"errors"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/godbus/dbus/v5"
+
+	"lucor.dev/paw/internal/paw"
+)
+
+const busConfig = `<!DOCTYPE busconfig PUBLIC "-//freedesktop//DTD D-Bus Bus Configuration 1.0//EN"
+ "http://www.freedesktop.org/standards/dbus/1.0/busconfig.dtd">
+<busconfig>
+  <type>session</type>
+  <listen>unix:dir=%s</listen>
+  <auth>EXTERNAL</auth>
+  <policy context="default">
+    <allow send_destination="*" eavesdrop="true"/>
+    <allow eavesdrop="true"/>
+    <allow own="*"/>
+  </policy>
+</busconfig>
+`
+
+// startBus starts a private dbus-daemon returning its address
+func startBus(t *testing.T) string {
+	t.Helper()
+	daemon, err := exec.LookPath("dbus-daemon")
+	if err != nil {
+		t.Skip("dbus-daemon not available")
+	}
+
+	dir := t.TempDir()
+	config := filepath.Join(dir, "bus.conf")
+	err = os.WriteFile(config, []byte(strings.Replace(busConfig, "%s", dir, 1)), 0600)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	cmd := exec.Command(daemon, "--config-file="+config, "--nofork", "--print-address=1")
+	stdout, err := cmd.StdoutPipe()
+	if err != nil {
+		t.Fatal(err)
+	}
+	err = cmd.Start()
+	if err != nil {
+		t.Fatal(err)
+	}
+	t.Cleanup(func() {
+		cmd.Process.Kill()
+		cmd.Wait()
+	})
+
+	address, err := bufio.NewReader(stdout).ReadString('\n')
+	if err != nil {
+		t.Fatalf("could not read the bus address: %s", err)
+	}
+	return strings.TrimSpace(address)
+}
+
+func connect(t *testing.T, address string) *dbus.Conn {
+	t.Helper()
+	conn, err := dbus.Connect(address)
+	if err != nil {
+		t.Fatal(err)
+	}
+	t.Cleanup(func() { conn.Close() })
+	return conn
+}
+
+func newStorageWithVault(t *testing.T) (paw.Storage, *paw.Vault) {
+	t.Helper()
+	s, err := paw.NewOSStorageRooted(t.TempDir())
+	if err != nil {
+		t.Fatal(err)
+	}
+	key, err := s.CreateVaultKey("my vault", "secret")
+	if err != nil {
+		t.Fatal(err)
+	}
+	vault, err := s.CreateVault("my vault", key)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return s, vault
+}
+
+func TestService(t *testing.T) {
+	address := startBus(t)
+	s, vault := newStorageWithVault(t)
+
+	svc := New(connect(t, address), s)
+	err := svc.AddVault(vault)
+	if err != nil {
+		t.Fatal(err)
+	}
+	err = svc.Start()
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer svc.Stop()
+
+	// the bus name can be owned by only one provider
+	other := New(connect(t, address), s)
+	err = other.Start()
+	if !errors.Is(err, ErrNameTaken) {
+		t.Fatalf("Start() error = %v, want %v", err, ErrNameTaken)
+	}
+
+	client := connect(t, address)
+	service := client.Object(BusName, servicePath)
+
+	var collection dbus.ObjectPath
+	err = service.Call(serviceInterface+".ReadAlias", 0, "default").Store(&collection)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if want := collectionPath(vault); collection != want {
+		t.Fatalf("ReadAlias() = %s, want %s", collection, want)
+	}
+
+	var output dbus.Variant
+	var sessionPath dbus.ObjectPath
+	err = service.Call(serviceInterface+".OpenSession", 0, plainAlgorithm, dbus.MakeVariant("")).Store(&output, &sessionPath)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	attributes := map[string]string{"service": "example.com", "username": "john"}
+	props := map[string]dbus.Variant{
+		itemLabelProperty:      dbus.MakeVariant("Example/Password"),
+		itemAttributesProperty: dbus.MakeVariant(attributes),
+	}
+	var item, prompt dbus.ObjectPath
+	alias := client.Object(BusName, dbus.ObjectPath(aliasPrefix+defaultAlias))
+	err = alias.Call(collectionInterface+".CreateItem", 0, props, secret{Session: sessionPath, Parameters: []byte{}, Value: []byte("s3cr3t"), ContentType: "text/plain"}, true).Store(&item, &prompt)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if prompt != noPrompt {
+		t.Errorf("CreateItem() prompt = %s, want %s", prompt, noPrompt)
+	}
+
+	var unlocked, locked []dbus.ObjectPath
+	err = service.Call(serviceInterface+".SearchItems", 0, map[string]string{"service": "example.com"}).Store(&unlocked, &locked)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(unlocked) != 1 || unlocked[0] != item || len(locked) != 0 {
+		t.Fatalf("SearchItems() = %v, %v, want [%s], []", unlocked, locked, item)
+	}
+
+	err = service.Call(serviceInterface+".SearchItems", 0, map[string]string{"service": "other.com"}).Store(&unlocked, &locked)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(unlocked) != 0 {
+		t.Fatalf("SearchItems() = %v, want []", unlocked)
+	}
+
+	var secrets map[dbus.ObjectPath]secret
+	err = service.Call(serviceInterface+".GetSecrets", 0, []dbus.ObjectPath{item}, sessionPath).Store(&secrets)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got := string(secrets[item].Value); got != "s3cr3t" {
+		t.Errorf("GetSecrets() = %q, want %q", got, "s3cr3t")
+	}
+
+	itemObj := client.Object(BusName, item)
+	label, err := itemObj.GetProperty(itemInterface + ".Label")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got := label.Value().(string); got != "Example-Password" {
+		t.Errorf("Label = %q, want %q", got, "Example-Password")
+	}
+
+	// replace the item with the same attributes
+	props[itemLabelProperty] = dbus.MakeVariant("Another label")
+	var replaced dbus.ObjectPath
+	err = alias.Call(collectionInterface+".CreateItem", 0, props, secret{Session: sessionPath, Parameters: []byte{}, Value: []byte("n3w"), ContentType: "text/plain"}, true).Store(&replaced, &prompt)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if replaced != item {
+		t.Errorf("CreateItem() with replace = %s, want %s", replaced, item)
+	}
+
+	var sec secret
+	err = itemObj.Call(itemInterface+".GetSecret", 0, sessionPath).Store(&sec)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got := string(sec.Value); got != "n3w" {
+		t.Errorf("GetSecret() = %q, want %q", got, "n3w")
+	}
+
+	// the session cannot be used by another client
+	err = connect(t, address).Object(BusName, item).Call(itemInterface+".GetSecret", 0, sessionPath).Store(&sec)
+	var dbusErr dbus.Error
+	if !errors.As(err, &dbusErr) || dbusErr.Name != "org.freedesktop.Secret.Error.NoSession" {
+		t.Errorf("GetSecret() from another client error = %v, want NoSession", err)
+	}
+
+	// the item is persisted with its attributes
+	key, err := s.LoadVaultKey(vault.Name, "secret")
+	if err != nil {
+		t.Fatal(err)
+	}
+	stored, err := s.LoadVault(vault.Name, key)
+	if err != nil {
+		t.Fatal(err)
+	}
+	meta, ok := stored.ItemMetadataByName(paw.PasswordItemType, "Example-Password")
+	if !ok {
+		t.Fatal("item not found into the stored vault")
+	}
+	if !matchAttributes(meta.Attributes, attributes) {
+		t.Errorf("stored attributes = %v, want %v", meta.Attributes, attributes)
+	}
+
+	err = itemObj.Call(itemInterface+".Delete", 0).Store(&prompt)
+	if err != nil {
+		t.Fatal(err)
+	}
+	err = service.Call(serviceInterface+".SearchItems", 0, map[string]string{}).Store(&unlocked, &locked)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(unlocked) != 0 {
+		t.Errorf("SearchItems() after delete = %v, want []", unlocked)
+	}
+
+	// locked vaults are not exposed
+	svc.RemoveVault(vault.Name)
+	err = service.Call(serviceInterface+".ReadAlias", 0, "default").Store(&collection)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if collection != noPrompt {
+		t.Errorf("ReadAlias() with locked vault = %s, want %s", collection, noPrompt)
+	}
+}
+
+func TestService_DHSession(t *testing.T) {
+	address := startBus(t)
+	s, vault := newStorageWithVault(t)
+
+	svc := New(connect(t, address), s)
+	err := svc.AddVault(vault)
+	if err != nil {
+		t.Fatal(err)
+	}
+	err = svc.Start()
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer svc.Stop()
+
+	client := connect(t, address)
+	service := client.Object(BusName, servicePath)
+
+	private, public, err := dhKeyPair()
+	if err != nil {
+		t.Fatal(err)
+	}
+	var output dbus.Variant
+	var sessionPath dbus.ObjectPath
+	err = service.Call(serviceInterface+".OpenSession", 0, dhAlgorithm, dbus.MakeVariant(public)).Store(&output, &sessionPath)
+	if err != nil {
+		t.Fatal(err)
+	}
+	key, err := dhSharedKey(private, output.Value().([]byte))
+	if err != nil {
+		t.Fatal(err)
+	}
+	clientSession := &session{path: sessionPath, key: key}
+
+	sec, err := clientSession.encrypt([]byte("dh s3cr3t"), "text/plain")
+	if err != nil {
+		t.Fatal(err)
+	}
+	props := map[string]dbus.Variant{
+		itemLabelProperty:      dbus.MakeVariant("dh"),
+		itemAttributesProperty: dbus.MakeVariant(map[string]string{"app": "test"}),
+	}
+	var item, prompt dbus.ObjectPath
+	err = client.Object(BusName, collectionPath(vault)).Call(collectionInterface+".CreateItem", 0, props, sec, false).Store(&item, &prompt)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	err = client.Object(BusName, item).Call(itemInterface+".GetSecret", 0, sessionPath).Store(&sec)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if bytes.Equal(sec.Value, []byte("dh s3cr3t")) {
+		t.Fatal("GetSecret() returned the secret unencrypted")
+	}
+	value, err := clientSession.decrypt(sec)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if string(value) != "dh s3cr3t" {
+		t.Errorf("GetSecret() = %q, want %q", value, "dh s3cr3t")
+	}
+
+	err = service.Call(serviceInterface+".OpenSession", 0, "unknown", dbus.MakeVariant("")).Store(&output, &sessionPath)
+	var dbusErr dbus.Error
+	if !errors.As(err, &dbusErr) || dbusErr.Name != "org.freedesktop.DBus.Error.NotSupported" {
+		t.Errorf("OpenSession() with unknown algorithm error = %v, want NotSupported", err)
+	}
+}
+
+func TestService_VaultCopy(t *testing.T) {
+	address := startBus(t)
+	s, vault := newStorageWithVault(t)
+
+	changed := make(chan string, 10)
+	svc := New(connect(t, address), s)
+	svc.OnVaultChanged = func(name string) {
+		changed <- name
+	}
+	err := svc.AddVault(vault)
+	if err != nil {
+		t.Fatal(err)
+	}
+	err = svc.Start()
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer svc.Stop()
+
+	client := connect(t, address)
+	service := client.Object(BusName, servicePath)
+	var output dbus.Variant
+	var sessionPath dbus.ObjectPath
+	err = service.Call(serviceInterface+".OpenSession", 0, plainAlgorithm, dbus.MakeVariant("")).Store(&output, &sessionPath)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// the item created by a client is stored without changing the app vault
+	props := map[string]dbus.Variant{
+		itemLabelProperty:      dbus.MakeVariant("client"),
+		itemAttributesProperty: dbus.MakeVariant(map[string]string{"app": "client"}),
+	}
+	var item, prompt dbus.ObjectPath
+	err = client.Object(BusName, collectionPath(vault)).Call(collectionInterface+".CreateItem", 0, props, secret{Session: sessionPath, Parameters: []byte{}, Value: []byte("s3cr3t"), ContentType: "text/plain"}, false).Store(&item, &prompt)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if name := <-changed; name != vault.Name {
+		t.Errorf("OnVaultChanged() = %q, want %q", name, vault.Name)
+	}
+	if _, ok := vault.ItemMetadataByName(paw.PasswordItemType, "client"); ok {
+		t.Fatal("the app vault has been changed by the service")
+	}
+
+	// the app item is stored merging the item created by the client
+	app, err := paw.NewItem("app", paw.PasswordItemType)
+	if err != nil {
+		t.Fatal(err)
+	}
+	app.(*paw.Password).Value = "app s3cr3t"
+	app.GetMetadata().Attributes = map[string]string{"app": "app"}
+	err = s.StoreItem(vault, app)
+	if err != nil {
+		t.Fatal(err)
+	}
+	err = vault.AddItem(app)
+	if err != nil {
+		t.Fatal(err)
+	}
+	err = s.StoreVault(vault)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, ok := vault.ItemMetadataByName(paw.PasswordItemType, "client"); !ok {
+		t.Fatal("the item created by the client has not been merged into the app vault")
+	}
+
+	// the item stored by the app is exposed to the clients
+	var unlocked, locked []dbus.ObjectPath
+	err = service.Call(serviceInterface+".SearchItems", 0, map[string]string{"app": "app"}).Store(&unlocked, &locked)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if want := itemPath(vault, app.GetMetadata()); len(unlocked) != 1 || unlocked[0] != want {
+		t.Fatalf("SearchItems() = %v, want [%s]", unlocked, want)
+	}
+
+	err = client.Object(BusName, item).Call(itemInterface+".Delete", 0).Store(&prompt)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if name := <-changed; name != vault.Name {
+		t.Errorf("OnVaultChanged() = %q, want %q", name, vault.Name)
+	}
+	_, err = paw.MergeVault(s, vault)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, ok := vault.ItemMetadataByName(paw.PasswordItemType, "client"); ok {
+		t.Error("the item deleted by the client has not been merged into the app vault")
+	}
+	if _, ok := vault.ItemMetadataByName(paw.PasswordItemType, "app"); !ok {
+		t.Error("the app item has been deleted")
+	}
+}
+
+func TestEncodePathElement(t *testing.T) {
+	tests := []struct {
+		in   string
+		want string
+	}{
+		{in: "vault", want: "vault"},
+		{in: "my vault", want: "my_20vault"},
+		{in: "a_b-c", want: "a_5fb_2dc"},
+	}
+	for _, tt := range tests {
+		if got := encodePathElement(tt.in); got != tt.want {
+			t.Errorf("encodePathElement(%q) = %q, want %q", tt.in, got, tt.want)
+		}
+	}
+}
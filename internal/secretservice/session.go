@@ -0,0 +1,147 @@
+// SPDX-FileCopyrightText: 2025 Luca Corbo, Paw contributors
+// SPDX-License-Identifier: AGPL-3.0-or-later
+
+package secretservice
+
+import (
+	"bytes"
+	"crypto/aes"
+	"crypto/cipher"
+	"crypto/rand"
+	"crypto/sha256"
+	"errors"
+	"fmt"
+	"io"
+	"math/big"
+
+	"github.com/godbus/dbus/v5"
+	"golang.org/x/crypto/hkdf"
+)
+
+const (
+	// plainAlgorithm transfers the secrets unencrypted
+	plainAlgorithm = "plain"
+	// dhAlgorithm negotiates an AES key using the Diffie-Hellman key exchange
+	dhAlgorithm = "dh-ietf1024-sha256-aes128-cbc-pkcs7"
+)
+
+// dhPrime is the 1024-bit MODP group prime defined into the RFC 2409 (Second Oakley Group)
+var dhPrime, _ = new(big.Int).SetString(
+	"FFFFFFFFFFFFFFFFC90FDAA22168C234C4C6628B80DC1CD1"+
+		"29024E088A67CC74020BBEA63B139B22514A08798E3404DD"+
+		"EF9519B3CD3A431B302B0A6DF25F14374FE1356D6D51C245"+
+		"E485B576625E7EC6F44C42E9A637ED6B0BFF5CB6F406B7ED"+
+		"EE386BFB5A899FA5AE9F24117C4B1FE649286651ECE65381"+
+		"FFFFFFFFFFFFFFFF", 16)
+
+// dhGenerator is the generator of the 1024-bit MODP group
+var dhGenerator = big.NewInt(2)
+
+// secret is the secret structure exchanged over D-Bus, signature (oayays)
+type secret struct {
+	Session     dbus.ObjectPath
+	Parameters  []byte
+	Value       []byte
+	ContentType string
+}
+
+// session is a Secret Service session used to transfer the secrets.
+// The key is nil for the plain algorithm.
+type session struct {
+	path dbus.ObjectPath
+	// sender is the unique bus name of the client that opened the session
+	sender string
+	key    []byte
+}
+
+// encrypt returns the secret value to send to the client
+func (s *session) encrypt(value []byte, contentType string) (secret, error) {
+	sec := secret{
+		Session:     s.path,
+		ContentType: contentType,
+	}
+	if s.key == nil {
+		sec.Parameters = []byte{}
+		sec.Value = value
+		return sec, nil
+	}
+
+	block, err := aes.NewCipher(s.key)
+	if err != nil {
+		return sec, err
+	}
+	iv := make([]byte, aes.BlockSize)
+	if _, err := io.ReadFull(rand.Reader, iv); err != nil {
+		return sec, err
+	}
+	padding := aes.BlockSize - len(value)%aes.BlockSize
+	plaintext := append(append([]byte{}, value...), bytes.Repeat([]byte{byte(padding)}, padding)...)
+	ciphertext := make([]byte, len(plaintext))
+	cipher.NewCBCEncrypter(block, iv).CryptBlocks(ciphertext, plaintext)
+	sec.Parameters = iv
+	sec.Value = ciphertext
+	return sec, nil
+}
+
+// decrypt returns the secret value sent by the client
+func (s *session) decrypt(sec secret) ([]byte, error) {
+	if s.key == nil {
+		return sec.Value, nil
+	}
+
+	if len(sec.Parameters) != aes.BlockSize {
+		return nil, errors.New("invalid secret: the IV length is invalid")
+	}
+	if len(sec.Value) == 0 || len(sec.Value)%aes.BlockSize != 0 {
+		return nil, errors.New("invalid secret: the value length is invalid")
+	}
+	block, err := aes.NewCipher(s.key)
+	if err != nil {
+		return nil, err
+	}
+	plaintext := make([]byte, len(sec.Value))
+	cipher.NewCBCDecrypter(block, sec.Parameters).CryptBlocks(plaintext, sec.Value)
+	padding := int(plaintext[len(plaintext)-1])
+	if padding == 0 || padding > aes.BlockSize || padding > len(plaintext) {
+		return nil, errors.New("invalid secret: the padding is invalid")
+	}
+	for _, b := range plaintext[len(plaintext)-padding:] {
+		if int(b) != padding {
+			return nil, errors.New("invalid secret: the padding is invalid")
+		}
+	}
+	return plaintext[:len(plaintext)-padding], nil
+}
+
+// dhKeyPair generates a Diffie-Hellman key pair for the 1024-bit MODP group
+func dhKeyPair() (private *big.Int, public []byte, err error) {
+	max := new(big.Int).Sub(dhPrime, big.NewInt(2))
+	private, err = rand.Int(rand.Reader, max)
+	if err != nil {
+		return nil, nil, err
+	}
+	private.Add(private, big.NewInt(1))
+	public = new(big.Int).Exp(dhGenerator, private, dhPrime).Bytes()
+	return private, public, nil
+}
+
+// dhSharedKey derives the AES key from the peer public key
+func dhSharedKey(private *big.Int, peerPublic []byte) ([]byte, error) {
+	y := new(big.Int).SetBytes(peerPublic)
+	pMinusOne := new(big.Int).Sub(dhPrime, big.NewInt(1))
+	if y.Cmp(big.NewInt(1)) <= 0 || y.Cmp(pMinusOne) >= 0 {
+		return nil, errors.New("invalid public key")
+	}
+
+	// the shared secret is padded to the prime length
+	shared := new(big.Int).Exp(y, private, dhPrime).Bytes()
+	ikm := make([]byte, (dhPrime.BitLen()+7)/8)
+	copy(ikm[len(ikm)-len(shared):], shared)
+
+	key := make([]byte, 16)
+	_, err := io.ReadFull(hkdf.New(sha256.New, ikm, nil, nil), key)
+	if err != nil {
+		return nil, fmt.Errorf("could not derive the key: %w", err)
+	}
+	return key, nil
+}
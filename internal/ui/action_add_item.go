@@ -4,6 +4,8 @@
 package ui
 
 import (
+	"sort"
+
 	"fyne.io/fyne/v2"
 	"fyne.io/fyne/v2/container"
 	"fyne.io/fyne/v2/widget"
@@ -29,20 +31,26 @@ func (a *app) makeAddItemView() fyne.CanvasObject {
 // makeEmptyItems returns a slice of empty paw.Item ready to use as template for
 // item's creation
 func (a *app) makeEmptyItems() []paw.Item {
-	note := paw.NewNote()
-	password := paw.NewPassword()
-	website := paw.NewLogin()
-	website.TOTP = &paw.TOTP{
-		Digits:   a.state.Preferences.TOTP.Digits,
-		Hash:     a.state.Preferences.TOTP.Hash,
-		Interval: a.state.Preferences.TOTP.Interval,
-	}
-	sshkey := paw.NewSSHKey()
-
-	return []paw.Item{
-		note,
-		password,
-		website,
-		sshkey,
+	items := []paw.Item{}
+	for _, t := range paw.ItemTypes() {
+		if _, ok := fyneItemTypes[t]; !ok {
+			continue
+		}
+		item, err := paw.NewItem("", t)
+		if err != nil {
+			continue
+		}
+		if login, ok := item.(*paw.Login); ok {
+			login.TOTP = &paw.TOTP{
+				Digits:   a.state.Preferences.TOTP.Digits,
+				Hash:     a.state.Preferences.TOTP.Hash,
+				Interval: a.state.Preferences.TOTP.Interval,
+			}
+		}
+		items = append(items, item)
 	}
+	sort.Slice(items, func(i, j int) bool {
+		return items[i].GetMetadata().Type.Label() < items[j].GetMetadata().Type.Label()
+	})
+	return items
 }
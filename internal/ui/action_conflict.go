@@ -0,0 +1,151 @@
+// SPDX-FileCopyrightText: 2025 Luca Corbo, Paw contributors
+// SPDX-License-Identifier: AGPL-3.0-or-later
+
+package ui
+
+import (
+	"fmt"
+	"time"
+
+	"fyne.io/fyne/v2"
+	"fyne.io/fyne/v2/container"
+	"fyne.io/fyne/v2/dialog"
+	"fyne.io/fyne/v2/layout"
+	"fyne.io/fyne/v2/theme"
+	"fyne.io/fyne/v2/widget"
+
+	"lucor.dev/paw/internal/paw"
+)
+
+// makeConflictsView returns a view to review the changes discarded merging
+// the concurrent changes of the vault
+func (a *app) makeConflictsView() fyne.CanvasObject {
+	conflicts := a.vault.ConflictedItems()
+
+	heading := headingText("Conflicts")
+	text := widget.NewLabel("These items have been changed concurrently, e.g. by the CLI or by another device. The most recent change has been kept, the listed changes have been discarded. A discarded change can be restored as a new item until the conflict is dismissed.")
+	text.Wrapping = fyne.TextWrapWord
+
+	dismissAllBtn := widget.NewButtonWithIcon("Dismiss All", theme.ConfirmIcon(), func() {
+		a.dismissConflicts()
+	})
+
+	list := container.NewVBox()
+	if len(conflicts) == 0 {
+		dismissAllBtn.Disable()
+		list.Add(widget.NewLabel("No conflicts to review"))
+	}
+	for _, conflict := range conflicts {
+		conflict := conflict
+
+		openBtn := widget.NewButtonWithIcon("", theme.VisibilityIcon(), func() {
+			a.showConflictedItem(conflict)
+		})
+		if _, ok := a.vault.ItemMetadata[conflict.Type][conflict.ID()]; !ok {
+			// the kept change moved the item to the trash
+			openBtn.Disable()
+		}
+		restoreBtn := widget.NewButtonWithIcon("", theme.ContentUndoIcon(), func() {
+			a.restoreConflictCopy(conflict)
+		})
+		if !conflict.Copy {
+			// the discarded change has not been kept, e.g. a deletion
+			restoreBtn.Disable()
+		}
+		dismissBtn := widget.NewButtonWithIcon("", theme.ConfirmIcon(), func() {
+			a.dismissConflicts(conflict.ID())
+		})
+
+		name := widget.NewLabelWithStyle(conflict.Name, fyne.TextAlignLeading, fyne.TextStyle{Bold: true})
+		discarded := widget.NewLabel(describeConflict(conflict))
+		list.Add(container.NewBorder(
+			nil, nil,
+			widget.NewIcon((&Metadata{Metadata: conflict.Metadata}).Icon()),
+			container.NewHBox(openBtn, restoreBtn, dismissBtn),
+			container.New(layout.NewGridLayout(2), name, discarded),
+		))
+	}
+
+	top := container.NewVBox(a.makeCancelHeaderButton(), heading, text, container.NewHBox(layout.NewSpacer(), dismissAllBtn))
+	return container.NewBorder(top, nil, nil, nil, container.NewVScroll(list))
+}
+
+// showConflictedItem shows the item as kept by the merge
+func (a *app) showConflictedItem(conflict *paw.Conflict) {
+	meta, ok := a.vault.ItemMetadata[conflict.Type][conflict.ID()]
+	if !ok {
+		return
+	}
+	item, err := a.storage.LoadItem(a.vault, meta)
+	if err != nil {
+		dialog.ShowError(err, a.win)
+		return
+	}
+	a.showItemView(a.newFyneItemWidget(a.vault, item))
+}
+
+// dismissConflicts dismisses the conflicts of the items with the specified
+// IDs, all the conflicts if none is specified
+func (a *app) dismissConflicts(ids ...string) {
+	err := paw.DismissConflicts(a.storage, a.vault, ids...)
+	if err != nil {
+		dialog.ShowError(err, a.win)
+		return
+	}
+	a.state.Modified = time.Now().UTC()
+	err = a.storage.StoreAppState(a.state)
+	if err != nil {
+		dialog.ShowError(err, a.win)
+	}
+	a.refreshCurrentView()
+	if len(a.vault.Conflicts) == 0 {
+		a.showCurrentVaultView()
+		return
+	}
+	a.showConflictsView()
+}
+
+// restoreConflictCopy restores the discarded change as a new item and shows it
+func (a *app) restoreConflictCopy(conflict *paw.Conflict) {
+	item, err := paw.RestoreConflictCopy(a.storage, a.vault, conflict.ID())
+	if err != nil {
+		dialog.ShowError(err, a.win)
+		return
+	}
+	a.state.Modified = time.Now().UTC()
+	err = a.storage.StoreAppState(a.state)
+	if err != nil {
+		dialog.ShowError(err, a.win)
+	}
+	a.refreshCurrentView()
+	a.showItemView(a.newFyneItemWidget(a.vault, item))
+}
+
+// makeConflictsStatus returns a button reporting the conflicts to review that
+// shows them once tapped. It returns nil if the vault has no conflicts.
+func (a *app) makeConflictsStatus() fyne.CanvasObject {
+	n := len(a.vault.Conflicts)
+	if n == 0 {
+		return nil
+	}
+	text := "1 conflict to review"
+	if n > 1 {
+		text = fmt.Sprintf("%d conflicts to review", n)
+	}
+	btn := widget.NewButtonWithIcon(text, theme.WarningIcon(), func() {
+		a.showConflictsView()
+	})
+	btn.Importance = widget.WarningImportance
+	return btn
+}
+
+// describeConflict returns a description of the discarded change
+func describeConflict(c *paw.Conflict) string {
+	switch {
+	case c.Purged:
+		return "Deleted from the trash"
+	case !c.Deleted.IsZero():
+		return fmt.Sprintf("Deleted %s", c.Deleted.Local().Format("2006-01-02 15:04"))
+	}
+	return fmt.Sprintf("Modified %s", c.Modified.Local().Format("2006-01-02 15:04"))
+}
@@ -14,11 +14,12 @@ import (
 	"fyne.io/fyne/v2/container"
 	"fyne.io/fyne/v2/dialog"
 	"fyne.io/fyne/v2/widget"
+
+	"lucor.dev/paw/internal/paw"
 )
 
 func (a *app) makeEditItemView(fyneItemWidget FyneItemWidget) fyne.CanvasObject {
 	item := fyneItemWidget.Item()
-	itemID := item.ID()
 	isNew := item.GetMetadata().IsEmpty()
 
 	itemEditWidget := newItemEditWidget(context.TODO(), a.vault.Key(), fyneItemWidget, a.win)
@@ -38,10 +39,21 @@ func (a *app) makeEditItemView(fyneItemWidget FyneItemWidget) fyne.CanvasObject
 			dialog.ShowError(fErr, a.win)
 			return
 		}
+		customFields, err := itemEditWidget.CustomFields()
+		if err != nil {
+			dialog.ShowError(err, a.win)
+			return
+		}
+		if v, ok := editItem.(paw.CustomFielder); ok {
+			*v.GetCustomFields() = customFields
+		}
+
 		updatedTime := time.Now().UTC()
 		updatedMetadata := editItem.GetMetadata()
+		updatedMetadata.Tags = itemEditWidget.Tags()
+		updatedMetadata.Folder = itemEditWidget.Folder()
 
-		if itemID != editItem.ID() && a.vault.HasItem(editItem) {
+		if other, ok := a.vault.ItemMetadataByName(updatedMetadata.Type, updatedMetadata.Name); ok && other.ID() != editItem.ID() {
 			msg := fmt.Sprintf("A %s item with the name %q already exists", updatedMetadata.Type.String(), updatedMetadata.Name)
 			d := dialog.NewInformation("", msg, a.win)
 			d.Show()
@@ -52,7 +64,18 @@ func (a *app) makeEditItemView(fyneItemWidget FyneItemWidget) fyne.CanvasObject
 			updatedMetadata.Modified = updatedTime
 		}
 
+		// keep track of the replaced password
+		paw.TrackPasswordHistory(item, editItem, updatedTime)
+
+		unlock, err := paw.LockVault(a.storage, a.vault)
+		if err != nil {
+			dialog.ShowError(err, a.win)
+			return
+		}
+		defer unlock()
+
 		// add item to vault and store into the storage
+		// the item ID does not change on rename so the item is just updated
 		a.vault.AddItem(editItem)
 		err = a.storage.StoreItem(a.vault, editItem)
 		if err != nil {
@@ -63,17 +86,6 @@ func (a *app) makeEditItemView(fyneItemWidget FyneItemWidget) fyne.CanvasObject
 		// make sure key is removed from SSH agent to honour user's preference
 		_ = a.removeSSHKeyFromAgent(item)
 
-		if itemID != editItem.ID() {
-			if !isNew {
-				// item ID is changed, delete the old one
-				a.vault.DeleteItem(item)
-				err := a.storage.DeleteItem(a.vault, item)
-				if err != nil {
-					log.Printf("item rename: could not remove old item from storage: %s", err)
-				}
-			}
-		}
-
 		err = a.addSSHKeyToAgent(editItem)
 		if err != nil {
 			log.Println(err)
@@ -94,8 +106,7 @@ func (a *app) makeEditItemView(fyneItemWidget FyneItemWidget) fyne.CanvasObject
 		}
 
 		a.refreshCurrentView()
-		fyneItemWidget := NewFyneItemWidget(editItem, a.state.Preferences)
-		a.showItemView(fyneItemWidget)
+		a.showItemView(a.newFyneItemWidget(a.vault, editItem))
 	}
 
 	// elements should not be displayed on create but only on edit
@@ -105,8 +116,8 @@ func (a *app) makeEditItemView(fyneItemWidget FyneItemWidget) fyne.CanvasObject
 
 		d := dialog.NewCustomConfirm("", "Delete", "Cancel", msg, func(b bool) {
 			if b {
-				a.vault.DeleteItem(editItem)
-				err := a.storage.DeleteItem(a.vault, editItem)
+				// the item is moved to the trash and can be restored from the "Recently Deleted" view
+				err := paw.MoveItemToTrash(a.storage, a.vault, editItem)
 				if err != nil {
 					dialog.ShowError(err, a.win)
 					return
@@ -118,13 +129,6 @@ func (a *app) makeEditItemView(fyneItemWidget FyneItemWidget) fyne.CanvasObject
 
 				now := time.Now().UTC()
 
-				a.vault.Modified = now
-				err = a.storage.StoreVault(a.vault)
-				if err != nil {
-					dialog.ShowError(err, a.win)
-					return
-				}
-
 				a.state.Modified = now
 				err = a.storage.StoreAppState(a.state)
 				if err != nil {
@@ -5,9 +5,9 @@ package ui
 
 import (
 	"context"
-	"encoding/json"
 	"errors"
 	"fmt"
+	"sync"
 	"sync/atomic"
 	"time"
 
@@ -18,132 +18,245 @@ import (
 	"fyne.io/fyne/v2/widget"
 	"golang.org/x/sync/errgroup"
 	"golang.org/x/sync/semaphore"
+	"lucor.dev/paw/internal/importer"
 	"lucor.dev/paw/internal/paw"
 )
 
+// importFromFile shows the dialog to select the import format and the conflict strategy
 func (a *app) importFromFile() {
+	formats := make([]string, len(importer.Formats))
+	for i, f := range importer.Formats {
+		formats[i] = f.Label()
+	}
+	format := widget.NewSelect(formats, nil)
+	format.SetSelectedIndex(0)
+
+	onConflict := widget.NewSelect([]string{
+		string(paw.OnConflictSkip),
+		string(paw.OnConflictOverwrite),
+		string(paw.OnConflictRename),
+	}, nil)
+	onConflict.SetSelected(string(paw.OnConflictSkip))
+
+	items := []*widget.FormItem{
+		widget.NewFormItem("Format", format),
+		widget.NewFormItem("On conflict", onConflict),
+	}
+	d := dialog.NewForm("Import From File", "Choose File", "Cancel", items, func(b bool) {
+		if !b {
+			return
+		}
+		a.chooseImportFile(importer.Formats[format.SelectedIndex()], paw.OnConflict(onConflict.Selected))
+	}, a.win)
+	d.Resize(fyne.NewSize(400, d.MinSize().Height))
+	d.Show()
+}
+
+// chooseImportFile shows the file open dialog and reports the import actions for the selected file
+func (a *app) chooseImportFile(format importer.Format, onConflict paw.OnConflict) {
 	d := dialog.NewFileOpen(func(uc fyne.URIReadCloser, e error) {
 		if e != nil {
 			dialog.NewError(e, a.win).Show()
 			return
 		}
-		ctx, cancel := context.WithCancel(context.Background())
+		if uc == nil {
+			// file open dialog has been cancelled
+			return
+		}
+		defer uc.Close()
+
+		imported, err := importer.Import(format, uc)
+		if err != nil {
+			dialog.ShowError(err, a.win)
+			return
+		}
+		a.showImportReport(imported.Resolve(a.vault, onConflict))
+	}, a.win)
+	d.Show()
+}
+
+// showImportReport shows the actions that will be performed and asks confirmation before importing
+func (a *app) showImportReport(entries []paw.ImportEntry) {
+	counters := map[paw.ImportAction]int{}
+	for _, entry := range entries {
+		counters[entry.Action]++
+	}
+	summary := widget.NewLabel(fmt.Sprintf("%d to create, %d to overwrite, %d to rename, %d to skip",
+		counters[paw.ImportCreate],
+		counters[paw.ImportOverwrite],
+		counters[paw.ImportRename],
+		counters[paw.ImportSkip],
+	))
 
-		data := paw.Imported{}
-		var counter uint32
+	list := widget.NewList(
+		func() int {
+			return len(entries)
+		},
+		func() fyne.CanvasObject {
+			return container.NewBorder(nil, nil, widget.NewLabel("overwrite"), nil, widget.NewLabel("item"))
+		},
+		func(lii widget.ListItemID, co fyne.CanvasObject) {
+			entry := entries[lii]
+			meta := entry.Item.GetMetadata()
+			text := fmt.Sprintf("%s/%s", meta.Type, meta.Name)
+			if entry.Action == paw.ImportRename {
+				text = fmt.Sprintf("%s/%s → %s", meta.Type, entry.Name, meta.Name)
+			}
+			c := co.(*fyne.Container)
+			c.Objects[0].(*widget.Label).SetText(text)
+			c.Objects[1].(*widget.Label).SetText(string(entry.Action))
+		},
+	)
 
-		modalTitle := widget.NewLabel("Importing items...")
+	content := container.NewBorder(summary, nil, nil, nil, list)
+	d := dialog.NewCustomConfirm("Import Report", "Import", "Cancel", content, func(b bool) {
+		if !b {
+			return
+		}
+		a.importEntries(entries)
+	}, a.win)
+	d.Resize(fyne.NewSize(500, 400))
+	d.Show()
+}
+
+// importEntries stores the import entries into the vault showing the progress
+func (a *app) importEntries(entries []paw.ImportEntry) {
+	ctx, cancel := context.WithCancel(context.Background())
 
-		progressBind := binding.NewFloat()
-		progressbar := widget.NewProgressBarWithData(progressBind)
-		progressbar.TextFormatter = func() string {
-			v, _ := progressBind.Get()
-			return fmt.Sprintf("%.0f of %d", v, len(data.Items))
+	toImport := []paw.ImportEntry{}
+	for _, entry := range entries {
+		if entry.Action != paw.ImportSkip {
+			toImport = append(toImport, entry)
 		}
+	}
+	if len(toImport) == 0 {
+		cancel()
+		dialog.ShowInformation("Import From File", "No items to import", a.win)
+		return
+	}
 
-		var cancelButton *widget.Button
-		cancelButton = widget.NewButton("Cancel", func() {
-			modalTitle.SetText("Cancelling import, please wait...")
-			progressbar.Hide()
-			cancelButton.Disable()
-			cancel()
-		})
+	var counter uint32
 
-		c := container.NewBorder(modalTitle, nil, nil, nil, container.NewCenter(container.NewVBox(progressbar, cancelButton)))
-		modal := widget.NewModalPopUp(c, a.win.Canvas())
+	modalTitle := widget.NewLabel("Importing items...")
 
-		rollback := func(items []paw.Item) {
-			for _, item := range items {
-				a.storage.DeleteItem(a.vault, item)
-				a.vault.DeleteItem(item)
-				a.removeSSHKeyFromAgent(item)
-			}
+	progressBind := binding.NewFloat()
+	progressbar := widget.NewProgressBarWithData(progressBind)
+	progressbar.TextFormatter = func() string {
+		v, _ := progressBind.Get()
+		return fmt.Sprintf("%.0f of %d", v, len(toImport))
+	}
+
+	var cancelButton *widget.Button
+	cancelButton = widget.NewButton("Cancel", func() {
+		modalTitle.SetText("Cancelling import, please wait...")
+		progressbar.Hide()
+		cancelButton.Disable()
+		cancel()
+	})
+
+	c := container.NewBorder(modalTitle, nil, nil, nil, container.NewCenter(container.NewVBox(progressbar, cancelButton)))
+	modal := widget.NewModalPopUp(c, a.win.Canvas())
+
+	var mu sync.Mutex
+	// processed holds the stored items, previous the overwritten ones
+	processed := []paw.Item{}
+	previous := []paw.Item{}
+
+	rollback := func() {
+		for _, item := range processed {
+			a.storage.DeleteItem(a.vault, item)
+			a.vault.DeleteItem(item)
 		}
+		for _, item := range previous {
+			a.storage.StoreItem(a.vault, item)
+			a.vault.AddItem(item)
+		}
+	}
 
-		go func() {
-			if uc == nil {
-				// file open dialog has been cancelled
-				modal.Hide()
-				return
-			}
-			defer uc.Close()
-			// Decode the JSON input file
-			err := json.NewDecoder(uc).Decode(&data)
-			if err != nil {
+	go func() {
+		defer cancel()
+		defer func() {
+			fyne.Do(func() {
 				modal.Hide()
-				dialog.ShowError(err, a.win)
-				return
-			}
+			})
+		}()
 
-			sem := semaphore.NewWeighted(int64(maxWorkers))
-			g := &errgroup.Group{}
+		sem := semaphore.NewWeighted(int64(maxWorkers))
+		g := &errgroup.Group{}
 
-			processed := []paw.Item{}
-			// TODO: handle if an item with same name and type already exists
-			for _, item := range data.Items {
-				item := item
+		for _, entry := range toImport {
+			entry := entry
 
-				err = sem.Acquire(ctx, 1)
+			err := sem.Acquire(ctx, 1)
+			if err != nil {
+				cancel()
+				break
+			}
+
+			g.Go(func() error {
+				defer sem.Release(1)
+				item := entry.Item
+				var old paw.Item
+				if entry.Action == paw.ImportOverwrite {
+					old, _ = a.storage.LoadItem(a.vault, item.GetMetadata())
+				}
+				err := a.storage.StoreItem(a.vault, item)
 				if err != nil {
-					cancel()
-					break
+					return fmt.Errorf("could not import item %q: %w", entry.Name, err)
 				}
-
-				g.Go(func() error {
-					defer sem.Release(1)
-					err := a.storage.StoreItem(a.vault, item)
-					if err != nil {
-						return err
-					}
+				mu.Lock()
+				if old != nil {
+					previous = append(previous, old)
+				} else {
 					processed = append(processed, item)
-					v := atomic.AddUint32(&counter, 1)
-					fyne.Do(func() {
-						progressBind.Set(float64(v))
-					})
-					return nil
-				})
-			}
-
-			defer func() {
+				}
+				mu.Unlock()
+				v := atomic.AddUint32(&counter, 1)
 				fyne.Do(func() {
-					modal.Hide()
+					progressBind.Set(float64(v))
 				})
-			}()
-			err = g.Wait()
-			if err != nil || errors.Is(ctx.Err(), context.Canceled) {
-				rollback(processed)
-				if err != nil {
-					fyne.Do(func() {
-						dialog.ShowError(err, a.win)
-					})
-				}
-				return
-			}
+				return nil
+			})
+		}
 
-			for _, item := range processed {
-				a.vault.AddItem(item)
-				a.addSSHKeyToAgent(item)
-			}
-			now := time.Now().UTC()
-			a.vault.Modified = now
-			err = a.storage.StoreVault(a.vault)
+		err := g.Wait()
+		if err != nil || errors.Is(ctx.Err(), context.Canceled) {
+			rollback()
 			if err != nil {
-				rollback(processed)
 				fyne.Do(func() {
 					dialog.ShowError(err, a.win)
 				})
-				return
 			}
-			a.state.Modified = now
-			a.storage.StoreAppState(a.state)
+			return
+		}
+
+		for _, item := range previous {
+			a.removeSSHKeyFromAgent(item)
+		}
+		for _, entry := range toImport {
+			a.vault.AddItem(entry.Item)
+			a.addSSHKeyToAgent(entry.Item)
+		}
+		now := time.Now().UTC()
+		a.vault.Modified = now
+		err = a.storage.StoreVault(a.vault)
+		if err != nil {
+			for _, entry := range toImport {
+				a.removeSSHKeyFromAgent(entry.Item)
+			}
+			rollback()
 			fyne.Do(func() {
-				a.refreshCurrentView()
-				a.showCurrentVaultView()
+				dialog.ShowError(err, a.win)
 			})
-		}()
-
-		modal.Show()
+			return
+		}
+		a.state.Modified = now
+		a.storage.StoreAppState(a.state)
+		fyne.Do(func() {
+			a.refreshCurrentView()
+			a.showCurrentVaultView()
+		})
+	}()
 
-	}, a.win)
-	d.Show()
+	modal.Show()
 }
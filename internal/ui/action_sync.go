@@ -0,0 +1,122 @@
+// SPDX-FileCopyrightText: 2025 Luca Corbo, Paw contributors
+// SPDX-License-Identifier: AGPL-3.0-or-later
+
+package ui
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"fyne.io/fyne/v2"
+	"fyne.io/fyne/v2/dialog"
+	"fyne.io/fyne/v2/theme"
+	"fyne.io/fyne/v2/widget"
+
+	"lucor.dev/paw/internal/paw"
+)
+
+// configureSync shows the dialog to set the remote of the current vault and synchronise it
+func (a *app) configureSync() {
+	remote := widget.NewEntry()
+	remote.SetPlaceHolder("/path/to/dir or https://dav.example.com/paw")
+	if state := a.state.Sync[a.vault.Name]; state != nil {
+		remote.SetText(state.Remote)
+	}
+	remote.Validator = func(s string) error {
+		if s == "" {
+			return newValidatioError("The remote is required")
+		}
+		if _, err := paw.NewRemote(s); err != nil {
+			return newValidatioError("Expected a directory path, a file or an http(s) URL")
+		}
+		return nil
+	}
+
+	msg := widget.NewLabel("The vault files are transferred encrypted.\nThe password for an HTTP remote can be set using the PAW_SYNC_PASSWORD env var.")
+	items := []*widget.FormItem{
+		widget.NewFormItem("", msg),
+		widget.NewFormItem("Remote", remote),
+	}
+
+	d := dialog.NewForm("Synchronise", "Synchronise", "Cancel", items, func(b bool) {
+		if !b {
+			return
+		}
+		a.syncVault(remote.Text)
+	}, a.win)
+	d.Resize(fyne.NewSize(480, d.MinSize().Height))
+	d.Show()
+}
+
+// syncVault synchronises the current vault with the remote
+func (a *app) syncVault(location string) {
+	vault := a.vault
+	remote, err := paw.NewRemote(location)
+	if err != nil {
+		dialog.ShowError(err, a.win)
+		return
+	}
+
+	act := NewActivity()
+	modal := dialog.NewCustomWithoutButtons("synchronising vault...", act, a.win)
+	act.Start()
+	modal.Show()
+
+	go func() {
+		res, err := paw.SyncVault(context.Background(), a.storage, vault, remote)
+		fyne.Do(func() {
+			act.Stop()
+			modal.Hide()
+
+			state := &paw.SyncState{Remote: remote.String()}
+			if prev := a.state.Sync[vault.Name]; prev != nil {
+				state.Synced = prev.Synced
+			}
+			if err != nil {
+				state.Error = err.Error()
+			} else {
+				state.Synced = res.Synced
+			}
+			if a.state.Sync == nil {
+				a.state.Sync = make(map[string]*paw.SyncState)
+			}
+			a.state.Sync[vault.Name] = state
+			a.state.Modified = time.Now().UTC()
+			if serr := a.storage.StoreAppState(a.state); serr != nil && err == nil {
+				err = serr
+			}
+
+			a.refreshCurrentView()
+			if err != nil {
+				dialog.ShowError(err, a.win)
+			}
+		})
+	}()
+}
+
+// makeSyncStatus returns a button reporting the synchronisation status of the
+// current vault that synchronises it again once tapped.
+// It returns nil if the vault has never been synchronised.
+func (a *app) makeSyncStatus() fyne.CanvasObject {
+	state := a.state.Sync[a.vault.Name]
+	if state == nil || state.Remote == "" {
+		return nil
+	}
+
+	text := "Not synchronised"
+	resource := theme.ViewRefreshIcon()
+	switch {
+	case state.Error != "":
+		text = "Synchronisation failed"
+		resource = theme.ErrorIcon()
+	case !state.Synced.IsZero():
+		text = fmt.Sprintf("Synchronised %s", state.Synced.Local().Format("2006-01-02 15:04"))
+	}
+
+	btn := widget.NewButtonWithIcon(text, resource, func() {
+		a.syncVault(state.Remote)
+	})
+	btn.Importance = widget.LowImportance
+	return btn
+}
@@ -0,0 +1,115 @@
+// SPDX-FileCopyrightText: 2025 Luca Corbo, Paw contributors
+// SPDX-License-Identifier: AGPL-3.0-or-later
+
+package ui
+
+import (
+	"fmt"
+	"time"
+
+	"fyne.io/fyne/v2"
+	"fyne.io/fyne/v2/container"
+	"fyne.io/fyne/v2/dialog"
+	"fyne.io/fyne/v2/layout"
+	"fyne.io/fyne/v2/theme"
+	"fyne.io/fyne/v2/widget"
+
+	"lucor.dev/paw/internal/paw"
+)
+
+// makeTrashView returns a view to restore or purge the items moved to the trash
+func (a *app) makeTrashView() fyne.CanvasObject {
+	retention := a.state.Preferences.Trash.Retention()
+	tombstones := a.vault.TrashedItems()
+
+	heading := headingText("Recently Deleted")
+	text := widget.NewLabel(fmt.Sprintf("The deleted items are kept for %d days before being permanently deleted", int(retention.Hours()/24)))
+	text.Wrapping = fyne.TextWrapWord
+
+	emptyBtn := widget.NewButtonWithIcon("Empty Trash", theme.DeleteIcon(), func() {
+		msg := widget.NewLabel(fmt.Sprintf("Are you sure you want to delete permanently all the %d items?", len(tombstones)))
+		d := dialog.NewCustomConfirm("", "Delete", "Cancel", msg, func(b bool) {
+			if !b {
+				return
+			}
+			for _, tombstone := range tombstones {
+				err := paw.PurgeItemFromTrash(a.storage, a.vault, tombstone.ID())
+				if err != nil {
+					dialog.ShowError(err, a.win)
+					break
+				}
+			}
+			a.storeTrashChanges()
+			a.showTrashView()
+		}, a.win)
+		d.SetConfirmImportance(widget.DangerImportance)
+		d.Show()
+	})
+	emptyBtn.Importance = widget.DangerImportance
+
+	list := container.NewVBox()
+	if len(tombstones) == 0 {
+		emptyBtn.Disable()
+		list.Add(widget.NewLabel("No recently deleted items"))
+	}
+	for _, tombstone := range tombstones {
+		tombstone := tombstone
+
+		restoreBtn := widget.NewButtonWithIcon("", theme.HistoryIcon(), func() {
+			_, err := paw.RestoreItemFromTrash(a.storage, a.vault, tombstone.ID())
+			if err != nil {
+				dialog.ShowError(err, a.win)
+				return
+			}
+			a.storeTrashChanges()
+			a.refreshCurrentView()
+			a.showTrashView()
+		})
+		purgeBtn := widget.NewButtonWithIcon("", theme.DeleteIcon(), func() {
+			msg := widget.NewLabel(fmt.Sprintf("Are you sure you want to delete permanently %q?", tombstone.Name))
+			d := dialog.NewCustomConfirm("", "Delete", "Cancel", msg, func(b bool) {
+				if !b {
+					return
+				}
+				err := paw.PurgeItemFromTrash(a.storage, a.vault, tombstone.ID())
+				if err != nil {
+					dialog.ShowError(err, a.win)
+					return
+				}
+				a.storeTrashChanges()
+				a.showTrashView()
+			}, a.win)
+			d.SetConfirmImportance(widget.DangerImportance)
+			d.Show()
+		})
+
+		name := widget.NewLabelWithStyle(tombstone.Name, fyne.TextAlignLeading, fyne.TextStyle{Bold: true})
+		deleted := widget.NewLabel(fmt.Sprintf("Deleted %s", tombstone.Deleted.Local().Format("2006-01-02 15:04")))
+		list.Add(container.NewBorder(
+			nil, nil,
+			widget.NewIcon((&Metadata{Metadata: tombstone.Metadata}).Icon()),
+			container.NewHBox(restoreBtn, purgeBtn),
+			container.New(layout.NewGridLayout(2), name, deleted),
+		))
+	}
+
+	top := container.NewVBox(a.makeCancelHeaderButton(), heading, text, container.NewHBox(layout.NewSpacer(), emptyBtn))
+	return container.NewBorder(top, nil, nil, nil, container.NewVScroll(list))
+}
+
+// storeTrashChanges updates the app state modification time after a trash operation
+func (a *app) storeTrashChanges() {
+	a.state.Modified = time.Now().UTC()
+	err := a.storage.StoreAppState(a.state)
+	if err != nil {
+		dialog.ShowError(err, a.win)
+	}
+}
+
+// purgeExpiredTrash deletes permanently the items moved to the trash before the retention period
+func (a *app) purgeExpiredTrash(vault *paw.Vault) {
+	_, err := paw.PurgeExpiredTrash(a.storage, vault, a.state.Preferences.Trash.Retention(), time.Now().UTC())
+	if err != nil {
+		fyne.LogError("could not purge the expired items from the trash", err)
+	}
+}
@@ -0,0 +1,123 @@
+// SPDX-FileCopyrightText: 2025 Luca Corbo, Paw contributors
+// SPDX-License-Identifier: AGPL-3.0-or-later
+
+package ui
+
+import (
+	"errors"
+	"fmt"
+	"log"
+	"time"
+
+	"filippo.io/age"
+	"fyne.io/fyne/v2"
+	"fyne.io/fyne/v2/dialog"
+	"fyne.io/fyne/v2/widget"
+
+	"lucor.dev/paw/internal/paw"
+)
+
+// changeVaultPassword shows the dialog to change the password of the current vault
+func (a *app) changeVaultPassword() {
+	vaultName := a.vault.Name
+
+	currentPassword := widget.NewPasswordEntry()
+	currentPassword.Validator = requiredValidator("The current password is required")
+	newPassword := widget.NewPasswordEntry()
+	newPassword.Validator = requiredValidator("The new password cannot be empty")
+	confirmPassword := widget.NewPasswordEntry()
+	confirmPassword.Validator = func(s string) error {
+		if s != newPassword.Text {
+			return newValidatioError("Passwords do not match")
+		}
+		return nil
+	}
+
+	items := []*widget.FormItem{
+		widget.NewFormItem("Current password", currentPassword),
+		widget.NewFormItem("New password", newPassword),
+		widget.NewFormItem("Confirm password", confirmPassword),
+	}
+
+	d := dialog.NewForm("Change Password", "Change", "Cancel", items, func(b bool) {
+		if !b {
+			return
+		}
+		err := paw.ChangeVaultPassword(a.storage, vaultName, currentPassword.Text, newPassword.Text)
+		if err != nil {
+			dialog.ShowError(unlockError(err), a.win)
+			return
+		}
+		dialog.ShowInformation("Change Password", fmt.Sprintf("The password for vault %q has been changed", vaultName), a.win)
+	}, a.win)
+	d.Resize(fyne.NewSize(400, d.MinSize().Height))
+	d.Show()
+}
+
+// rotateVaultKey shows the dialog to rotate the key of the current vault
+func (a *app) rotateVaultKey() {
+	vault := a.vault
+
+	password := widget.NewPasswordEntry()
+	password.Validator = requiredValidator("The password is required")
+
+	text := "A new key will be generated and all the items re-encrypted.\nThe vault password does not change."
+	if paw.IsVaultGitRepository(a.storage, vault.Name) {
+		text += "\nThe previous key is kept into the git history of the vault."
+	}
+	msg := widget.NewLabel(text)
+	items := []*widget.FormItem{
+		widget.NewFormItem("", msg),
+		widget.NewFormItem("Password", password),
+	}
+
+	d := dialog.NewForm("Rotate Key", "Rotate", "Cancel", items, func(b bool) {
+		if !b {
+			return
+		}
+
+		act := NewActivity()
+		modal := dialog.NewCustomWithoutButtons("rotating vault key...", act, a.win)
+		act.Start()
+		modal.Show()
+
+		go func() {
+			rotated, err := paw.RotateVaultKey(a.storage, vault, password.Text)
+			fyne.Do(func() {
+				act.Stop()
+				modal.Hide()
+				if err != nil {
+					dialog.ShowError(unlockError(err), a.win)
+					return
+				}
+
+				// sessions hold the previous key, lock them
+				if c := a.agentClient(); c != nil {
+					if err := c.Lock(vault.Name); err != nil {
+						log.Println("could not lock the vault sessions:", err)
+					}
+				}
+
+				a.state.Modified = time.Now().UTC()
+				err = a.storage.StoreAppState(a.state)
+				if err != nil {
+					dialog.ShowError(err, a.win)
+				}
+
+				a.setVaultView(rotated)
+				dialog.ShowInformation("Rotate Key", fmt.Sprintf("The key for vault %q has been rotated", vault.Name), a.win)
+			})
+		}()
+	}, a.win)
+	d.Resize(fyne.NewSize(400, d.MinSize().Height))
+	d.Show()
+}
+
+// unlockError returns a user friendly error when the vault password is incorrect
+func unlockError(err error) error {
+	var invalidPasswordError *age.NoIdentityMatchError
+	if errors.As(err, &invalidPasswordError) {
+		return errors.New("the password is incorrect")
+	}
+	return err
+}
@@ -12,6 +12,7 @@ import (
 
 func (a *app) makeShowItemView(fyneItemWidget FyneItemWidget) fyne.CanvasObject {
 	itemViewWidget := newItemViewWidget(context.TODO(), fyneItemWidget, a.win)
+	itemViewWidget.attachments = a.makeAttachmentsView(a.vault, fyneItemWidget.Item())
 	itemViewWidget.OnSubmit = func() {
 		a.showEditItemView(fyneItemWidget)
 	}
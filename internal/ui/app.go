@@ -20,6 +20,7 @@ import (
 	"lucor.dev/paw/internal/agent"
 	"lucor.dev/paw/internal/icon"
 	"lucor.dev/paw/internal/paw"
+	"lucor.dev/paw/internal/secretservice"
 	"lucor.dev/paw/internal/sshkey"
 )
 
@@ -47,6 +48,9 @@ type app struct {
 
 	// Paw agent client
 	client agent.PawAgent
+
+	// secretService exposes the unlocked vaults using the Secret Service API, if enabled
+	secretService *secretservice.Service
 }
 
 func MakeApp(s paw.Storage, w fyne.Window) fyne.CanvasObject {
@@ -68,6 +72,12 @@ func MakeApp(s paw.Storage, w fyne.Window) fyne.CanvasObject {
 	a.main = a.makeApp()
 	a.makeSysTray()
 
+	if a.state.Preferences.SecretService.Enabled {
+		if err := a.startSecretService(); err != nil {
+			log.Println("secret service not available:", err)
+		}
+	}
+
 	return a.main
 }
 
@@ -177,8 +187,16 @@ func (a *app) addSSHKeysToAgent() {
 }
 
 func (a *app) setVaultView(vault *paw.Vault) {
+	if _, ok := a.unlockedVault[vault.Name]; !ok {
+		a.purgeExpiredTrash(vault)
+	}
 	a.vault = vault
 	a.unlockedVault[vault.Name] = vault
+	if a.secretService != nil {
+		if err := a.secretService.AddVault(vault); err != nil {
+			log.Println("secret service:", err)
+		}
+	}
 	a.main.Content = a.makeCurrentVaultView()
 	a.main.Refresh()
 	a.setWindowTitle()
@@ -188,6 +206,14 @@ func (a *app) showAuditPasswordView() {
 	a.win.SetContent(a.makeAuditPasswordView())
 }
 
+func (a *app) showTrashView() {
+	a.win.SetContent(a.makeTrashView())
+}
+
+func (a *app) showConflictsView() {
+	a.win.SetContent(a.makeConflictsView())
+}
+
 func (a *app) showCreateVaultView() {
 	a.win.SetContent(a.makeCreateVaultView())
 }
@@ -222,6 +248,9 @@ func (a *app) showPreferencesView() {
 
 func (a *app) lockVault() {
 	delete(a.unlockedVault, a.vault.Name)
+	if a.secretService != nil {
+		a.secretService.RemoveVault(a.vault.Name)
+	}
 	a.vault = nil
 }
 
@@ -0,0 +1,26 @@
+// SPDX-FileCopyrightText: 2025 Luca Corbo, Paw contributors
+// SPDX-License-Identifier: AGPL-3.0-or-later
+
+//go:build (darwin || linux || windows) && !android && !ios
+
+package ui
+
+import (
+	"errors"
+
+	"golang.design/x/clipboard"
+)
+
+// readImageFromClipboard returns the PNG encoded image from the clipboard.
+// The Fyne clipboard supports only text content.
+func readImageFromClipboard() ([]byte, error) {
+	err := clipboard.Init()
+	if err != nil {
+		return nil, err
+	}
+	b := clipboard.Read(clipboard.FmtImage)
+	if b == nil {
+		return nil, errors.New("clipboard does not contain an image")
+	}
+	return b, nil
+}
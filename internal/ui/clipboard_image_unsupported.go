@@ -0,0 +1,16 @@
+// SPDX-FileCopyrightText: 2025 Luca Corbo, Paw contributors
+// SPDX-License-Identifier: AGPL-3.0-or-later
+
+//go:build !(darwin || linux || windows) || android || ios
+
+package ui
+
+import (
+	"errors"
+)
+
+// readImageFromClipboard returns the PNG encoded image from the clipboard.
+// The Fyne clipboard supports only text content.
+func readImageFromClipboard() ([]byte, error) {
+	return nil, errors.New("reading images from the clipboard is not supported on this OS")
+}
@@ -0,0 +1,124 @@
+// SPDX-FileCopyrightText: 2025 Luca Corbo, Paw contributors
+// SPDX-License-Identifier: AGPL-3.0-or-later
+
+package ui
+
+import (
+	"fmt"
+	"io"
+	"time"
+
+	"fyne.io/fyne/v2"
+	"fyne.io/fyne/v2/container"
+	"fyne.io/fyne/v2/dialog"
+	"fyne.io/fyne/v2/theme"
+	"fyne.io/fyne/v2/widget"
+
+	"lucor.dev/paw/internal/paw"
+)
+
+// makeAttachmentsView returns the view to list, save, add and remove the item attachments
+func (a *app) makeAttachmentsView(vault *paw.Vault, item paw.Item) fyne.CanvasObject {
+	list := container.NewVBox()
+	for _, attachment := range item.GetMetadata().Attachments {
+		attachment := attachment
+
+		saveBtn := widget.NewButtonWithIcon("", theme.DocumentSaveIcon(), func() {
+			a.saveAttachment(vault, item, attachment)
+		})
+		removeBtn := widget.NewButtonWithIcon("", theme.DeleteIcon(), func() {
+			msg := widget.NewLabel(fmt.Sprintf("Are you sure you want to delete %q?", attachment.Name))
+			d := dialog.NewCustomConfirm("", "Delete", "Cancel", msg, func(b bool) {
+				if !b {
+					return
+				}
+				err := paw.RemoveAttachment(a.storage, vault, item, attachment.Name)
+				if err != nil {
+					dialog.ShowError(err, a.win)
+					return
+				}
+				a.storeAttachmentChanges(vault, item)
+			}, a.win)
+			d.SetConfirmImportance(widget.DangerImportance)
+			d.Show()
+		})
+		label := widget.NewLabel(fmt.Sprintf("%s (%s)", attachment.Name, formatSize(attachment.Size)))
+		label.Truncation = fyne.TextTruncateEllipsis
+		list.Add(container.NewBorder(nil, nil, nil, container.NewHBox(saveBtn, removeBtn), label))
+	}
+
+	addBtn := widget.NewButtonWithIcon("Add attachment", theme.ContentAddIcon(), func() {
+		d := dialog.NewFileOpen(func(uc fyne.URIReadCloser, err error) {
+			if err != nil {
+				dialog.ShowError(err, a.win)
+				return
+			}
+			if uc == nil {
+				// file open dialog has been cancelled
+				return
+			}
+			defer uc.Close()
+			_, err = paw.AddAttachment(a.storage, vault, item, uc.URI().Name(), uc)
+			if err != nil {
+				dialog.ShowError(err, a.win)
+				return
+			}
+			a.storeAttachmentChanges(vault, item)
+		}, a.win)
+		d.Show()
+	})
+
+	return container.NewVBox(labelWithStyle("Attachments"), list, container.NewHBox(addBtn))
+}
+
+// saveAttachment asks for the destination file and writes the decrypted attachment content
+func (a *app) saveAttachment(vault *paw.Vault, item paw.Item, attachment *paw.Attachment) {
+	d := dialog.NewFileSave(func(uc fyne.URIWriteCloser, err error) {
+		if err != nil {
+			dialog.ShowError(err, a.win)
+			return
+		}
+		if uc == nil {
+			// file save dialog has been cancelled
+			return
+		}
+		defer uc.Close()
+		r, _, err := paw.OpenAttachment(a.storage, vault, item, attachment.Name)
+		if err != nil {
+			dialog.ShowError(err, a.win)
+			return
+		}
+		defer r.Close()
+		_, err = io.Copy(uc, r)
+		if err != nil {
+			dialog.ShowError(err, a.win)
+		}
+	}, a.win)
+	d.SetFileName(attachment.Name)
+	d.Show()
+}
+
+// storeAttachmentChanges updates the app state and shows the updated item
+func (a *app) storeAttachmentChanges(vault *paw.Vault, item paw.Item) {
+	a.state.Modified = time.Now().UTC()
+	err := a.storage.StoreAppState(a.state)
+	if err != nil {
+		dialog.ShowError(err, a.win)
+	}
+	a.refreshCurrentView()
+	a.showItemView(a.newFyneItemWidget(vault, item))
+}
+
+// formatSize returns the size in bytes in a human readable format
+func formatSize(size int64) string {
+	const unit = 1024
+	if size < unit {
+		return fmt.Sprintf("%d B", size)
+	}
+	div, exp := int64(unit), 0
+	for n := size / unit; n >= unit; n /= unit {
+		div *= unit
+		exp++
+	}
+	return fmt.Sprintf("%.1f %ciB", float64(size)/float64(div), "KMGTPE"[exp])
+}
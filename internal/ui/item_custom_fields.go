@@ -0,0 +1,148 @@
+// SPDX-FileCopyrightText: 2025 Luca Corbo, Paw contributors
+// SPDX-License-Identifier: AGPL-3.0-or-later
+
+package ui
+
+import (
+	"context"
+	"strings"
+
+	"fyne.io/fyne/v2"
+	"fyne.io/fyne/v2/container"
+	"fyne.io/fyne/v2/layout"
+	"fyne.io/fyne/v2/theme"
+	"fyne.io/fyne/v2/widget"
+
+	"lucor.dev/paw/internal/paw"
+)
+
+// showCustomFields returns the rows to view the custom fields of the item
+func showCustomFields(ctx context.Context, item paw.Item, w fyne.Window) fyne.CanvasObject {
+	v, ok := item.(paw.CustomFielder)
+	if !ok || len(*v.GetCustomFields()) == 0 {
+		return container.NewVBox()
+	}
+
+	obj := []fyne.CanvasObject{}
+	for _, f := range *v.GetCustomFields() {
+		switch f.Type {
+		case paw.TOTPCustomField:
+			totp, err := f.TOTP()
+			if err != nil {
+				obj = append(obj, rowWithAction(f.Name, err.Error(), rowActionOptions{}, w)...)
+				continue
+			}
+			row := (&TOTP{TOTP: totp, Issuer: item.GetMetadata().Name, Account: f.Name}).Show(ctx, w)
+			row[0] = labelWithStyle(f.Name)
+			obj = append(obj, row...)
+		case paw.HiddenCustomField:
+			obj = append(obj, rowWithAction(f.Name, f.Value, rowActionOptions{widgetType: "password", copy: true}, w)...)
+		case paw.URLCustomField:
+			obj = append(obj, rowWithAction(f.Name, f.Value, rowActionOptions{widgetType: "url", copy: true}, w)...)
+		default:
+			obj = append(obj, rowWithAction(f.Name, f.Value, rowActionOptions{copy: true}, w)...)
+		}
+	}
+	return container.New(layout.NewFormLayout(), obj...)
+}
+
+// customFieldsEditor is the editor of the item custom fields
+type customFieldsEditor struct {
+	rows *fyne.Container
+	// fields returns the fields for the rows, by row
+	fields map[fyne.CanvasObject]func() (*paw.CustomField, error)
+}
+
+// newCustomFieldsEditor returns the custom fields editor for the item.
+// Nil is returned if the item does not support the custom fields.
+func newCustomFieldsEditor(item paw.Item) *customFieldsEditor {
+	v, ok := item.(paw.CustomFielder)
+	if !ok {
+		return nil
+	}
+	e := &customFieldsEditor{
+		rows:   container.NewVBox(),
+		fields: map[fyne.CanvasObject]func() (*paw.CustomField, error){},
+	}
+	for _, f := range *v.GetCustomFields() {
+		e.addRow(f)
+	}
+	return e
+}
+
+// Content returns the editor content
+func (e *customFieldsEditor) Content() fyne.CanvasObject {
+	addBtn := widget.NewButtonWithIcon("Add field", theme.ContentAddIcon(), func() {
+		e.addRow(&paw.CustomField{Type: paw.TextCustomField})
+	})
+	return container.NewVBox(e.rows, container.NewHBox(addBtn))
+}
+
+// addRow adds a row to edit the field
+func (e *customFieldsEditor) addRow(f *paw.CustomField) {
+	nameEntry := widget.NewEntry()
+	nameEntry.SetPlaceHolder("Field name")
+	nameEntry.SetText(f.Name)
+
+	valueEntry := widget.NewEntry()
+	valueEntry.SetText(f.Value)
+
+	labels := []string{}
+	types := map[string]paw.CustomFieldType{}
+	for _, t := range paw.CustomFieldTypes() {
+		labels = append(labels, t.Label())
+		types[t.Label()] = t
+	}
+	fieldType := f.Type
+	typeSelect := widget.NewSelect(labels, func(s string) {
+		fieldType = types[s]
+		valueEntry.Password = fieldType.IsSecret()
+		switch fieldType {
+		case paw.DateCustomField:
+			valueEntry.SetPlaceHolder("YYYY-MM-DD")
+		case paw.TOTPCustomField:
+			valueEntry.SetPlaceHolder("2FA key or otpauth URI")
+		default:
+			valueEntry.SetPlaceHolder("Value")
+		}
+		valueEntry.Refresh()
+	})
+	typeSelect.SetSelected(fieldType.Label())
+
+	var row *fyne.Container
+	removeBtn := widget.NewButtonWithIcon("", theme.ContentRemoveIcon(), func() {
+		e.rows.Remove(row)
+		delete(e.fields, row)
+	})
+	row = container.NewBorder(nil, nil, typeSelect, removeBtn, container.NewGridWithColumns(2, nameEntry, valueEntry))
+	e.fields[row] = func() (*paw.CustomField, error) {
+		if strings.TrimSpace(nameEntry.Text) == "" && valueEntry.Text == "" {
+			return nil, nil
+		}
+		return paw.NewCustomField(nameEntry.Text, fieldType, valueEntry.Text)
+	}
+	e.rows.Add(row)
+}
+
+// CustomFields returns the custom fields validating the values.
+// Rows with empty name and value are ignored.
+func (e *customFieldsEditor) CustomFields() (paw.CustomFields, error) {
+	fields := paw.CustomFields{}
+	for _, row := range e.rows.Objects {
+		f, err := e.fields[row]()
+		if err != nil {
+			return nil, newValidatioError(err.Error())
+		}
+		if f == nil {
+			continue
+		}
+		if _, ok := fields.Get(f.Name); ok {
+			return nil, newValidatioError("The custom field " + f.Name + " is defined multiple times")
+		}
+		fields = append(fields, f)
+	}
+	if len(fields) == 0 {
+		return nil, nil
+	}
+	return fields, nil
+}
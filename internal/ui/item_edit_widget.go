@@ -5,9 +5,11 @@ package ui
 
 import (
 	"context"
+	"strings"
 
 	"fyne.io/fyne/v2"
 	"fyne.io/fyne/v2/container"
+	"fyne.io/fyne/v2/layout"
 	"fyne.io/fyne/v2/theme"
 	"fyne.io/fyne/v2/widget"
 	"lucor.dev/paw/internal/paw"
@@ -22,8 +24,12 @@ type itemEditWidget struct {
 	itemWidget FyneItemWidget
 	win        fyne.Window
 
-	deleteBtn *widget.Button
-	saveBtn   *widget.Button
+	deleteBtn   *widget.Button
+	saveBtn     *widget.Button
+	tagsEntry   *widget.Entry
+	folderEntry *widget.Entry
+
+	customFields *customFieldsEditor
 
 	OnDelete func()
 	OnSave   func()
@@ -39,14 +45,26 @@ func newItemEditWidget(ctx context.Context, key *paw.Key, itemWidget FyneItemWid
 		Icon:       theme.DeleteIcon(),
 		Importance: widget.DangerImportance,
 	}
+	metadata := itemWidget.Item().GetMetadata()
+	tagsEntry := widget.NewEntry()
+	tagsEntry.SetPlaceHolder("Tags, comma separated")
+	tagsEntry.SetText(strings.Join(metadata.Tags, ", "))
+	folderEntry := widget.NewEntry()
+	folderEntry.SetPlaceHolder("Folder, i.e. work/projects")
+	folderEntry.SetText(metadata.Folder)
+
 	iew := &itemEditWidget{
 		ctx:        ctx,
 		key:        key,
 		itemWidget: itemWidget,
 		win:        win,
 
-		deleteBtn: deleteBtn,
-		saveBtn:   saveBtn,
+		deleteBtn:   deleteBtn,
+		saveBtn:     saveBtn,
+		tagsEntry:   tagsEntry,
+		folderEntry: folderEntry,
+
+		customFields: newCustomFieldsEditor(itemWidget.Item()),
 	}
 	iew.ExtendBaseWidget(iew)
 	iew.deleteBtn.OnTapped = func() {
@@ -64,8 +82,35 @@ func (iew *itemEditWidget) CreateRenderer() fyne.WidgetRenderer {
 	if metadata.IsEmpty() {
 		iew.deleteBtn.Hide()
 	}
-	bottom := container.NewBorder(nil, nil, iew.deleteBtn, iew.saveBtn, widget.NewLabel(""))
+	organize := container.New(
+		layout.NewFormLayout(),
+		labelWithStyle("Tags"), iew.tagsEntry,
+		labelWithStyle("Folder"), iew.folderEntry,
+	)
+	buttons := container.NewBorder(nil, nil, iew.deleteBtn, iew.saveBtn, widget.NewLabel(""))
+	bottom := container.NewVBox(organize, buttons)
+	if iew.customFields != nil {
+		bottom = container.NewVBox(iew.customFields.Content(), organize, buttons)
+	}
 
 	c := container.NewBorder(nil, bottom, nil, nil, itemContent)
 	return widget.NewSimpleRenderer(c)
 }
+
+// Tags returns the normalized tags from the tags entry
+func (iew *itemEditWidget) Tags() []string {
+	return paw.ParseTags(iew.tagsEntry.Text)
+}
+
+// Folder returns the normalized folder from the folder entry
+func (iew *itemEditWidget) Folder() string {
+	return paw.NormalizeFolder(iew.folderEntry.Text)
+}
+
+// CustomFields returns the validated custom fields from the custom fields editor
+func (iew *itemEditWidget) CustomFields() (paw.CustomFields, error) {
+	if iew.customFields == nil {
+		return nil, nil
+	}
+	return iew.customFields.CustomFields()
+}
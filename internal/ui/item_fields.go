@@ -0,0 +1,135 @@
+// SPDX-FileCopyrightText: 2025 Luca Corbo, Paw contributors
+// SPDX-License-Identifier: AGPL-3.0-or-later
+
+package ui
+
+import (
+	"context"
+
+	"fyne.io/fyne/v2"
+	"fyne.io/fyne/v2/container"
+	"fyne.io/fyne/v2/data/binding"
+	"fyne.io/fyne/v2/layout"
+	"fyne.io/fyne/v2/widget"
+
+	"lucor.dev/paw/internal/icon"
+	"lucor.dev/paw/internal/paw"
+)
+
+func init() {
+	registerFyneItemType(paw.APICredentialItemType, icon.APIOutlinedIconThemed, nil)
+	registerFyneItemType(paw.CardItemType, icon.CreditCardOutlinedIconThemed, nil)
+	registerFyneItemType(paw.IdentityItemType, icon.IDOutlinedIconThemed, nil)
+	registerFyneItemType(paw.LicenseItemType, icon.LicenseOutlinedIconThemed, nil)
+	registerFyneItemType(paw.WiFiItemType, icon.WiFiOutlinedIconThemed, nil)
+}
+
+// Declare conformity to FyneItem interface
+var _ FyneItemWidget = (*fieldsItemWidget)(nil)
+
+// newFieldsItemWidget returns the widget for the items composed only of plain fields
+func newFieldsItemWidget(item paw.FieldsItem, icon fyne.Resource) FyneItemWidget {
+	return &fieldsItemWidget{
+		item: item,
+		icon: icon,
+	}
+}
+
+type fieldsItemWidget struct {
+	item      paw.FieldsItem
+	icon      fyne.Resource
+	validator []fyne.Validatable
+}
+
+func (iw *fieldsItemWidget) Item() paw.Item {
+	copy, err := paw.NewItem("", iw.item.GetMetadata().Type)
+	if err != nil {
+		panic(err)
+	}
+	err = deepCopyItem(iw.item, copy)
+	if err != nil {
+		panic(err)
+	}
+	return copy
+}
+
+func (iw *fieldsItemWidget) Icon() fyne.Resource {
+	return iw.icon
+}
+
+// OnSubmit implements FyneItem.
+func (iw *fieldsItemWidget) OnSubmit() (paw.Item, error) {
+	for _, v := range iw.validator {
+		if err := v.Validate(); err != nil {
+			return nil, err
+		}
+	}
+	err := paw.NormalizeItemFields(iw.item)
+	if err != nil {
+		return nil, newValidatioError(err.Error())
+	}
+	return iw.Item(), nil
+}
+
+func (iw *fieldsItemWidget) Edit(ctx context.Context, key *paw.Key, w fyne.Window) fyne.CanvasObject {
+	titleEntry := widget.NewEntryWithData(binding.BindString(&iw.item.GetMetadata().Name))
+	titleEntry.Validator = requiredValidator("The title cannot be emtpy")
+	titleEntry.PlaceHolder = "Untitled " + iw.item.GetMetadata().Type.Label()
+	titleEntry.Validate()
+
+	iw.validator = append(iw.validator, titleEntry)
+
+	form := container.New(layout.NewFormLayout())
+	form.Add(widget.NewIcon(iw.Icon()))
+	form.Add(titleEntry)
+
+	for _, f := range iw.item.ItemFields() {
+		bind := binding.BindString(f.Value)
+
+		var entry fyne.CanvasObject
+		switch {
+		case len(f.Options) > 0:
+			s := widget.NewSelect(f.Options, func(v string) {
+				bind.Set(v)
+			})
+			s.SetSelected(*f.Value)
+			entry = s
+		case f.Type.IsSecret():
+			e := widget.NewPasswordEntry()
+			e.Bind(bind)
+			e.Validator = nil
+			entry = e
+		case f.Multiline:
+			entry = newNoteEntryWithData(bind)
+		default:
+			e := widget.NewEntryWithData(bind)
+			e.Validator = nil
+			if f.Type == paw.DateCustomField {
+				e.SetPlaceHolder("YYYY-MM-DD")
+			}
+			entry = e
+		}
+		form.Add(labelWithStyle(f.Label))
+		form.Add(entry)
+	}
+
+	return form
+}
+
+func (iw *fieldsItemWidget) Show(ctx context.Context, w fyne.Window) fyne.CanvasObject {
+	obj := titleRow(iw.Icon(), iw.item.GetMetadata().Name)
+	for _, f := range iw.item.ItemFields() {
+		if *f.Value == "" {
+			continue
+		}
+		opts := rowActionOptions{copy: true}
+		switch {
+		case f.Type.IsSecret():
+			opts.widgetType = "password"
+		case f.Type == paw.URLCustomField:
+			opts.widgetType = "url"
+		}
+		obj = append(obj, rowWithAction(f.Label, *f.Value, opts, w)...)
+	}
+	return container.New(layout.NewFormLayout(), obj...)
+}
@@ -44,16 +44,29 @@ type FynePasswordGenerator interface {
 	ShowPasswordGenerator(bind binding.String, password *paw.Password, w fyne.Window)
 }
 
+// fyneItemType holds the UI details of an item type
+type fyneItemType struct {
+	icon      fyne.Resource
+	newWidget func(item paw.Item, preferences *paw.Preferences) FyneItemWidget
+}
+
+// fyneItemTypes holds the UI details of the registered item types
+var fyneItemTypes = map[paw.ItemType]*fyneItemType{}
+
+// registerFyneItemType registers the icon and the widget constructor of an item type.
+// A nil constructor can be used for the items composed only of plain fields, see paw.FieldsItem.
+func registerFyneItemType(itemType paw.ItemType, icon fyne.Resource, newWidget func(item paw.Item, preferences *paw.Preferences) FyneItemWidget) {
+	fyneItemTypes[itemType] = &fyneItemType{icon: icon, newWidget: newWidget}
+}
+
 func NewFyneItemWidget(item paw.Item, preferences *paw.Preferences) FyneItemWidget {
-	switch item.GetMetadata().Type {
-	case paw.NoteItemType:
-		return NewNoteWidget(item.(*paw.Note))
-	case paw.LoginItemType:
-		return NewLoginWidget(item.(*paw.Login), preferences)
-	case paw.PasswordItemType:
-		return NewPasswordWidget(item.(*paw.Password), preferences)
-	case paw.SSHKeyItemType:
-		return NewSSHWidget(item.(*paw.SSHKey), preferences)
+	if t, ok := fyneItemTypes[item.GetMetadata().Type]; ok {
+		if t.newWidget != nil {
+			return t.newWidget(item, preferences)
+		}
+		if v, ok := item.(paw.FieldsItem); ok {
+			return newFieldsItemWidget(v, t.icon)
+		}
 	}
 	panic(fmt.Sprintf("unsupported item type %q", item.GetMetadata().Type))
 }
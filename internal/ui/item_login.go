@@ -19,6 +19,12 @@ import (
 	"lucor.dev/paw/internal/paw"
 )
 
+func init() {
+	registerFyneItemType(paw.LoginItemType, icon.WorldWWWOutlinedIconThemed, func(item paw.Item, preferences *paw.Preferences) FyneItemWidget {
+		return NewLoginWidget(item.(*paw.Login), preferences)
+	})
+}
+
 // Declare conformity to FyneItemWidget interface
 var _ FyneItemWidget = (*loginItemWidget)(nil)
 
@@ -36,6 +42,11 @@ type loginItemWidget struct {
 	preferences *paw.Preferences
 	urlEntry    *urlEntry
 
+	// onNextHOTPCode returns the next HOTP code incrementing the persisted counter
+	onNextHOTPCode func() (string, error)
+	// onRestorePassword restores the password history entry at index i
+	onRestorePassword func(i int) error
+
 	validator []fyne.Validatable
 }
 
@@ -180,8 +191,10 @@ func (iw *loginItemWidget) Show(ctx context.Context, w fyne.Window) fyne.CanvasO
 	if iw.item.Password.Value != "" {
 		obj = append(obj, rowWithAction("Password", iw.item.Password.Value, rowActionOptions{widgetType: "password", copy: true}, w)...)
 	}
+	history := &passwordHistory{password: iw.item.Password, OnRestore: iw.onRestorePassword}
+	obj = append(obj, history.Show(w)...)
 	if iw.item.TOTP != nil && iw.item.TOTP.Secret != "" {
-		uiTOTP := &TOTP{TOTP: iw.item.TOTP}
+		uiTOTP := &TOTP{TOTP: iw.item.TOTP, Issuer: iw.item.Name, Account: iw.item.Username, OnNextHOTPCode: iw.onNextHOTPCode}
 		obj = append(obj, uiTOTP.Show(ctx, w)...)
 	}
 	if iw.item.Note != nil && iw.item.Note.Value != "" {
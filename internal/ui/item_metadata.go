@@ -31,25 +31,25 @@ func (m *Metadata) Icon() fyne.Resource {
 	if m.Favicon != nil {
 		return m.Favicon
 	}
-	switch m.Type {
-	case paw.NoteItemType:
-		return icon.NoteOutlinedIconThemed
-	case paw.PasswordItemType:
-		return icon.PasswordOutlinedIconThemed
-	case paw.LoginItemType:
-		return icon.WorldWWWOutlinedIconThemed
-	case paw.SSHKeyItemType:
-		return icon.KeyOutlinedIconThemed
+	if t, ok := fyneItemTypes[m.Type]; ok {
+		return t.icon
 	}
 	return icon.PawIcon
 }
 
 func ShowMetadata(m *paw.Metadata) fyne.CanvasObject {
-	return container.New(
-		layout.NewFormLayout(),
-		widget.NewLabel("Modified"),
-		widget.NewLabel(m.Modified.Local().Format(time.RFC1123)),
-		widget.NewLabel("Created"),
-		widget.NewLabel(m.Created.Local().Format(time.RFC1123)),
-	)
+	c := container.New(layout.NewFormLayout())
+	if len(m.Tags) > 0 {
+		c.Add(widget.NewLabel("Tags"))
+		c.Add(widget.NewLabel(formatTags(m.Tags)))
+	}
+	if m.Folder != "" {
+		c.Add(widget.NewLabel("Folder"))
+		c.Add(widget.NewLabel(m.Folder))
+	}
+	c.Add(widget.NewLabel("Modified"))
+	c.Add(widget.NewLabel(m.Modified.Local().Format(time.RFC1123)))
+	c.Add(widget.NewLabel("Created"))
+	c.Add(widget.NewLabel(m.Created.Local().Format(time.RFC1123)))
+	return c
 }
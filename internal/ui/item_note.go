@@ -16,6 +16,12 @@ import (
 	"lucor.dev/paw/internal/paw"
 )
 
+func init() {
+	registerFyneItemType(paw.NoteItemType, icon.NoteOutlinedIconThemed, func(item paw.Item, preferences *paw.Preferences) FyneItemWidget {
+		return NewNoteWidget(item.(*paw.Note))
+	})
+}
+
 // Declare conformity to FyneItem interface
 var _ FyneItemWidget = (*noteItemWidget)(nil)
 
@@ -17,6 +17,12 @@ import (
 	"lucor.dev/paw/internal/paw"
 )
 
+func init() {
+	registerFyneItemType(paw.PasswordItemType, icon.PasswordOutlinedIconThemed, func(item paw.Item, preferences *paw.Preferences) FyneItemWidget {
+		return NewPasswordWidget(item.(*paw.Password), preferences)
+	})
+}
+
 // Declare conformity to FyneItem interface
 var _ FyneItemWidget = (*passwordItemWidget)(nil)
 
@@ -31,6 +37,9 @@ type passwordItemWidget struct {
 	item        *paw.Password
 	preferences *paw.Preferences
 	validator   []fyne.Validatable
+
+	// onRestorePassword restores the password history entry at index i
+	onRestorePassword func(i int) error
 }
 
 func (iw *passwordItemWidget) Item() paw.Item {
@@ -116,6 +125,8 @@ func (iw *passwordItemWidget) Show(ctx context.Context, w fyne.Window) fyne.Canv
 	if iw.item.Value != "" {
 		obj = append(obj, rowWithAction("Password", iw.item.Value, rowActionOptions{widgetType: "password", copy: true}, w)...)
 	}
+	history := &passwordHistory{password: iw.item, OnRestore: iw.onRestorePassword}
+	obj = append(obj, history.Show(w)...)
 	if iw.item.Note.Value != "" {
 		obj = append(obj, rowWithAction("Note", iw.item.Note.Value, rowActionOptions{copy: true}, w)...)
 	}
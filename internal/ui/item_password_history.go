@@ -0,0 +1,90 @@
+// SPDX-FileCopyrightText: 2025 Luca Corbo, Paw contributors
+// SPDX-License-Identifier: AGPL-3.0-or-later
+
+package ui
+
+import (
+	"fmt"
+
+	"fyne.io/fyne/v2"
+	"fyne.io/fyne/v2/container"
+	"fyne.io/fyne/v2/dialog"
+	"fyne.io/fyne/v2/layout"
+	"fyne.io/fyne/v2/theme"
+	"fyne.io/fyne/v2/widget"
+
+	"lucor.dev/paw/internal/paw"
+	pawwidget "lucor.dev/paw/internal/widget"
+)
+
+// passwordHistory shows the previous values of a password
+type passwordHistory struct {
+	password *paw.Password
+	// OnRestore restores the history entry at index i.
+	// The restore action is not available when nil.
+	OnRestore func(i int) error
+}
+
+// Show returns the history row, it is empty when the history is empty
+func (ph *passwordHistory) Show(w fyne.Window) []fyne.CanvasObject {
+	if ph.password == nil || len(ph.password.History) == 0 {
+		return nil
+	}
+
+	text := "1 previous password"
+	if n := len(ph.password.History); n > 1 {
+		text = fmt.Sprintf("%d previous passwords", n)
+	}
+	button := widget.NewButtonWithIcon(text, theme.HistoryIcon(), func() {
+		ph.showDialog(w)
+	})
+	button.Alignment = widget.ButtonAlignLeading
+	button.Importance = widget.LowImportance
+
+	return []fyne.CanvasObject{
+		labelWithStyle("History"),
+		button,
+	}
+}
+
+func (ph *passwordHistory) showDialog(w fyne.Window) {
+	var d dialog.Dialog
+
+	form := container.New(layout.NewFormLayout())
+	for i, entry := range ph.password.History {
+		i := i
+		actionMenu := []*fyne.MenuItem{
+			{
+				Label:  "Copy",
+				Icon:   theme.ContentCopyIcon(),
+				Action: copyAction("Password", entry.Value, w),
+			},
+		}
+		if ph.OnRestore != nil {
+			actionMenu = append(actionMenu, &fyne.MenuItem{
+				Label: "Restore",
+				Icon:  theme.HistoryIcon(),
+				Action: func() {
+					msg := widget.NewLabel("Are you sure you want to restore this password?\nThe current one will be added to the history.")
+					dialog.ShowCustomConfirm("", "Restore", "Cancel", msg, func(b bool) {
+						if !b {
+							return
+						}
+						d.Hide()
+						err := ph.OnRestore(i)
+						if err != nil {
+							dialog.ShowError(err, w)
+						}
+					}, w)
+				},
+			})
+		}
+		replaced := widget.NewLabel(entry.Replaced.Local().Format("2006-01-02 15:04:05"))
+		form.Add(replaced)
+		form.Add(container.NewBorder(nil, nil, nil, makeActionMenu(actionMenu, w), pawwidget.NewPasswordRevealer(entry.Value)))
+	}
+
+	d = dialog.NewCustom("Password history", "Close", container.NewVScroll(form), w)
+	d.Resize(fyne.NewSize(480, 360))
+	d.Show()
+}
@@ -22,6 +22,12 @@ import (
 	"lucor.dev/paw/internal/sshkey"
 )
 
+func init() {
+	registerFyneItemType(paw.SSHKeyItemType, icon.KeyOutlinedIconThemed, func(item paw.Item, preferences *paw.Preferences) FyneItemWidget {
+		return NewSSHWidget(item.(*paw.SSHKey), preferences)
+	})
+}
+
 // Declare conformity to FyneItem interface
 var _ FyneItemWidget = (*sshItemWidget)(nil)
 
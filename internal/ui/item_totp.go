@@ -4,26 +4,35 @@
 package ui
 
 import (
+	"bytes"
 	"context"
-	"crypto/sha1"
 	"fmt"
 	"strconv"
+	"strings"
 	"time"
 
 	"fyne.io/fyne/v2"
+	"fyne.io/fyne/v2/canvas"
 	"fyne.io/fyne/v2/container"
 	"fyne.io/fyne/v2/data/binding"
 	"fyne.io/fyne/v2/dialog"
 	"fyne.io/fyne/v2/layout"
+	"fyne.io/fyne/v2/storage"
 	"fyne.io/fyne/v2/theme"
 	"fyne.io/fyne/v2/widget"
 
 	"lucor.dev/paw/internal/otp"
 	"lucor.dev/paw/internal/paw"
+	"lucor.dev/paw/internal/qrcode"
 )
 
 type TOTP struct {
 	*paw.TOTP
+	// Issuer and Account are the labels used to export the TOTP as otpauth URI
+	Issuer  string
+	Account string
+	// OnNextHOTPCode returns the next HOTP code incrementing the persisted counter
+	OnNextHOTPCode func() (string, error)
 }
 
 func (t *TOTP) Edit(ctx context.Context, w fyne.Window) (fyne.CanvasObject, *paw.TOTP) {
@@ -46,6 +55,57 @@ func (t *TOTP) Edit(ctx context.Context, w fyne.Window) (fyne.CanvasObject, *paw
 		copy := totp
 		form := container.New(layout.NewFormLayout())
 
+		intervalBind := binding.BindInt(&copy.Interval)
+		intervalSlider := widget.NewSlider(5, 60)
+		intervalSlider.Step = 5
+		intervalSlider.OnChanged = func(f float64) {
+			intervalBind.Set(int(f))
+		}
+		intervalSlider.Value = float64(copy.Interval)
+		intervalEntry := widget.NewLabelWithData(binding.IntToString(intervalBind))
+		intervalRow := container.NewBorder(nil, nil, nil, intervalEntry, intervalSlider)
+
+		counterEntry := widget.NewEntry()
+		counterEntry.SetText(strconv.FormatUint(copy.Counter, 10))
+		counterEntry.Validator = func(s string) error {
+			_, err := strconv.ParseUint(s, 10, 64)
+			if err != nil {
+				return newValidatioError("The counter must be a positive number")
+			}
+			return nil
+		}
+		counterEntry.OnChanged = func(s string) {
+			if v, err := strconv.ParseUint(s, 10, 64); err == nil {
+				copy.Counter = v
+			}
+		}
+
+		toggleTypeOptions := func() {
+			if copy.IsHOTP() {
+				intervalRow.Hide()
+				counterEntry.Show()
+				return
+			}
+			intervalRow.Show()
+			counterEntry.Hide()
+		}
+
+		typeOptions := []string{"Time-based (TOTP)", "Counter-based (HOTP)"}
+		typeSelect := widget.NewSelect(typeOptions, func(s string) {
+			copy.OTPType = paw.TOTPType
+			if s == typeOptions[1] {
+				copy.OTPType = paw.HOTPType
+			}
+			toggleTypeOptions()
+		})
+		typeSelect.Selected = typeOptions[0]
+		if copy.IsHOTP() {
+			typeSelect.Selected = typeOptions[1]
+		}
+		toggleTypeOptions()
+		form.Add(labelWithStyle("Type"))
+		form.Add(typeSelect)
+
 		hashOptions := []string{string(paw.SHA1), string(paw.SHA256), string(paw.SHA512)}
 		hashSelect := widget.NewSelect(hashOptions, func(s string) {
 			copy.Hash = paw.TOTPHash(s)
@@ -54,7 +114,7 @@ func (t *TOTP) Edit(ctx context.Context, w fyne.Window) (fyne.CanvasObject, *paw
 		form.Add(labelWithStyle("Hash Algorithm"))
 		form.Add(hashSelect)
 
-		digitsOptions := []string{"5", "6", "7", "8", "9", "10"}
+		digitsOptions := []string{"6", "7", "8", "9", "10"}
 		digitsSelect := widget.NewSelect(digitsOptions, func(s string) {
 			copy.Digits, _ = strconv.Atoi(s)
 		})
@@ -62,16 +122,9 @@ func (t *TOTP) Edit(ctx context.Context, w fyne.Window) (fyne.CanvasObject, *paw
 		form.Add(labelWithStyle("Digits"))
 		form.Add(digitsSelect)
 
-		intervalBind := binding.BindInt(&copy.Interval)
-		intervalSlider := widget.NewSlider(5, 60)
-		intervalSlider.Step = 5
-		intervalSlider.OnChanged = func(f float64) {
-			intervalBind.Set(int(f))
-		}
-		intervalSlider.Value = float64(copy.Interval)
-		intervalEntry := widget.NewLabelWithData(binding.IntToString(intervalBind))
-		form.Add(labelWithStyle("Interval"))
-		form.Add(container.NewBorder(nil, nil, nil, intervalEntry, intervalSlider))
+		// the interval is used only by TOTP and the counter only by HOTP
+		form.Add(labelWithStyle("Interval / Counter"))
+		form.Add(container.NewStack(intervalRow, counterEntry))
 
 		dialog.ShowCustomConfirm("2FA key (TOTP) custom settings", "OK", "Cancel", container.NewStack(form), func(b bool) {
 			if b {
@@ -80,15 +133,92 @@ func (t *TOTP) Edit(ctx context.Context, w fyne.Window) (fyne.CanvasObject, *paw
 		}, w)
 	})
 
+	// setFromOTPAuth sets the TOTP from an otpauth URI or a base32 encoded secret
+	setFromOTPAuth := func(value string) {
+		parsed := paw.NewDefaultTOTP()
+		if strings.HasPrefix(value, paw.OTPAuthScheme+":") {
+			o, err := paw.ParseOTPAuth(value)
+			if err != nil {
+				dialog.ShowError(err, w)
+				return
+			}
+			parsed = o.TOTP
+		} else {
+			secret, err := paw.NormalizeTOTPSecret(value)
+			if err != nil {
+				dialog.ShowError(err, w)
+				return
+			}
+			parsed.Secret = secret
+		}
+		*totp = *parsed
+		keyBind.Reload()
+	}
+
+	totpActionMenu := []*fyne.MenuItem{
+		{
+			Label: "Scan QR code image",
+			Icon:  theme.FileImageIcon(),
+			Action: func() {
+				d := dialog.NewFileOpen(func(uc fyne.URIReadCloser, err error) {
+					if err != nil {
+						dialog.ShowError(err, w)
+						return
+					}
+					if uc == nil {
+						return
+					}
+					defer uc.Close()
+					v, err := qrcode.Decode(uc)
+					if err != nil {
+						dialog.ShowError(err, w)
+						return
+					}
+					setFromOTPAuth(v)
+				}, w)
+				d.SetFilter(storage.NewExtensionFileFilter([]string{".png", ".jpg", ".jpeg"}))
+				d.Show()
+			},
+		},
+		{
+			Label: "Scan QR code from clipboard",
+			Icon:  theme.ContentPasteIcon(),
+			Action: func() {
+				img, err := readImageFromClipboard()
+				if err != nil {
+					dialog.ShowError(err, w)
+					return
+				}
+				v, err := qrcode.Decode(bytes.NewReader(img))
+				if err != nil {
+					dialog.ShowError(err, w)
+					return
+				}
+				setFromOTPAuth(v)
+			},
+		},
+		{
+			Label: "Paste otpauth URI",
+			Icon:  theme.ContentPasteIcon(),
+			Action: func() {
+				setFromOTPAuth(fyne.CurrentApp().Clipboard().Content())
+			},
+		},
+	}
+
 	form := container.New(layout.NewFormLayout())
 
 	form.Add(labelWithStyle("2FA key"))
-	form.Add(container.NewBorder(nil, nil, nil, settingsButton, keyEntry))
+	form.Add(container.NewBorder(nil, nil, nil, container.NewHBox(settingsButton, makeActionMenu(totpActionMenu, w)), keyEntry))
 
 	return form, totp
 }
 
 func (t *TOTP) Show(ctx context.Context, w fyne.Window) []fyne.CanvasObject {
+	if t.IsHOTP() {
+		return t.showHOTP(w)
+	}
+
 	totp := binding.NewString()
 
 	keyLabel := widget.NewLabel("")
@@ -99,14 +229,14 @@ func (t *TOTP) Show(ctx context.Context, w fyne.Window) []fyne.CanvasObject {
 	}))
 
 	now := time.Now().UTC()
-	v, _ := otp.TOTPFromBase32(t.Hasher(), t.Secret, now, t.Interval, t.Digits)
+	v, _ := t.Code(now)
 	totp.Set(v)
 
 	progressbar := widget.NewProgressBar()
 	progressbar.Min = 0
 	progressbar.Max = float64(t.Interval)
 
-	progressbar.SetValue(float64(t.Interval - (now.Second() % t.Interval)))
+	progressbar.SetValue(t.Remaining(now).Seconds())
 	progressbar.TextFormatter = func() string {
 		return fmt.Sprintf("%.0f", progressbar.Value)
 	}
@@ -121,7 +251,7 @@ func (t *TOTP) Show(ctx context.Context, w fyne.Window) []fyne.CanvasObject {
 			case <-ticker.C:
 				v := progressbar.Value
 				if v == 1 {
-					v, _ := otp.TOTPFromBase32(sha1.New, t.Secret, time.Now().UTC(), t.Interval, t.Digits)
+					v, _ := t.Code(time.Now().UTC())
 					fyne.DoAndWait(func() {
 						totp.Set(v)
 						progressbar.SetValue(progressbar.Max)
@@ -144,5 +274,85 @@ func (t *TOTP) Show(ctx context.Context, w fyne.Window) []fyne.CanvasObject {
 		})
 	})
 
-	return []fyne.CanvasObject{labelWithStyle("2FA key"), container.NewBorder(nil, nil, keyLabel, b, progressbar)}
+	qrButton := widget.NewButtonWithIcon("", theme.FileImageIcon(), func() {
+		t.showQRCode(w)
+	})
+
+	return []fyne.CanvasObject{labelWithStyle("2FA key"), container.NewBorder(nil, nil, keyLabel, container.NewHBox(b, qrButton), progressbar)}
+}
+
+// showHOTP shows the HOTP. The code is generated on demand since each code
+// generation increments the counter.
+func (t *TOTP) showHOTP(w fyne.Window) []fyne.CanvasObject {
+	code := ""
+	keyLabel := widget.NewLabel("")
+	counterLabel := widget.NewLabel("")
+	update := func() {
+		counterLabel.SetText(fmt.Sprintf("counter %d", t.Counter))
+		if code == "" {
+			keyLabel.SetText("••• •••")
+			return
+		}
+		m := len(code) / 2
+		keyLabel.SetText(code[0:m] + " " + code[m:])
+	}
+	update()
+
+	copyButton := widget.NewButtonWithIcon("", theme.ContentCopyIcon(), func() {
+		if code == "" {
+			return
+		}
+		fyne.CurrentApp().Clipboard().SetContent(code)
+		fyne.CurrentApp().SendNotification(&fyne.Notification{
+			Title:   "paw",
+			Content: "2FA key copied",
+		})
+	})
+	copyButton.Disable()
+
+	generateButton := widget.NewButtonWithIcon("Generate", theme.ViewRefreshIcon(), func() {
+		if t.OnNextHOTPCode == nil {
+			return
+		}
+		v, err := t.OnNextHOTPCode()
+		if err != nil {
+			dialog.ShowError(err, w)
+			return
+		}
+		code = v
+		copyButton.Enable()
+		update()
+	})
+
+	qrButton := widget.NewButtonWithIcon("", theme.FileImageIcon(), func() {
+		t.showQRCode(w)
+	})
+
+	return []fyne.CanvasObject{labelWithStyle("2FA key"), container.NewBorder(nil, nil, keyLabel, container.NewHBox(generateButton, copyButton, qrButton), counterLabel)}
+}
+
+// showQRCode shows the TOTP as otpauth URI and QR code to transfer to another device
+func (t *TOTP) showQRCode(w fyne.Window) {
+	o := &paw.OTPAuth{TOTP: t.TOTP, Issuer: t.Issuer, Account: t.Account}
+	uri := o.String()
+	img, err := qrcode.Image(uri, 256)
+	if err != nil {
+		dialog.ShowError(err, w)
+		return
+	}
+
+	qr := canvas.NewImageFromImage(img)
+	qr.FillMode = canvas.ImageFillOriginal
+
+	copyButton := widget.NewButtonWithIcon("Copy URI", theme.ContentCopyIcon(), func() {
+		fyne.CurrentApp().Clipboard().SetContent(uri)
+		fyne.CurrentApp().SendNotification(&fyne.Notification{
+			Title:   "paw",
+			Content: "otpauth URI copied",
+		})
+	})
+
+	msg := widget.NewLabel("Scan the QR code with an authenticator app")
+	msg.Alignment = fyne.TextAlignCenter
+	dialog.ShowCustom("2FA key", "Close", container.NewVBox(msg, qr, container.NewCenter(copyButton)), w)
 }
@@ -20,6 +20,9 @@ type itemViewWidget struct {
 	itemWidget FyneItemWidget
 	win        fyne.Window
 
+	// attachments is the optional view of the item attachments
+	attachments fyne.CanvasObject
+
 	editBtn  *widget.Button
 	OnSubmit func()
 }
@@ -44,10 +47,15 @@ func newItemViewWidget(ctx context.Context, itemWidget FyneItemWidget, win fyne.
 }
 
 func (ivw *itemViewWidget) CreateRenderer() fyne.WidgetRenderer {
-	metadata := ivw.itemWidget.Item().GetMetadata()
+	item := ivw.itemWidget.Item()
 	itemContent := ivw.itemWidget.Show(ivw.ctx, ivw.win)
-	metaContent := ShowMetadata(metadata)
-	bottom := container.NewVBox(metaContent, ivw.editBtn)
+	metaContent := ShowMetadata(item.GetMetadata())
+	bottom := container.NewVBox(showCustomFields(ivw.ctx, item, ivw.win))
+	if ivw.attachments != nil {
+		bottom.Add(ivw.attachments)
+	}
+	bottom.Add(metaContent)
+	bottom.Add(ivw.editBtn)
 	c := container.NewBorder(nil, bottom, nil, nil, itemContent)
 	return widget.NewSimpleRenderer(c)
 }
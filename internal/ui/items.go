@@ -84,6 +84,11 @@ func (iw *itemsWidget) makeList(selectedItem paw.Item, opts *paw.VaultFilterOpti
 			}, &canvas.Text{
 				Text:     "Item subtitle",
 				TextSize: theme.CaptionTextSize(),
+			}, &canvas.Text{
+				Text:      "Item tags",
+				TextSize:  theme.CaptionTextSize(),
+				TextStyle: fyne.TextStyle{Italic: true},
+				Color:     theme.Color(theme.ColorNamePrimary),
 			})
 		},
 		func(id int, obj fyne.CanvasObject) {
@@ -95,6 +100,9 @@ func (iw *itemsWidget) makeList(selectedItem paw.Item, opts *paw.VaultFilterOpti
 			subtitle := obj.(*fyne.Container).Objects[2].(*canvas.Text)
 			subtitle.Text = metadata.Subtitle
 			subtitle.Refresh()
+			tags := obj.(*fyne.Container).Objects[3].(*canvas.Text)
+			tags.Text = formatTags(metadata.Tags)
+			tags.Refresh()
 		})
 
 	list.OnSelected = func(id widget.ListItemID) {
@@ -115,6 +123,7 @@ type itemListLayout struct {
 	icon     *widget.Icon
 	title    *canvas.Text
 	subtitle *canvas.Text
+	tags     *canvas.Text
 }
 
 // MinSize returns the minimum size required by the custom layout
@@ -128,6 +137,7 @@ func (l *itemListLayout) MinSize(objects []fyne.CanvasObject) fyne.Size {
 func (l *itemListLayout) Layout(objects []fyne.CanvasObject, containerSize fyne.Size) {
 	l.icon.Resize(iconSize)
 	l.icon.Move(fyne.NewPos((iconBoxSize.Width-l.icon.MinSize().Width)/2, (containerSize.Height-l.icon.MinSize().Height)/2))
+	l.tags.Move(fyne.NewPos(containerSize.Width-l.tags.MinSize().Width-theme.InnerPadding(), (containerSize.Height-l.tags.MinSize().Height)/2))
 	boxLeft := iconBoxSize.Width + theme.InnerPadding()
 	if l.subtitle.Text == "" {
 		l.title.Move(fyne.NewPos(boxLeft, (containerSize.Height-l.title.MinSize().Height)/2))
@@ -138,11 +148,21 @@ func (l *itemListLayout) Layout(objects []fyne.CanvasObject, containerSize fyne.
 }
 
 // newItemListContainer returns a new container for the item list
-func newItemListContainer(icon *widget.Icon, title, subtitle *canvas.Text) *fyne.Container {
+func newItemListContainer(icon *widget.Icon, title, subtitle, tags *canvas.Text) *fyne.Container {
 	layout := &itemListLayout{
 		icon:     icon,
 		title:    title,
 		subtitle: subtitle,
+		tags:     tags,
+	}
+	return container.New(layout, icon, title, subtitle, tags)
+}
+
+// formatTags returns the tags formatted to be displayed as labels, i.e. #work #bank
+func formatTags(tags []string) string {
+	labels := make([]string, len(tags))
+	for i, tag := range tags {
+		labels[i] = "#" + tag
 	}
-	return container.New(layout, icon, title, subtitle)
+	return strings.Join(labels, " ")
 }
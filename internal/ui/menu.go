@@ -74,8 +74,13 @@ func (a *app) makeVaultMenu() fyne.CanvasObject {
 
 	menuItems := []*fyne.MenuItem{
 		fyne.NewMenuItem("Password Audit", a.showAuditPasswordView),
+		fyne.NewMenuItem("Recently Deleted", a.showTrashView),
 		fyne.NewMenuItem("Import From File", a.importFromFile),
 		fyne.NewMenuItem("Export To File", a.exportToFile),
+		fyne.NewMenuItem("Synchronise", a.configureSync),
+		fyne.NewMenuItemSeparator(),
+		fyne.NewMenuItem("Change Password", a.changeVaultPassword),
+		fyne.NewMenuItem("Rotate Key", a.rotateVaultKey),
 		fyne.NewMenuItemSeparator(),
 		fyne.NewMenuItem("Lock Vault", func() {
 			a.main.Content = a.makeUnlockVaultView(a.vault.Name)
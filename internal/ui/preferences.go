@@ -21,7 +21,9 @@ func (a *app) makePreferencesView() fyne.CanvasObject {
 		container.NewVBox(
 			a.makeFaviconDownloaderPreferencesCard(),
 			a.makePasswordPreferencesCard(),
+			a.makeSecretServicePreferencesCard(),
 			a.makeTOTPPreferencesCard(),
+			a.makeTrashPreferencesCard(),
 		),
 	)
 
@@ -49,6 +51,31 @@ func (a *app) makeFaviconDownloaderPreferencesCard() fyne.CanvasObject {
 	)
 }
 
+func (a *app) makeSecretServicePreferencesCard() fyne.CanvasObject {
+	checkbox := widget.NewCheck("Enabled", func(enabled bool) {
+		if enabled == a.state.Preferences.SecretService.Enabled {
+			return
+		}
+		a.state.Preferences.SecretService.Enabled = enabled
+		a.storePreferences()
+		if !enabled {
+			a.stopSecretService()
+			return
+		}
+		err := a.startSecretService()
+		if err != nil {
+			dialog.ShowError(err, a.win)
+		}
+	})
+	checkbox.Checked = a.state.Preferences.SecretService.Enabled
+
+	return widget.NewCard(
+		"Secret Service",
+		"Expose the unlocked vaults to the applications using the Freedesktop Secret Service API",
+		checkbox,
+	)
+}
+
 func (a *app) makePasswordPreferencesCard() fyne.CanvasObject {
 	passphraseCard := widget.NewCard(
 		"Passphrase",
@@ -80,7 +107,7 @@ func (a *app) makeTOTPPreferencesCard() fyne.CanvasObject {
 	form.Add(labelWithStyle("Hash Algorithm"))
 	form.Add(hashSelect)
 
-	digitsOptions := []string{"5", "6", "7", "8", "9", "10"}
+	digitsOptions := []string{"6", "7", "8", "9", "10"}
 	digitsSelect := widget.NewSelect(digitsOptions, func(selected string) {
 		a.state.Preferences.TOTP.Digits, _ = strconv.Atoi(selected)
 		a.storePreferences()
@@ -108,6 +135,25 @@ func (a *app) makeTOTPPreferencesCard() fyne.CanvasObject {
 	)
 }
 
+func (a *app) makeTrashPreferencesCard() fyne.CanvasObject {
+	form := container.New(layout.NewFormLayout())
+
+	retentionOptions := []string{"7", "30", "90", "365"}
+	retentionSelect := widget.NewSelect(retentionOptions, func(selected string) {
+		a.state.Preferences.Trash.RetentionDays, _ = strconv.Atoi(selected)
+		a.storePreferences()
+	})
+	retentionSelect.Selected = strconv.Itoa(int(a.state.Preferences.Trash.Retention().Hours() / 24))
+	form.Add(labelWithStyle("Retention (days)"))
+	form.Add(retentionSelect)
+
+	return widget.NewCard(
+		"Trash",
+		"",
+		form,
+	)
+}
+
 func (a *app) makePreferenceLenghtWidget(lenght *int, min, max int) fyne.CanvasObject {
 	lengthBind := binding.BindInt(lenght)
 	lengthEntry := widget.NewEntryWithData(binding.IntToString(lengthBind))
@@ -0,0 +1,95 @@
+// SPDX-FileCopyrightText: 2025 Luca Corbo, Paw contributors
+// SPDX-License-Identifier: AGPL-3.0-or-later
+
+package ui
+
+import (
+	"errors"
+	"fmt"
+	"log"
+	"runtime"
+
+	"fyne.io/fyne/v2"
+	"github.com/godbus/dbus/v5"
+
+	"lucor.dev/paw/internal/paw"
+	"lucor.dev/paw/internal/secretservice"
+)
+
+// startSecretService starts the Secret Service provider on the session bus
+// exposing the vaults already unlocked
+func (a *app) startSecretService() error {
+	if a.secretService != nil {
+		return nil
+	}
+	switch runtime.GOOS {
+	case "linux", "freebsd", "openbsd", "netbsd":
+	default:
+		return fmt.Errorf("the Secret Service API is not supported on %s", runtime.GOOS)
+	}
+
+	// a private connection is used, so the bus name is released on close
+	conn, err := dbus.SessionBusPrivate()
+	if err != nil {
+		return fmt.Errorf("failed to connect to session bus: %w", err)
+	}
+	if err := conn.Auth(nil); err != nil {
+		conn.Close()
+		return fmt.Errorf("failed to authenticate to session bus: %w", err)
+	}
+	if err := conn.Hello(); err != nil {
+		conn.Close()
+		return fmt.Errorf("failed to connect to session bus: %w", err)
+	}
+
+	svc := secretservice.New(conn, a.storage)
+	svc.OnVaultChanged = func(name string) {
+		fyne.Do(func() {
+			a.mergeStoredVault(name)
+		})
+	}
+	for _, vault := range a.unlockedVault {
+		if err := svc.AddVault(vault); err != nil {
+			log.Println("secret service:", err)
+		}
+	}
+	err = svc.Start()
+	if err != nil {
+		conn.Close()
+		if errors.Is(err, secretservice.ErrNameTaken) {
+			return fmt.Errorf("another Secret Service provider is running, e.g. GNOME Keyring or KWallet, stop it to use Paw")
+		}
+		return err
+	}
+	a.secretService = svc
+	return nil
+}
+
+// mergeStoredVault merges into the unlocked vault the changes stored by the
+// Secret Service clients and refreshes the view
+func (a *app) mergeStoredVault(name string) {
+	vault, ok := a.unlockedVault[name]
+	if !ok {
+		return
+	}
+	_, err := paw.MergeVault(a.storage, vault)
+	if err != nil {
+		log.Println("could not merge the vault changes:", err)
+		return
+	}
+	if a.vault == vault {
+		a.refreshCurrentView()
+	}
+}
+
+// stopSecretService stops the Secret Service provider, if running
+func (a *app) stopSecretService() {
+	if a.secretService == nil {
+		return
+	}
+	if err := a.secretService.Stop(); err != nil {
+		log.Println("could not stop the secret service:", err)
+	}
+	a.secretService.Close()
+	a.secretService = nil
+}
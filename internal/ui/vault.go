@@ -91,10 +91,11 @@ func (a *app) makeCurrentVaultView() fyne.CanvasObject {
 				msg,
 				func(delete bool) {
 					if delete {
-						item, err = paw.NewItem(meta.Name, meta.Type)
-						vault.DeleteItem(item)                // remove item from vault
-						a.removeSSHKeyFromAgent(item)         // remove item from ssh agent
-						_ = a.storage.DeleteItem(vault, item) // remove item from storage
+						vault.DeleteItem(meta) // remove item from vault
+						if item, err := paw.NewItem(meta.Name, meta.Type); err == nil {
+							a.removeSSHKeyFromAgent(item) // remove item from ssh agent
+						}
+						_ = a.storage.DeleteItem(vault, meta) // remove item from storage
 						now := time.Now().UTC()
 						vault.Modified = now
 						_ = a.storage.StoreVault(vault) // ensure vault is up-to-date
@@ -108,8 +109,7 @@ func (a *app) makeCurrentVaultView() fyne.CanvasObject {
 			return
 		}
 
-		fyneItemWidget := NewFyneItemWidget(item, a.state.Preferences)
-		a.showItemView(fyneItemWidget)
+		a.showItemView(a.newFyneItemWidget(vault, item))
 		itemsWidget.listEntry.UnselectAll()
 	}
 
@@ -147,12 +147,114 @@ func (a *app) makeCurrentVaultView() fyne.CanvasObject {
 
 	list.SetSelectedIndex(0)
 
-	header := container.NewBorder(nil, nil, nil, a.makeVaultMenu(), list)
+	filters := []fyne.CanvasObject{list}
+	if tags := vault.Tags(); len(tags) > 0 {
+		allTags := "All tags"
+		tagSelect := widget.NewSelect(append([]string{allTags}, tags...), func(s string) {
+			filter.Tags = nil
+			if s != allTags {
+				filter.Tags = []string{s}
+			}
+			itemsWidget.Reload(nil, filter)
+		})
+		tagSelect.SetSelectedIndex(0)
+		if len(filter.Tags) > 0 {
+			tagSelect.SetSelected(filter.Tags[0])
+		}
+		filters = append(filters, tagSelect)
+	}
+	if folders := vault.Folders(); len(folders) > 0 {
+		allFolders := "All folders"
+		folderSelect := widget.NewSelect(append([]string{allFolders}, folders...), func(s string) {
+			filter.Folder = ""
+			if s != allFolders {
+				filter.Folder = s
+			}
+			itemsWidget.Reload(nil, filter)
+		})
+		folderSelect.SetSelectedIndex(0)
+		if filter.Folder != "" {
+			folderSelect.SetSelected(filter.Folder)
+		}
+		filters = append(filters, folderSelect)
+	}
+
+	header := container.NewBorder(nil, nil, nil, a.makeVaultMenu(), container.NewGridWithColumns(len(filters), filters...))
 
 	button := widget.NewButtonWithIcon("Add item", theme.ContentAddIcon(), func() {
 		a.showAddItemView()
 	})
 
+	footer := container.NewVBox(button)
+	if status := a.makeConflictsStatus(); status != nil {
+		footer.Add(status)
+	}
+	if status := a.makeSyncStatus(); status != nil {
+		footer.Add(status)
+	}
+
 	// layout so we can focus the search box using shift+tab
-	return container.NewBorder(search, nil, nil, nil, container.NewBorder(header, button, nil, nil, itemsWidget))
+	return container.NewBorder(search, nil, nil, nil, container.NewBorder(header, footer, nil, nil, itemsWidget))
+}
+
+// newFyneItemWidget returns the FyneItemWidget for the vault item
+// setting the actions that need to access the storage
+func (a *app) newFyneItemWidget(vault *paw.Vault, item paw.Item) FyneItemWidget {
+	fyneItemWidget := NewFyneItemWidget(item, a.state.Preferences)
+	switch iw := fyneItemWidget.(type) {
+	case *loginItemWidget:
+		iw.onNextHOTPCode = func() (string, error) {
+			return paw.NextHOTPCode(a.storage, vault, iw.item)
+		}
+		iw.onRestorePassword = func(i int) error {
+			return a.restorePassword(vault, iw.item, iw.item.Password, i)
+		}
+	case *passwordItemWidget:
+		iw.onRestorePassword = func(i int) error {
+			return a.restorePassword(vault, iw.item, iw.item, i)
+		}
+	}
+	return fyneItemWidget
+}
+
+// restorePassword restores the password history entry at index i
+// and shows the updated item
+func (a *app) restorePassword(vault *paw.Vault, item paw.Item, password *paw.Password, i int) error {
+	now := time.Now().UTC()
+	err := password.RestoreHistory(i, now)
+	if err != nil {
+		return err
+	}
+
+	unlock, err := paw.LockVault(a.storage, vault)
+	if err != nil {
+		return err
+	}
+	defer unlock()
+
+	item.GetMetadata().Modified = now
+	err = a.storage.StoreItem(vault, item)
+	if err != nil {
+		return err
+	}
+	err = vault.AddItem(item)
+	if err != nil {
+		return err
+	}
+
+	vault.Modified = now
+	err = a.storage.StoreVault(vault)
+	if err != nil {
+		return err
+	}
+
+	a.state.Modified = now
+	err = a.storage.StoreAppState(a.state)
+	if err != nil {
+		return err
+	}
+
+	a.refreshCurrentView()
+	a.showItemView(a.newFyneItemWidget(vault, item))
+	return nil
 }
@@ -7,7 +7,6 @@ import (
 	"errors"
 	"fmt"
 
-	"filippo.io/age"
 	"fyne.io/fyne/v2"
 	"fyne.io/fyne/v2/canvas"
 	"fyne.io/fyne/v2/container"
@@ -46,12 +45,8 @@ func (uw *unlockerVaultWidget) unlock() {
 
 	key, err := uw.app.storage.LoadVaultKey(uw.vaultName, password)
 	if err != nil {
-		var invalidPasswordError *age.NoIdentityMatchError
-		if errors.As(err, &invalidPasswordError) {
-			err = errors.New("the password is incorrect")
-		}
 		stopAct()
-		dialog.ShowError(err, uw.app.win)
+		dialog.ShowError(unlockError(err), uw.app.win)
 		return
 	}
 	vault, err := uw.app.storage.LoadVault(uw.vaultName, key)
@@ -6,7 +6,9 @@ package main
 import (
 	"fmt"
 	"os"
+	"path/filepath"
 	"runtime"
+	"strings"
 
 	"fyne.io/fyne/v2"
 	"fyne.io/fyne/v2/app"
@@ -26,7 +28,13 @@ type appType struct {
 
 // IsCLI returns true if the application is a CLI app
 func (a *appType) IsCLI() bool {
-	return len(a.args) > 1 && a.args[1] == "cli"
+	return (len(a.args) > 1 && a.args[1] == "cli") || a.IsDockerCredentialHelper()
+}
+
+// IsDockerCredentialHelper returns true if the application is invoked by docker as credential helper
+func (a *appType) IsDockerCredentialHelper() bool {
+	name := strings.TrimSuffix(filepath.Base(a.args[0]), ".exe")
+	return name == "docker-credential-paw"
 }
 
 // IsGUI returns true if the application is a GUI app
@@ -83,6 +91,13 @@ func main() {
 		return
 	}
 
+	if at.IsDockerCredentialHelper() {
+		// Run the CLI docker-credential command
+		args := append([]string{os.Args[0], "cli", "docker-credential"}, os.Args[1:]...)
+		cli.Run(args, s)
+		return
+	}
+
 	if at.IsCLI() {
 		// Run the CLI app
 		cli.Run(os.Args, s)
@@ -124,6 +139,10 @@ func makeStorage(at *appType, fyneApp fyne.App) (paw.Storage, error) {
 		// Mobile app returns the Fyne storage
 		return paw.NewFyneStorage(fyneApp.Storage())
 	}
-	// Otherwise returns the OS storage
-	return paw.NewOSStorage()
+	// Otherwise returns the OS storage committing the changes of the git backed vaults
+	s, err := paw.NewOSStorage()
+	if err != nil {
+		return nil, err
+	}
+	return paw.NewGitStorage(s), nil
 }